@@ -0,0 +1,27 @@
+// Package chi provides an adapter to serve health check endpoints with the chi router.
+// It is provided as a separate module, so that the health package itself does not depend on chi.
+package chi
+
+import (
+	"net/http"
+
+	"github.com/alexliesenfeld/health"
+	chiv5 "github.com/go-chi/chi/v5"
+)
+
+// Handler creates a new http.HandlerFunc that serves the health check endpoint (see health.NewHandler).
+// Since chi is compatible with net/http, this is equivalent to health.NewHandler.
+func Handler(checker health.Checker, options ...health.HandlerOption) http.HandlerFunc {
+	return health.NewHandler(checker, options...)
+}
+
+// Route registers the health check endpoint at the provided pattern of the router for all HTTP methods
+// (e.g., to also accept refresh requests; see health.WithRefreshEnabled). Middleware that is registered
+// at the router is applied to the endpoint as well.
+//
+// Example:
+//
+//	chi.Route(router, "/health", checker)
+func Route(router chiv5.Router, pattern string, checker health.Checker, options ...health.HandlerOption) {
+	router.Handle(pattern, Handler(checker, options...))
+}
@@ -0,0 +1,55 @@
+package chi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	chiv5 "github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey struct{}
+
+func TestRoute(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if ctx.Value(contextKey{}) != "router middleware" {
+					return errors.New("request context was not passed on")
+				}
+				return nil
+			},
+		}),
+	)
+
+	router := chiv5.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, "router middleware")))
+		})
+	})
+	Route(router, "/health", checker, health.WithRefreshEnabled())
+
+	// Act
+	get := httptest.NewRecorder()
+	router.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/health", nil))
+	post := httptest.NewRecorder()
+	router.ServeHTTP(post, httptest.NewRequest(http.MethodPost, "/health?refresh=true", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, get.Code)
+	assert.Equal(t, "application/json; charset=utf-8", get.Header().Get("Content-Type"))
+	var result health.CheckerResult
+	assert.NoError(t, json.Unmarshal(get.Body.Bytes(), &result))
+	assert.Equal(t, health.StatusUp, result.Status)
+	assert.Equal(t, health.StatusUp, result.Details["database"].Status)
+	assert.Equal(t, http.StatusOK, post.Code)
+}
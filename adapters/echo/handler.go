@@ -0,0 +1,24 @@
+// Package echo provides an adapter to serve health check endpoints with the Echo web framework, version 4.x.
+// In contrast to health.NewHandlerEcho, the handler created by this package supports all handler options
+// (e.g., content negotiation and result writers) because it is based on health.NewHandler.
+package echo
+
+import (
+	"github.com/alexliesenfeld/health"
+	echov4 "github.com/labstack/echo/v4"
+)
+
+// Handler creates a new echo.HandlerFunc that serves the health check endpoint (see health.NewHandler).
+// The request context of the Echo request is passed on to the checker, so that values and deadlines set
+// by Echo middleware are available in check functions.
+//
+// Example:
+//
+//	e.GET("/health", echo.Handler(checker))
+func Handler(checker health.Checker, options ...health.HandlerOption) echov4.HandlerFunc {
+	handler := health.NewHandler(checker, options...)
+	return func(c echov4.Context) error {
+		handler.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
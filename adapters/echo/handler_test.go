@@ -0,0 +1,54 @@
+package echo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	echov4 "github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey struct{}
+
+func TestHandler(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if ctx.Value(contextKey{}) != "echo middleware" {
+					return errors.New("request context was not passed on")
+				}
+				return errors.New("connection refused")
+			},
+		}),
+	)
+
+	e := echov4.New()
+	e.Use(func(next echov4.HandlerFunc) echov4.HandlerFunc {
+		return func(c echov4.Context) error {
+			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), contextKey{}, "echo middleware")))
+			return next(c)
+		}
+	})
+	e.GET("/health", Handler(checker))
+
+	// Act
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header().Get("Content-Type"))
+
+	var result health.CheckerResult
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, "connection refused", result.Details["database"].Error.Error())
+}
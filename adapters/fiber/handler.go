@@ -0,0 +1,62 @@
+// Package fiber provides an adapter to serve health check endpoints with the Fiber web framework, version 2.x.
+// It is provided as a separate module, so that the health package itself does not depend on Fiber.
+package fiber
+
+import (
+	"net/http"
+
+	"github.com/alexliesenfeld/health"
+	fiberv2 "github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+// responseWriter is an http.ResponseWriter that writes to a Fiber response.
+type responseWriter struct {
+	ctx         *fiberv2.Ctx
+	header      http.Header
+	wroteHeader bool
+}
+
+// Handler creates a new fiber.Handler that serves the health check endpoint (see health.NewHandler).
+// The user context of the Fiber request (see fiber.Ctx.UserContext) is passed on to the checker,
+// so that values and deadlines set by Fiber middleware are available in check functions.
+//
+// Example:
+//
+//	app.Get("/health", fiber.Handler(checker))
+func Handler(checker health.Checker, options ...health.HandlerOption) fiberv2.Handler {
+	handler := health.NewHandler(checker, options...)
+	return func(c *fiberv2.Ctx) error {
+		var r http.Request
+		if err := fasthttpadaptor.ConvertRequest(c.Context(), &r, true); err != nil {
+			return err
+		}
+
+		handler.ServeHTTP(&responseWriter{ctx: c, header: http.Header{}}, r.WithContext(c.UserContext()))
+
+		return nil
+	}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	for key, values := range w.header {
+		for _, value := range values {
+			w.ctx.Response().Header.Add(key, value)
+		}
+	}
+	w.ctx.Status(statusCode)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.ctx.Write(data)
+}
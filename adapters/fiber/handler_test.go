@@ -0,0 +1,59 @@
+package fiber
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	fiberv2 "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contextKey struct{}
+
+func TestHandler(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if ctx.Value(contextKey{}) != "fiber middleware" {
+					return errors.New("request context was not passed on")
+				}
+				return errors.New("connection refused")
+			},
+		}),
+	)
+
+	app := fiberv2.New()
+	app.Use(func(c *fiberv2.Ctx) error {
+		c.SetUserContext(context.WithValue(c.UserContext(), contextKey{}, "fiber middleware"))
+		return c.Next()
+	})
+	app.Get("/health", Handler(checker))
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/health", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	var result health.CheckerResult
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, "connection refused", result.Details["database"].Error.Error())
+}
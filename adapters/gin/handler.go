@@ -0,0 +1,22 @@
+// Package gin provides an adapter to serve health check endpoints with the Gin web framework.
+// It is provided as a separate module, so that the health package itself does not depend on Gin.
+package gin
+
+import (
+	"github.com/alexliesenfeld/health"
+	gingonic "github.com/gin-gonic/gin"
+)
+
+// Handler creates a new gin.HandlerFunc that serves the health check endpoint (see health.NewHandler).
+// The request context of the Gin request is passed on to the checker, so that values and deadlines set
+// by Gin middleware are available in check functions.
+//
+// Example:
+//
+//	router.GET("/health", gin.Handler(checker))
+func Handler(checker health.Checker, options ...health.HandlerOption) gingonic.HandlerFunc {
+	handler := health.NewHandler(checker, options...)
+	return func(c *gingonic.Context) {
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,53 @@
+package gin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	gingonic "github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey struct{}
+
+func TestHandler(t *testing.T) {
+	// Arrange
+	gingonic.SetMode(gingonic.TestMode)
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if ctx.Value(contextKey{}) != "gin middleware" {
+					return errors.New("request context was not passed on")
+				}
+				return errors.New("connection refused")
+			},
+		}),
+	)
+
+	router := gingonic.New()
+	router.Use(func(c *gingonic.Context) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), contextKey{}, "gin middleware"))
+		c.Next()
+	})
+	router.GET("/health", Handler(checker))
+
+	// Act
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header().Get("Content-Type"))
+	var result health.CheckerResult
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, health.StatusDown, result.Status)
+	assert.Equal(t, "connection refused", result.Details["database"].Error.Error())
+}
@@ -0,0 +1,129 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	// AuditEntry describes a single status transition of the aggregated system status or of a component
+	// (see WithAuditSink).
+	AuditEntry struct {
+		// Timestamp holds the time when the transition was detected.
+		Timestamp time.Time `json:"timestamp"`
+		// Component is the name of the check whose status has changed.
+		// It is empty for transitions of the aggregated system status.
+		Component string `json:"component,omitempty"`
+		// PreviousStatus is the status before the transition.
+		PreviousStatus AvailabilityStatus `json:"previousStatus"`
+		// Status is the status after the transition.
+		Status AvailabilityStatus `json:"status"`
+		// Error contains the error message of the check that caused the transition, if the check failed.
+		Error string `json:"error,omitempty"`
+	}
+
+	// AuditSink receives the entries of the audit trail (see WithAuditSink).
+	AuditSink interface {
+		// Write appends an entry to the audit trail.
+		Write(ctx context.Context, entry AuditEntry) error
+	}
+
+	// AuditSinkFunc is an adapter to allow the use of ordinary functions as an AuditSink.
+	AuditSinkFunc func(ctx context.Context, entry AuditEntry) error
+
+	// JSONAuditSink is an AuditSink that writes each entry as a single line of JSON into an io.Writer,
+	// such as a file that was opened in append mode or a *syslog.Writer.
+	JSONAuditSink struct {
+		mtx sync.Mutex
+		w   io.Writer
+	}
+
+	auditPublisher struct {
+		*statusChangeDetector
+		sink AuditSink
+	}
+)
+
+// WithAuditSink records an audit trail of all status transitions of the aggregated system status and of each
+// component (see AuditEntry). Entries are passed to the sink asynchronously by a Publisher (see WithPublisher)
+// in the order the transitions occurred. Errors are passed to the publisher error handler
+// (see WithPublisherErrorHandler). This option can be used multiple times to write to more than one sink.
+func WithAuditSink(sink AuditSink) CheckerOption {
+	return WithPublisher(&auditPublisher{statusChangeDetector: newStatusChangeDetector(), sink: sink}, 100)
+}
+
+// Write implements AuditSink.Write.
+func (f AuditSinkFunc) Write(ctx context.Context, entry AuditEntry) error {
+	return f(ctx, entry)
+}
+
+// NewJSONAuditSink creates a new JSONAuditSink that writes into the provided io.Writer.
+func NewJSONAuditSink(w io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{w: w}
+}
+
+// Write implements AuditSink.Write.
+func (s *JSONAuditSink) Write(_ context.Context, entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("cannot marshal audit entry: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, err := s.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("cannot write audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// Publish implements Publisher.Publish. It is only called from a single goroutine
+// (see publisherWorker), so no synchronization is required.
+func (p *auditPublisher) Publish(ctx context.Context, state CheckerState) error {
+	payload, changed := p.detectChanges(state)
+	if !changed {
+		return nil
+	}
+
+	names := make([]string, 0, len(payload.Components))
+	for name := range payload.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		component := payload.Components[name]
+		entry := AuditEntry{
+			Timestamp:      state.CheckState[name].LastCheckedAt,
+			Component:      name,
+			PreviousStatus: component.PreviousStatus,
+			Status:         component.Status,
+			Error:          component.Error,
+		}
+
+		if entry.Timestamp.IsZero() {
+			entry.Timestamp = payload.Timestamp
+		}
+
+		if err := p.sink.Write(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	if payload.Status != payload.PreviousStatus {
+		return p.sink.Write(ctx, AuditEntry{
+			Timestamp:      payload.Timestamp,
+			PreviousStatus: payload.PreviousStatus,
+			Status:         payload.Status,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditSinkRecordsStatusTransitions(t *testing.T) {
+	// Arrange
+	entries := make(chan AuditEntry, 10)
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+		WithAuditSink(AuditSinkFunc(func(ctx context.Context, entry AuditEntry) error {
+			entries <- entry
+			return nil
+		})),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	var received []AuditEntry
+	for len(received) < 2 {
+		select {
+		case entry := <-entries:
+			received = append(received, entry)
+		case <-time.After(1 * time.Second):
+			require.Fail(t, "audit entries were not written")
+		}
+	}
+
+	assert.Equal(t, "database", received[0].Component)
+	assert.Equal(t, StatusUnknown, received[0].PreviousStatus)
+	assert.Equal(t, StatusDown, received[0].Status)
+	assert.Equal(t, "connection refused", received[0].Error)
+	assert.False(t, received[0].Timestamp.IsZero())
+	assert.Equal(t, "", received[1].Component)
+	assert.Equal(t, StatusDown, received[1].Status)
+}
+
+func TestJSONAuditSinkWritesJSONLines(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	sink := NewJSONAuditSink(&buf)
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	err1 := sink.Write(context.Background(), AuditEntry{Timestamp: timestamp, Component: "database",
+		PreviousStatus: StatusUp, Status: StatusDown, Error: "connection refused"})
+	err2 := sink.Write(context.Background(), AuditEntry{Timestamp: timestamp, PreviousStatus: StatusUp, Status: StatusDown})
+
+	// Assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"timestamp":"2024-01-01T00:00:00Z","component":"database","previousStatus":"up",`+
+		`"status":"down","error":"connection refused"}`, string(lines[0]))
+
+	var entry AuditEntry
+	require.NoError(t, json.Unmarshal(lines[1], &entry))
+	assert.Equal(t, AuditEntry{Timestamp: timestamp, PreviousStatus: StatusUp, Status: StatusDown}, entry)
+}
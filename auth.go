@@ -0,0 +1,63 @@
+package health
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// handlerAuthenticator authenticates requests to a Handler (see WithBasicAuth and WithBearerToken).
+type handlerAuthenticator struct {
+	// challenge is the value of the "WWW-Authenticate" header that is sent on authentication failure.
+	challenge    string
+	authenticate func(r *http.Request) bool
+}
+
+// isAuthenticated returns true if no authenticators are configured or if at least one of them
+// accepts the request.
+func isAuthenticated(r *http.Request, authenticators []handlerAuthenticator) bool {
+	if len(authenticators) == 0 {
+		return true
+	}
+
+	for _, authenticator := range authenticators {
+		if authenticator.authenticate(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeUnauthorized writes an HTTP 401 (Unauthorized) response that contains
+// the challenges of all authenticators.
+func writeUnauthorized(w http.ResponseWriter, authenticators []handlerAuthenticator) {
+	for _, authenticator := range authenticators {
+		w.Header().Add("WWW-Authenticate", authenticator.challenge)
+	}
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func basicAuthenticator(username string, passwordValid func(password string) bool) handlerAuthenticator {
+	return handlerAuthenticator{
+		challenge: `Basic realm="health", charset="UTF-8"`,
+		authenticate: func(r *http.Request) bool {
+			reqUsername, reqPassword, ok := r.BasicAuth()
+			return ok && subtle.ConstantTimeCompare([]byte(reqUsername), []byte(username)) == 1 && passwordValid(reqPassword)
+		},
+	}
+}
+
+func bearerTokenAuthenticator(tokenValid func(token string) bool) handlerAuthenticator {
+	return handlerAuthenticator{
+		challenge: `Bearer realm="health"`,
+		authenticate: func(r *http.Request) bool {
+			const prefix = "bearer "
+			header := r.Header.Get("Authorization")
+			if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+				return false
+			}
+			return tokenValid(strings.TrimSpace(header[len(prefix):]))
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerWithBasicAuthRejectsUnauthenticatedRequests(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(WithDisabledAutostart(), WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}}))
+	handler := NewHandler(ckr, WithBasicAuth("admin", func(password string) bool { return password == "secret" }))
+
+	authenticatedRequest := httptest.NewRequest(http.MethodGet, "/health", nil)
+	authenticatedRequest.SetBasicAuth("admin", "secret")
+	wrongPasswordRequest := httptest.NewRequest(http.MethodGet, "/health", nil)
+	wrongPasswordRequest.SetBasicAuth("admin", "wrong")
+
+	authenticatedResponse := httptest.NewRecorder()
+	wrongPasswordResponse := httptest.NewRecorder()
+	anonymousResponse := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(authenticatedResponse, authenticatedRequest)
+	handler.ServeHTTP(wrongPasswordResponse, wrongPasswordRequest)
+	handler.ServeHTTP(anonymousResponse, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, authenticatedResponse.Code)
+	assert.Equal(t, http.StatusUnauthorized, wrongPasswordResponse.Code)
+	assert.Equal(t, http.StatusUnauthorized, anonymousResponse.Code)
+	assert.Contains(t, anonymousResponse.Header().Get("WWW-Authenticate"), "Basic")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHandlerWithBearerTokenAcceptsValidTokens(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(WithDisabledAutostart())
+	handler := NewHandler(ckr,
+		WithBearerToken(func(token string) bool { return token == "valid-token" }),
+		WithBasicAuth("admin", func(password string) bool { return password == "secret" }),
+	)
+
+	validRequest := httptest.NewRequest(http.MethodGet, "/health", nil)
+	validRequest.Header.Set("Authorization", "Bearer valid-token")
+	invalidRequest := httptest.NewRequest(http.MethodGet, "/health", nil)
+	invalidRequest.Header.Set("Authorization", "Bearer invalid-token")
+
+	validResponse := httptest.NewRecorder()
+	invalidResponse := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(validResponse, validRequest)
+	handler.ServeHTTP(invalidResponse, invalidRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, validResponse.Code)
+	assert.Equal(t, http.StatusUnauthorized, invalidResponse.Code)
+	assert.Len(t, invalidResponse.Header().Values("WWW-Authenticate"), 2)
+}
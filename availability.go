@@ -0,0 +1,206 @@
+package health
+
+import (
+	"strings"
+	"time"
+)
+
+type (
+	// SLOPolicy defines an availability target (service level objective) for a check (see Check.SLO).
+	SLOPolicy struct {
+		// Target is the minimum fraction of time the check must be available (e.g., 0.999 for 99.9%)
+		// within Window. A value of 0 disables the target (default).
+		Target float64
+		// Window is the rolling time window in which the availability is measured. Default is 24 hours.
+		Window time.Duration
+	}
+
+	// availabilityTracker records the points in time when the availability of a check changed,
+	// so that its availability can be calculated for any rolling window (see Checker.GetAvailability).
+	availabilityTracker struct {
+		segments []availabilitySegment
+	}
+
+	// availabilitySegment starts at the given time and lasts until the next segment starts.
+	availabilitySegment struct {
+		start     time.Time
+		measured  bool
+		available bool
+	}
+)
+
+const defaultSLOWindow = 24 * time.Hour
+
+// WithAvailabilityWindows enables availability tracking for all checks. The availability of a check is the
+// fraction of time it was available within a rolling time window. A check is considered available while its
+// status is StatusUp or StatusDegraded and unavailable while its status is StatusDown. The time in any other
+// status (e.g., StatusUnknown or StatusSkipped) is not taken into account. The availability for each of the
+// provided windows is reported in the component details of every CheckerResult (see CheckResult.Availability)
+// and can be retrieved for any window using Checker.GetAvailability. If no window is provided, the windows
+// 1 hour, 24 hours and 30 days are used. Availability tracking is disabled by default, unless a check
+// has an availability target (see Check.SLO).
+func WithAvailabilityWindows(windows ...time.Duration) CheckerOption {
+	return func(cfg *checkerConfig) {
+		if len(windows) == 0 {
+			windows = []time.Duration{1 * time.Hour, 24 * time.Hour, 30 * 24 * time.Hour}
+		}
+		cfg.availabilityWindows = windows
+	}
+}
+
+// GetAvailability implements Checker.GetAvailability. Please refer to Checker.GetAvailability for more information.
+func (ck *defaultChecker) GetAvailability(name string, window time.Duration) (float64, bool) {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	tracker, ok := ck.availability[name]
+	if !ok {
+		return 0, false
+	}
+
+	return tracker.availability(ck.cfg.clock.Now(), window)
+}
+
+// trackAvailability records the status of the check in its availability tracker and reports the
+// check as degraded if its availability target has been breached (see Check.SLO).
+// Must be called while holding the state lock.
+func (ck *defaultChecker) trackAvailability(update *checkResult) {
+	check, ok := ck.cfg.checks[update.checkName]
+	if !ok || (len(ck.cfg.availabilityWindows) == 0 && check.SLO.Target <= 0) {
+		return
+	}
+
+	tracker, ok := ck.availability[update.checkName]
+	if !ok {
+		tracker = &availabilityTracker{}
+		ck.availability[update.checkName] = tracker
+	}
+
+	now := ck.cfg.clock.Now()
+	tracker.record(now, update.newState.Status, ck.maxAvailabilityWindow(check))
+
+	update.newState.SLOBreached = false
+	if check.SLO.Target > 0 && update.newState.Status == StatusUp {
+		availability, ok := tracker.availability(now, check.SLO.window())
+		update.newState.SLOBreached = ok && availability < check.SLO.Target
+		if update.newState.SLOBreached {
+			update.newState.Status = StatusDegraded
+		}
+	}
+}
+
+// availabilityReport returns the availability of the check for each configured window (see WithAvailabilityWindows).
+// Must be called while holding ck.mtx or the state lock.
+func (ck *defaultChecker) availabilityReport(name string) map[string]float64 {
+	tracker, ok := ck.availability[name]
+	if !ok || len(ck.cfg.availabilityWindows) == 0 {
+		return nil
+	}
+
+	var (
+		now    = ck.cfg.clock.Now()
+		report = make(map[string]float64, len(ck.cfg.availabilityWindows))
+	)
+
+	for _, window := range ck.cfg.availabilityWindows {
+		if availability, ok := tracker.availability(now, window); ok {
+			report[formatWindow(window)] = availability
+		}
+	}
+
+	if len(report) == 0 {
+		return nil
+	}
+
+	return report
+}
+
+func (ck *defaultChecker) maxAvailabilityWindow(check *Check) time.Duration {
+	var maxWindow time.Duration
+	if check.SLO.Target > 0 {
+		maxWindow = check.SLO.window()
+	}
+
+	for _, window := range ck.cfg.availabilityWindows {
+		if window > maxWindow {
+			maxWindow = window
+		}
+	}
+
+	return maxWindow
+}
+
+func (p *SLOPolicy) window() time.Duration {
+	if p.Window <= 0 {
+		return defaultSLOWindow
+	}
+	return p.Window
+}
+
+// record starts a new segment if the availability of the check has changed. Segments that ended
+// before the beginning of the largest window are discarded.
+func (t *availabilityTracker) record(now time.Time, status AvailabilityStatus, maxWindow time.Duration) {
+	segment := availabilitySegment{
+		start:     now,
+		measured:  status == StatusUp || status == StatusDegraded || status == StatusDown,
+		available: status == StatusUp || status == StatusDegraded,
+	}
+
+	last := len(t.segments) - 1
+	if last < 0 || t.segments[last].measured != segment.measured || t.segments[last].available != segment.available {
+		t.segments = append(t.segments, segment)
+	}
+
+	cutoff := now.Add(-maxWindow)
+	for len(t.segments) > 1 && !t.segments[1].start.After(cutoff) {
+		t.segments = t.segments[1:]
+	}
+}
+
+// availability returns the fraction of the measured time within the window during which the check was available.
+// The second return value is false if no time has been measured within the window yet.
+func (t *availabilityTracker) availability(now time.Time, window time.Duration) (float64, bool) {
+	var (
+		from                = now.Add(-window)
+		measured, available time.Duration
+	)
+
+	for i, segment := range t.segments {
+		end := now
+		if i+1 < len(t.segments) {
+			end = t.segments[i+1].start
+		}
+
+		start := segment.start
+		if start.Before(from) {
+			start = from
+		}
+
+		if !segment.measured || !end.After(start) {
+			continue
+		}
+
+		measured += end.Sub(start)
+		if segment.available {
+			available += end.Sub(start)
+		}
+	}
+
+	if measured == 0 {
+		return 0, false
+	}
+
+	return float64(available) / float64(measured), true
+}
+
+// formatWindow formats a window without trailing zero units (e.g., "24h" instead of "24h0m0s").
+func formatWindow(window time.Duration) string {
+	s := window.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
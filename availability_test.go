@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailabilityIsTrackedForRollingWindows(t *testing.T) {
+	// Arrange
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	failing := false
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithAvailabilityWindows(1*time.Hour, 24*time.Hour),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			if failing {
+				return errors.New("connection refused")
+			}
+			return nil
+		}}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	clock.advance(3 * time.Hour)
+	failing = true
+	ckr.Check(context.Background())
+	clock.advance(1 * time.Hour)
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, map[string]float64{"1h": 0, "24h": 0.75}, res.Details["database"].Availability)
+
+	availability, ok := ckr.GetAvailability("database", 2*time.Hour)
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, availability)
+
+	_, ok = ckr.GetAvailability("unknown", 1*time.Hour)
+	assert.False(t, ok)
+}
+
+func TestAvailabilityIsNotReportedWithoutMeasuredTime(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithClock(&manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}),
+		WithAvailabilityWindows(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Nil(t, res.Details["database"].Availability)
+}
+
+func TestSLOBreachDegradesStatus(t *testing.T) {
+	// Arrange
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	failing := true
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithCheck(Check{
+			Name: "database",
+			SLO:  SLOPolicy{Target: 0.9, Window: 1 * time.Hour},
+			Check: func(ctx context.Context) error {
+				if failing {
+					return errors.New("connection refused")
+				}
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	clock.advance(10 * time.Minute)
+	failing = false
+	ckr.Check(context.Background())
+	clock.advance(10 * time.Minute)
+	breached := ckr.Check(context.Background())
+	clock.advance(2 * time.Hour)
+	recovered := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDegraded, breached.Status)
+	assert.Equal(t, StatusDegraded, breached.Details["database"].Status)
+	assert.Equal(t, StatusUp, recovered.Status)
+
+	state, _ := ckr.GetCheckState("database")
+	assert.False(t, state.SLOBreached)
+}
+
+func TestFormatWindow(t *testing.T) {
+	assert.Equal(t, "1h", formatWindow(1*time.Hour))
+	assert.Equal(t, "720h", formatWindow(30*24*time.Hour))
+	assert.Equal(t, "30m", formatWindow(30*time.Minute))
+	assert.Equal(t, "1h30m", formatWindow(90*time.Minute))
+	assert.Equal(t, "45s", formatWindow(45*time.Second))
+}
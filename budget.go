@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// deadlineBudget splits the time until the deadline of a check run across the remaining
+// execution stages (see WithDeadlineBudget). A nil *deadlineBudget does not set any deadlines.
+type deadlineBudget struct {
+	// stages holds the number of sequential execution stages per dependency level.
+	stages []int
+	// batchSize is the number of checks that are executed at the same time, or 0 if unlimited.
+	batchSize int
+}
+
+// newDeadlineBudget creates a deadlineBudget for a check run that executes all checks
+// that are due (see isDue). Must be called while holding ck.mtx.
+func (ck *defaultChecker) newDeadlineBudget(filter CheckFilter, force bool) *deadlineBudget {
+	budget := deadlineBudget{
+		stages:    make([]int, len(ck.checkLevels)),
+		batchSize: int(ck.cfg.maxConcurrentChecks),
+	}
+
+	if ck.cfg.sequentialExecution {
+		budget.batchSize = 1
+	}
+
+	for level, checks := range ck.checkLevels {
+		numDueChecks := 0
+		for _, check := range checks {
+			if ck.isDue(check, filter, force) {
+				numDueChecks++
+			}
+		}
+
+		budget.stages[level] = budget.numStages(numDueChecks)
+	}
+
+	return &budget
+}
+
+// numStages returns the number of sequential stages required to execute n checks.
+func (b *deadlineBudget) numStages(n int) int {
+	if n == 0 {
+		return 0
+	}
+
+	if b.batchSize <= 0 {
+		return 1
+	}
+
+	return (n + b.batchSize - 1) / b.batchSize
+}
+
+// withDeadline returns a context whose deadline leaves enough time for the stages that follow
+// the stage of the index-th check of the provided level. The returned context.CancelFunc must be
+// called when the check has been executed. If ctx has no deadline, it is returned as is.
+func (b *deadlineBudget) withDeadline(ctx context.Context, level, index int) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if b == nil || !ok {
+		return ctx, func() {}
+	}
+
+	remainingStages := b.stages[level] - b.numStages(index+1) + 1
+	for _, stages := range b.stages[level+1:] {
+		remainingStages += stages
+	}
+
+	if remainingStages <= 1 {
+		return ctx, func() {}
+	}
+
+	budget := time.Until(deadline) / time.Duration(remainingStages)
+
+	return context.WithTimeout(ctx, budget)
+}
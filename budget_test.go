@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadlineBudgetSplitsTimeoutAcrossDependencyLevels(t *testing.T) {
+	// Arrange
+	var databaseDeadline, apiDeadline time.Time
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithTimeout(1*time.Second),
+		WithDeadlineBudget(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			databaseDeadline, _ = ctx.Deadline()
+			return nil
+		}}),
+		WithCheck(Check{Name: "api", DependsOn: []string{"database"}, Check: func(ctx context.Context) error {
+			apiDeadline, _ = ctx.Deadline()
+			return nil
+		}}),
+	)
+
+	// Act
+	startedAt := time.Now()
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, res.Status)
+	assert.InDelta(t, 500*time.Millisecond, databaseDeadline.Sub(startedAt), float64(100*time.Millisecond))
+	assert.InDelta(t, 1*time.Second, apiDeadline.Sub(startedAt), float64(100*time.Millisecond))
+}
+
+func TestDeadlineBudgetCountsBatchesAsStages(t *testing.T) {
+	// Arrange
+	budget := &deadlineBudget{stages: []int{2, 1}, batchSize: 2}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	// Act
+	firstBatchCtx, firstCancel := budget.withDeadline(ctx, 0, 1)
+	defer firstCancel()
+	secondBatchCtx, secondCancel := budget.withDeadline(ctx, 0, 2)
+	defer secondCancel()
+	lastLevelCtx, lastCancel := budget.withDeadline(ctx, 1, 0)
+	defer lastCancel()
+
+	// Assert
+	firstDeadline, _ := firstBatchCtx.Deadline()
+	secondDeadline, _ := secondBatchCtx.Deadline()
+	lastDeadline, _ := lastLevelCtx.Deadline()
+	assert.InDelta(t, 1*time.Second, time.Until(firstDeadline), float64(100*time.Millisecond))
+	assert.InDelta(t, 1500*time.Millisecond, time.Until(secondDeadline), float64(100*time.Millisecond))
+	assert.InDelta(t, 3*time.Second, time.Until(lastDeadline), float64(100*time.Millisecond))
+}
+
+func TestNilDeadlineBudgetKeepsContextDeadline(t *testing.T) {
+	// Arrange
+	var budget *deadlineBudget
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// Act
+	budgetCtx, budgetCancel := budget.withDeadline(ctx, 0, 0)
+	defer budgetCancel()
+
+	// Assert
+	assert.Equal(t, ctx, budgetCtx)
+}
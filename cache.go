@@ -0,0 +1,52 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ResultCache is a cache for check results that can be shared by multiple Checker instances (e.g., by all
+// instances of a horizontally scaled service), so that expensive checks of shared dependencies are only executed
+// by one instance per cache period (see WithResultCache and Check.SharedCache). A ResultCache implementation
+// must be safe for concurrent use.
+type ResultCache interface {
+	// Get returns the cached state of the check with the given name. The second
+	// return value reports whether a cached state was found.
+	Get(ctx context.Context, checkName string) (CheckState, bool, error)
+	// Set stores the state of the check with the given name. The state is expected
+	// to be evicted from the cache after the provided TTL has passed.
+	Set(ctx context.Context, checkName string, state CheckState, ttl time.Duration) error
+}
+
+// loadSharedState returns the state of the check from the shared result cache (see WithResultCache),
+// if the check uses the shared cache and the cached state has not expired yet.
+func (ck *defaultChecker) loadSharedState(ctx context.Context, check *Check) (CheckState, bool) {
+	if ck.cfg.resultCache == nil || !check.SharedCache {
+		return CheckState{}, false
+	}
+
+	state, ok, err := ck.cfg.resultCache.Get(ctx, check.Name)
+	if err != nil {
+		ck.handleErr(fmt.Errorf("cannot load result of check %q from cache: %w", check.Name, err))
+		return CheckState{}, false
+	}
+
+	if !ok || isCacheExpired(ck.cfg.clock.Now(), ck.cacheTTL(check), &state) {
+		return CheckState{}, false
+	}
+
+	return state, true
+}
+
+// storeSharedState stores the state of the check in the shared result cache (see WithResultCache),
+// if the check uses the shared cache.
+func (ck *defaultChecker) storeSharedState(ctx context.Context, check *Check, state CheckState) {
+	if ck.cfg.resultCache == nil || !check.SharedCache {
+		return
+	}
+
+	if err := ck.cfg.resultCache.Set(ctx, check.Name, state, ck.cacheTTL(check)); err != nil {
+		ck.handleErr(fmt.Errorf("cannot store result of check %q in cache: %w", check.Name, err))
+	}
+}
@@ -0,0 +1,136 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mapResultCache struct {
+	mtx    sync.Mutex
+	states map[string]CheckState
+	ttls   map[string]time.Duration
+	err    error
+}
+
+func newMapResultCache() *mapResultCache {
+	return &mapResultCache{states: map[string]CheckState{}, ttls: map[string]time.Duration{}}
+}
+
+func (c *mapResultCache) Get(_ context.Context, checkName string) (CheckState, bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	state, ok := c.states[checkName]
+	return state, ok, c.err
+}
+
+func (c *mapResultCache) Set(_ context.Context, checkName string, state CheckState, ttl time.Duration) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.states[checkName] = state
+	c.ttls[checkName] = ttl
+	return c.err
+}
+
+func TestSharedResultCacheIsUsedAcrossCheckers(t *testing.T) {
+	// Arrange
+	var calls int32
+	cache := newMapResultCache()
+	newTestChecker := func() Checker {
+		return NewChecker(
+			WithDisabledAutostart(),
+			WithCacheDuration(1*time.Hour),
+			WithResultCache(cache),
+			WithCheck(Check{Name: "database", SharedCache: true, Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return errors.New("connection refused")
+			}}),
+		)
+	}
+
+	// Act
+	first := newTestChecker().Check(context.Background())
+	second := newTestChecker().Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, StatusDown, first.Status)
+	assert.Equal(t, StatusDown, second.Status)
+	assert.EqualError(t, second.Details["database"].Error, "connection refused")
+	assert.Equal(t, 1*time.Hour, cache.ttls["database"])
+}
+
+func TestSharedResultCacheIgnoresExpiredResults(t *testing.T) {
+	// Arrange
+	var calls int32
+	cache := newMapResultCache()
+	cache.states["database"] = CheckState{Status: StatusDown, LastCheckedAt: time.Now().Add(-2 * time.Hour)}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithResultCache(cache),
+		WithCheck(Check{Name: "database", SharedCache: true, Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Equal(t, StatusUp, cache.states["database"].Status)
+}
+
+func TestSharedResultCacheIsOnlyUsedForOptedInChecks(t *testing.T) {
+	// Arrange
+	var calls int32
+	cache := newMapResultCache()
+	cache.states["database"] = CheckState{Status: StatusDown, LastCheckedAt: time.Now()}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithResultCache(cache),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, StatusUp, res.Status)
+}
+
+func TestSharedResultCacheErrorsArePassedToErrorHandler(t *testing.T) {
+	// Arrange
+	var errCount int32
+	cache := newMapResultCache()
+	cache.err = errors.New("cache unavailable")
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithResultCache(cache),
+		WithPublisherErrorHandler(func(err error) {
+			atomic.AddInt32(&errCount, 1)
+		}),
+		WithCheck(Check{Name: "database", SharedCache: true, Check: func(ctx context.Context) error {
+			return nil
+		}}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&errCount))
+}
@@ -5,30 +5,84 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"runtime/debug"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
+
+	"github.com/robfig/cron/v3"
 )
 
 type (
 	checkerConfig struct {
-		timeout              time.Duration
-		info                 map[string]interface{}
-		checks               map[string]*Check
-		cacheTTL             time.Duration
-		statusChangeListener func(context.Context, CheckerState)
-		interceptors         []Interceptor
-		detailsDisabled      bool
-		autostartDisabled    bool
+		timeout                  time.Duration
+		info                     map[string]interface{}
+		checks                   map[string]*Check
+		cacheTTL                 time.Duration
+		statusChangeListener     func(context.Context, CheckerState)
+		statusTransitionListener func(ctx context.Context, oldState, newState CheckerState)
+		interceptors             []Interceptor
+		detailsDisabled          bool
+		autostartDisabled        bool
+		publishers               []publisherConfig
+		publisherErrHandler      func(err error)
+		historySize              uint
+		flapDetection            *flapDetectionConfig
+		intervalJitter           time.Duration
+		startupGracePeriod       time.Duration
+		aggregator               AggregationFunc
+		stateStore               StateStore
+		resultCache              ResultCache
+		panicHandler             func(checkName string, err *PanicError)
+		maxConcurrentChecks      uint
+		deadlineBudget           bool
+		sequentialExecution      bool
+		clock                    Clock
+		staleAfter               time.Duration
+		singleFlight             bool
+		logger                   Logger
+		listenerTimeout          time.Duration
+		listenerQueue            chan func()
+		configChangeListener     func(ctx context.Context, change ConfigChange)
+		lifecycleHooks           []LifecycleHooks
+		silences                 *silenceRegistry
+		availabilityWindows      []time.Duration
+		incidentStatsInResults   bool
+		errorSanitizer           func(error) string
+		toggleProvider           ToggleProvider
 	}
 
 	defaultChecker struct {
-		started            bool
-		mtx                sync.Mutex
-		cfg                checkerConfig
-		state              CheckerState
-		wg                 sync.WaitGroup
-		cancel             context.CancelFunc
-		periodicCheckCount int
+		started          bool
+		paused           bool
+		mtx              sync.Mutex
+		stateMtx         sync.RWMutex
+		cfg              checkerConfig
+		state            CheckerState
+		wg               sync.WaitGroup
+		ctx              context.Context
+		cancel           context.CancelFunc
+		periodicChecks   map[string]context.CancelFunc
+		checkLevels      [][]*Check
+		publisherWorkers []*publisherWorker
+		history          map[string]*checkHistory
+		startedAt        time.Time
+		startupDeadline  time.Time
+		unhealthyReason  string
+		stateRestored    bool
+		subscribers      map[chan CheckerState]struct{}
+		nextRuns         map[*Check]time.Time
+		inFlight         map[string]struct{}
+		maintenance      map[string]Maintenance
+		availability     map[string]*availabilityTracker
+		incidents        map[string]*incidentTracker
+		disabled         map[string]struct{}
+		revalidation     chan struct{}
+		systemIncidents  incidentTracker
 	}
 
 	checkResult struct {
@@ -37,9 +91,21 @@ type (
 	}
 
 	jsonCheckResult struct {
-		Status    string    `json:"status"`
-		Timestamp time.Time `json:"timestamp,omitempty"`
-		Error     string    `json:"error,omitempty"`
+		Status       string                 `json:"status"`
+		Timestamp    time.Time              `json:"timestamp,omitempty"`
+		Error        string                 `json:"error,omitempty"`
+		Code         string                 `json:"code,omitempty"`
+		Data         map[string]interface{} `json:"data,omitempty"`
+		History      []CheckHistoryEntry    `json:"history,omitempty"`
+		Metadata     map[string]interface{} `json:"metadata,omitempty"`
+		TimedOut     bool                   `json:"timedOut,omitempty"`
+		Duration     string                 `json:"duration,omitempty"`
+		Attempts     uint                   `json:"attempts,omitempty"`
+		Maintenance  *Maintenance           `json:"maintenance,omitempty"`
+		Silenced     bool                   `json:"silenced,omitempty"`
+		Availability map[string]float64     `json:"availability,omitempty"`
+		Incidents    *IncidentStats         `json:"incidents,omitempty"`
+		Truncated    bool                   `json:"truncated,omitempty"`
 	}
 
 	// Checker is the main checker interface. It provides all health checking logic.
@@ -47,8 +113,19 @@ type (
 		// Start will start all necessary background workers and prepare
 		// the checker for further usage.
 		Start()
-		// Stop stops will stop the checker.
+		// Stop stops will stop the checker. It stops all periodic checks and waits
+		// until all currently running periodic check functions have completed.
+		// Calling Stop on a Checker that is not started has no effect.
 		Stop()
+		// Pause temporarily suspends the execution of all check functions (e.g., during a
+		// maintenance window) without stopping the Checker. While paused, Checker.Check
+		// reports the aggregated status StatusMaintenance along with the last known check states.
+		Pause()
+		// Resume resumes the execution of check functions after the Checker was paused
+		// (see Checker.Pause).
+		Resume()
+		// IsPaused returns true, if the Checker is currently paused (see Checker.Pause).
+		IsPaused() bool
 		// Check runs all synchronous (i.e., non-periodic) check functions.
 		// It returns the aggregated health status (combined from the results
 		// of this executions synchronous checks and the previously reported
@@ -57,12 +134,130 @@ type (
 		// The context will be passed to all downstream calls
 		// (such as listeners, component check functions, and interceptors).
 		Check(ctx context.Context) CheckerResult
+		// CheckWithFilter works like Checker.Check, but only executes, aggregates and reports
+		// the checks that are accepted by the provided CheckFilter. The aggregated status of
+		// the returned CheckerResult only reflects the state of the filtered checks.
+		// The results are stored in the same cache that is used by Checker.Check.
+		// If filter is nil, this function behaves exactly like Checker.Check.
+		CheckWithFilter(ctx context.Context, filter CheckFilter) CheckerResult
+		// CheckNow forces an immediate execution of the check with the given name, regardless of whether
+		// its result is still cached or whether it is a periodic check, and returns its fresh result.
+		// This is useful to re-evaluate a component right after a dependency was fixed instead of waiting
+		// for the next periodic execution. Dependency rules still apply (see Check.DependsOn). It returns
+		// an error if no check with this name exists. If the Checker is paused (see Checker.Pause),
+		// the check is not executed and its last known result is returned.
+		CheckNow(ctx context.Context, name string) (CheckResult, error)
+		// CheckAllNow works like Checker.Check, but forces an immediate execution of all checks
+		// (including periodic checks), regardless of whether their results are still cached (see Checker.CheckNow).
+		CheckAllNow(ctx context.Context) CheckerResult
+		// GetCheckNames returns the names of all configured checks that are accepted
+		// by the provided CheckFilter in alphabetical order. If filter is nil,
+		// the names of all configured checks are returned.
+		GetCheckNames(filter CheckFilter) []string
 		// GetRunningPeriodicCheckCount returns the number of currently
 		// running periodic checks.
 		GetRunningPeriodicCheckCount() int
+		// GetRunningPeriodicCheckNames returns the names of all currently
+		// running periodic checks in alphabetical order.
+		GetRunningPeriodicCheckNames() []string
+		// GetCheckState returns the last known state of the check with the
+		// given name. The second return value reports whether a check with
+		// this name exists. This function never executes any check function.
+		// It returns the same cached state that Checker.Check would use, so for
+		// synchronous checks the state may be older than the cache TTL if
+		// Checker.Check was not called for a while (see CheckState.LastCheckedAt).
+		// It is safe to call this function concurrently with running checks.
+		GetCheckState(name string) (CheckState, bool)
+		// GetCheckHistory returns the most recent evaluation results of the check with the given name,
+		// ordered from oldest to newest. The second return value reports whether a check with this
+		// name exists. The history is empty, unless it was enabled using WithHistorySize.
+		// It is safe to call this function concurrently with running checks.
+		GetCheckHistory(name string) ([]CheckHistoryEntry, bool)
+		// AddCheck adds a new synchronous check at runtime (see WithCheck). It returns an error
+		// if a check with the same name already exists or if the check depends on unknown checks
+		// (see Check.DependsOn). It is safe to call this function after the Checker was started.
+		AddCheck(check Check) error
+		// AddPeriodicCheck adds a new periodic check at runtime (see WithPeriodicCheck). If the Checker
+		// is already started, the periodic check is started immediately. It returns an error
+		// if a check with the same name already exists or if the check depends on unknown checks
+		// (see Check.DependsOn).
+		AddPeriodicCheck(refreshPeriod time.Duration, initialDelay time.Duration, check Check) error
+		// RemoveCheck removes the check with the given name at runtime. If it is a periodic check,
+		// its background goroutine is stopped. A check function that is currently being executed
+		// will complete in the background, but its result will be discarded. The resources of checks that
+		// were created from a declarative configuration are released (see DisposableCheckFactory). It returns
+		// an error if no check with this name exists or if other checks depend on it (see Check.DependsOn).
+		RemoveCheck(name string) error
 		// IsStarted returns true, if the Checker was started (see Checker.Start)
 		// and is currently still running. Returns false otherwise.
 		IsStarted() bool
+		// SetUnhealthy immediately marks the system as unavailable, so that the aggregated status is reported as
+		// StatusDown regardless of the results of the individual checks (e.g., to let load balancers drain traffic
+		// before the process terminates). The provided reason is available in CheckerState.UnhealthyReason.
+		// Status listeners are notified about the status change. This cannot be undone.
+		SetUnhealthy(reason string)
+		// Shutdown marks the system as unavailable (see Checker.SetUnhealthy) and stops the Checker
+		// (see Checker.Stop). It returns the context error if the context is done before all periodic
+		// checks have completed. Checker.Check can still be used after Shutdown to report the status.
+		Shutdown(ctx context.Context) error
+		// Subscribe returns a channel that receives a copy of the CheckerState after every check evaluation,
+		// starting with the current state. This allows to observe status changes after the Checker was
+		// created (e.g., for every client of a streaming endpoint; see NewEventStreamHandler). The channel
+		// buffers up to queueSize states. States are dropped if the buffer is full, so a slow subscriber
+		// does not stall check execution. The channel is closed when the context is done.
+		Subscribe(ctx context.Context, queueSize int) <-chan CheckerState
+		// GetState returns a deep copy of the current CheckerState, including the state of all checks.
+		// The result can be modified freely without affecting the Checker. This function never executes
+		// any check function. It is safe to call this function concurrently with running checks.
+		GetState() CheckerState
+		// ListChecks returns a summary of the configuration of all checks in alphabetical order, including
+		// the next scheduled execution time of periodic checks (see CheckInfo). This function never executes
+		// any check function. It is safe to call this function concurrently with running checks.
+		ListChecks() []CheckInfo
+		// Reload applies a new declarative configuration to a Checker that was created using
+		// NewCheckerFromConfig. Checks that were created from the configuration are added, removed or
+		// replaced according to the new configuration, all at once. Periodic checks are restarted if
+		// their configuration has changed. The state of checks whose configuration has changed is retained.
+		// Checks whose configuration has not changed are not created again. The resources of replaced and
+		// removed checks are released (see DisposableCheckFactory).
+		// Checks that were configured in code are not affected. The global settings of the configuration
+		// (e.g., the timeout) replace the current settings. Listeners are notified about the changes
+		// (see WithConfigChangeListener). Nothing is changed if the configuration is invalid.
+		Reload(cfg CheckerConfig) error
+		// SetMaintenance puts the check with the given name into maintenance (e.g., during a planned downtime of
+		// a dependency) until the provided time, or until Checker.ClearMaintenance is called if until is zero.
+		// During maintenance, the check function is not executed, the check does not contribute to the aggregated
+		// status, and the check is reported with status StatusMaintenance along with the maintenance details
+		// (see CheckResult.Maintenance). It returns an error if no check with this name exists.
+		SetMaintenance(name string, until time.Time, reason string) error
+		// ClearMaintenance ends the maintenance of the check with the given name (see Checker.SetMaintenance).
+		// It returns an error if no check with this name exists.
+		ClearMaintenance(name string) error
+		// AddSilence adds a silence that suppresses status notifications of the matching checks within
+		// a time window (see Silence). It returns an ID that can be used to remove the silence.
+		AddSilence(silence Silence) string
+		// RemoveSilence removes the silence with the given ID (see Checker.AddSilence and WithSilence).
+		// It returns false if no silence with this ID exists.
+		RemoveSilence(id string) bool
+		// GetAvailability returns the fraction of time (between 0 and 1) the check with the given name was
+		// available within the provided rolling window (see WithAvailabilityWindows). The second return value
+		// is false if the check does not exist or if no availability data has been recorded within the window.
+		GetAvailability(name string, window time.Duration) (float64, bool)
+		// GetIncidentStats returns the downtime statistics of the check with the given name, such as the
+		// number of incidents, the cumulative downtime and the mean time to recovery (see IncidentStats).
+		// The second return value is false if the check does not exist.
+		GetIncidentStats(name string) (IncidentStats, bool)
+		// GetSystemIncidentStats returns the downtime statistics of the aggregated system status (see IncidentStats).
+		GetSystemIncidentStats() IncidentStats
+		// DisableCheck switches off the check with the given name until Checker.EnableCheck is called
+		// (see also WithToggleProvider). The check function of a disabled check is not executed, the check
+		// does not contribute to the aggregated status, and the check is reported with status StatusDisabled
+		// along with its last known state. It returns an error if no check with this name exists.
+		DisableCheck(name string) error
+		// EnableCheck switches the check with the given name on again after it was disabled using
+		// Checker.DisableCheck. It does not enable checks that are disabled by the ToggleProvider
+		// (see WithToggleProvider). It returns an error if no check with this name exists.
+		EnableCheck(name string) error
 	}
 
 	// CheckerState represents the current state of the Checker.
@@ -71,13 +266,17 @@ type (
 		Status AvailabilityStatus
 		// CheckState contains the state of all checks.
 		CheckState map[string]CheckState
+		// UnhealthyReason holds the reason why the system was marked as unavailable
+		// (see Checker.SetUnhealthy). It is empty if the system was not marked as unavailable.
+		UnhealthyReason string
 	}
 
 	// CheckState represents the current state of a component check.
 	CheckState struct {
 		// LastCheckedAt holds the time of when the check was last executed.
 		LastCheckedAt time.Time
-		// LastCheckedAt holds the last time of when the check did not return an error.
+		// LastCheckedAt holds the last time of when the check did not return an error
+		// (or returned a DegradedError).
 		LastSuccessAt time.Time
 		// LastFailureAt holds the last time of when the check did return an error.
 		LastFailureAt time.Time
@@ -85,10 +284,34 @@ type (
 		FirstCheckStartedAt time.Time
 		// ContiguousFails holds the number of how often the check failed in a row.
 		ContiguousFails uint
+		// CheckCount holds the total number of check executions.
+		CheckCount uint
+		// LastCheckDuration holds how long the last check execution took (including retries).
+		LastCheckDuration time.Duration
 		// Result holds the error of the last check (nil if successful).
 		Result error
+		// Data holds the data that was reported by the last check (see Check.CheckWithData).
+		Data map[string]interface{}
 		// The current availability status of the check.
 		Status AvailabilityStatus
+		// Flapping is true if the status of the check changed too often within a short period of time
+		// (see WithFlapDetection). While a check is flapping, its status is reported as StatusDegraded.
+		Flapping bool
+		// TimedOut is true if the last check execution did not complete before its timeout
+		// (see Check.Timeout and WithTimeout). In this case, Result wraps CheckTimeoutErr.
+		TimedOut bool
+		// Attempts holds how often the check function was called during the last check execution,
+		// including retries (see Check.Retry).
+		Attempts uint
+		// Silenced is true if the check was silenced when this state was recorded (see Silence).
+		Silenced bool
+		// SLOBreached is true if the availability of the check is below its availability target (see Check.SLO).
+		// In this case, the status is reported as StatusDegraded instead of StatusUp.
+		SLOBreached bool
+
+		evaluatedStatus AvailabilityStatus
+		statusChanges   []time.Time
+		recentFailures  []bool
 	}
 
 	// CheckerResult holds the aggregated system availability status and
@@ -100,6 +323,15 @@ type (
 		Status AvailabilityStatus `json:"status"`
 		// Details contains health information for all checked components.
 		Details map[string]CheckResult `json:"details,omitempty"`
+		// Stale is true if the check run did not complete in time and the last known
+		// results are reported instead (see WithStaleWhileRevalidate).
+		Stale bool `json:"stale,omitempty"`
+		// Incidents holds the downtime statistics of the aggregated system status (see WithIncidentStats).
+		// It is not set for results of a subset of all checks (see Checker.CheckWithFilter).
+		Incidents *IncidentStats `json:"incidents,omitempty"`
+		// OmittedDetails holds the number of components that were omitted from Details,
+		// because the result exceeded the maximum number of components (see WithResultLimits).
+		OmittedDetails int `json:"omittedDetails,omitempty"`
 	}
 
 	// CheckResult holds a components health information.
@@ -116,6 +348,34 @@ type (
 		Timestamp time.Time `json:"timestamp,omitempty"`
 		// Error contains the check error message, if the check failed.
 		Error error `json:"error,omitempty"`
+		// Code holds the machine-readable code of the check error, if known (see Coder).
+		Code string `json:"code,omitempty"`
+		// Data contains the data that was reported by the check function (see Check.CheckWithData).
+		Data map[string]interface{} `json:"data,omitempty"`
+		// History contains the most recent evaluation results of the component,
+		// ordered from oldest to newest (see WithHistorySize).
+		History []CheckHistoryEntry `json:"history,omitempty"`
+		// Metadata contains the metadata of the component (see Check.Metadata).
+		Metadata map[string]interface{} `json:"metadata,omitempty"`
+		// TimedOut is true if the last check did not complete before its timeout (see CheckState.TimedOut).
+		TimedOut bool `json:"timedOut,omitempty"`
+		// Duration holds how long the last check execution took (see CheckState.LastCheckDuration).
+		Duration time.Duration `json:"duration,omitempty"`
+		// Attempts holds how often the check function was called during the last check execution
+		// (see CheckState.Attempts).
+		Attempts uint `json:"attempts,omitempty"`
+		// Maintenance holds the details of the maintenance of the component, if it is currently
+		// in maintenance (see Checker.SetMaintenance).
+		Maintenance *Maintenance `json:"maintenance,omitempty"`
+		// Silenced is true if status notifications of the component are currently suppressed (see Silence).
+		Silenced bool `json:"silenced,omitempty"`
+		// Availability holds the availability of the component (between 0 and 1) for each configured window,
+		// keyed by the window (e.g., "24h"). See WithAvailabilityWindows for more information.
+		Availability map[string]float64 `json:"availability,omitempty"`
+		// Incidents holds the downtime statistics of the component (see WithIncidentStats).
+		Incidents *IncidentStats `json:"incidents,omitempty"`
+		// Truncated is true if the error message or data of the component were truncated (see WithResultLimits).
+		Truncated bool `json:"truncated,omitempty"`
 	}
 
 	// Interceptor is factory function that allows creating new instances of
@@ -133,6 +393,35 @@ type (
 	// a components health check function.
 	InterceptorFunc func(ctx context.Context, checkName string, state CheckState) CheckState
 
+	// AggregationFunc combines the states of individual checks into the aggregated system status
+	// (see WithAggregator). The passed map only contains the states of checks that contribute to the
+	// aggregated status (i.e., it does not contain non-critical checks, see Check.NonCritical).
+	AggregationFunc func(states map[string]CheckState) AvailabilityStatus
+
+	// CheckFilter decides if a check should be included in a check run
+	// (see Checker.CheckWithFilter). It returns true if the check should be included.
+	CheckFilter func(check Check) bool
+
+	// DegradedError is an error that a check function can return to signal that the checked component
+	// is still available, but degraded (e.g., because of slow responses or partial functionality).
+	// A check that returns a DegradedError is reported with status StatusDegraded. It is not considered
+	// a failure, so it does neither count towards Check.MaxContiguousFails nor Check.MaxTimeInError.
+	// Use NewDegradedError to create a new instance.
+	DegradedError struct {
+		// Err holds the error that describes why the component is degraded.
+		Err error
+	}
+
+	// PanicError is the error that is reported if a check function panicked (unless panic recovery was
+	// disabled; see Check.DisablePanicRecovery). The stack trace of the panic is also reported as check data
+	// in field "panicStack" (truncated to 4 KiB). Use WithDetailsAuthorizer to hide it from untrusted callers.
+	PanicError struct {
+		// Value holds the value that was passed to panic.
+		Value interface{}
+		// Stack holds the stack trace of the goroutine that panicked.
+		Stack []byte
+	}
+
 	// AvailabilityStatus expresses the availability of either
 	// a component or the whole system.
 	AvailabilityStatus string
@@ -148,6 +437,24 @@ const (
 	// StatusDown holds the information that the system or a component
 	// down and not available.
 	StatusDown AvailabilityStatus = "down"
+	// StatusSkipped holds the information that a components check function
+	// was not executed, because at least one of its dependencies
+	// (see Check.DependsOn) is not available.
+	StatusSkipped AvailabilityStatus = "skipped"
+	// StatusDegraded holds the information that the system or a component
+	// is available, but with limited functionality or performance
+	// (see DegradedError).
+	StatusDegraded AvailabilityStatus = "degraded"
+	// StatusMaintenance holds the information that the system is in maintenance mode
+	// and health checks are currently not executed (see Checker.Pause).
+	StatusMaintenance AvailabilityStatus = "maintenance"
+	// StatusStarting holds the information that the system or a component is
+	// still starting up and is not yet considered to be unavailable
+	// (see WithStartupGracePeriod and Check.InitialDelay).
+	StatusStarting AvailabilityStatus = "starting"
+	// StatusDisabled holds the information that a component is switched off and its check
+	// function is not executed (see Checker.DisableCheck and WithToggleProvider).
+	StatusDisabled AvailabilityStatus = "disabled"
 )
 
 // MarshalJSON provides a custom marshaller for the CheckResult type.
@@ -157,13 +464,48 @@ func (cr CheckResult) MarshalJSON() ([]byte, error) {
 		errorMsg = cr.Error.Error()
 	}
 
+	duration := ""
+	if cr.Duration > 0 {
+		duration = cr.Duration.String()
+	}
+
 	return json.Marshal(&jsonCheckResult{
-		Status:    string(cr.Status),
-		Timestamp: cr.Timestamp,
-		Error:     errorMsg,
+		Status:       string(cr.Status),
+		Timestamp:    cr.Timestamp,
+		Error:        errorMsg,
+		Code:         cr.Code,
+		Data:         cr.Data,
+		History:      cr.History,
+		Metadata:     cr.Metadata,
+		TimedOut:     cr.TimedOut,
+		Duration:     duration,
+		Attempts:     cr.Attempts,
+		Maintenance:  cr.Maintenance,
+		Silenced:     cr.Silenced,
+		Availability: cr.Availability,
+		Incidents:    cr.Incidents,
+		Truncated:    cr.Truncated,
 	})
 }
 
+// SlowestCheck returns the name and the duration of the component whose last check took the longest
+// (see CheckResult.Duration). This helps to find the dependency that is closest to its timeout.
+// The last return value is false if the result contains no component with a known duration.
+func (r CheckerResult) SlowestCheck() (string, time.Duration, bool) {
+	var (
+		slowestName     string
+		slowestDuration time.Duration
+	)
+
+	for _, name := range sortedKeys(r.Details) {
+		if duration := r.Details[name].Duration; duration > slowestDuration {
+			slowestName, slowestDuration = name, duration
+		}
+	}
+
+	return slowestName, slowestDuration, slowestDuration > 0
+}
+
 func (cr *CheckResult) UnmarshalJSON(data []byte) error {
 	var result jsonCheckResult
 	if err := json.Unmarshal(data, &result); err != nil {
@@ -172,6 +514,25 @@ func (cr *CheckResult) UnmarshalJSON(data []byte) error {
 
 	cr.Status = AvailabilityStatus(result.Status)
 	cr.Timestamp = result.Timestamp
+	cr.Code = result.Code
+	cr.Data = result.Data
+	cr.History = result.History
+	cr.Metadata = result.Metadata
+	cr.TimedOut = result.TimedOut
+	cr.Attempts = result.Attempts
+	cr.Maintenance = result.Maintenance
+	cr.Silenced = result.Silenced
+	cr.Availability = result.Availability
+	cr.Incidents = result.Incidents
+	cr.Truncated = result.Truncated
+
+	if result.Duration != "" {
+		duration, err := time.ParseDuration(result.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+		cr.Duration = duration
+	}
 
 	if result.Error != "" {
 		cr.Error = errors.New(result.Error)
@@ -183,27 +544,129 @@ func (cr *CheckResult) UnmarshalJSON(data []byte) error {
 func (s AvailabilityStatus) criticality() int {
 	switch s {
 	case StatusDown:
+		return 4
+	case StatusUnknown, StatusSkipped:
+		return 3
+	case StatusStarting:
 		return 2
-	case StatusUnknown:
+	case StatusDegraded:
 		return 1
 	default:
 		return 0
 	}
 }
 
+// Error implements the error interface.
+func (e *DegradedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *DegradedError) Unwrap() error {
+	return e.Err
+}
+
+// NewDegradedError creates a new DegradedError that wraps the provided error.
+// Return it from a check function to report the checked component as degraded
+// (see StatusDegraded) rather than down.
+func NewDegradedError(err error) error {
+	return &DegradedError{Err: err}
+}
+
+// Error implements the error interface. It returns the message of the recovered
+// value, so that the panic is reported like a regular check error.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v", e.Value)
+}
+
+// Unwrap returns the recovered value if it is an error, so that it can be inspected using errors.Is and errors.As.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// truncateSuffix marks strings that have been shortened by truncate.
+const truncateSuffix = "..."
+
+// truncate shortens the string to at most maxLength bytes, including the suffix "..." that marks truncated
+// strings. It cuts on a rune boundary, so that no invalid UTF-8 sequences are created. The suffix is omitted
+// if maxLength does not leave room for it.
+func truncate(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+
+	suffix := truncateSuffix
+	if maxLength <= len(suffix) {
+		suffix = ""
+	}
+
+	end := maxLength - len(suffix)
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+
+	return s[:end] + suffix
+}
+
+func isDegraded(err error) bool {
+	var degradedErr *DegradedError
+	return errors.As(err, &degradedErr)
+}
+
+const maxPanicStackLength = 4096
+
 var (
-	CheckTimeoutErr = errors.New("check timed out")
+	// CheckTimeoutErr is the error that is reported if a check did not complete before its timeout
+	// (see Check.Timeout). Use errors.Is to detect it, or check CheckState.TimedOut.
+	CheckTimeoutErr          = errors.New("check timed out")
+	DependencyUnavailableErr = errors.New("dependency unavailable")
+	// BudgetExhaustedErr is the error that is reported for checks that were not executed because
+	// the deadline of the check run was exceeded before it was their turn (see WithSequentialExecution).
+	BudgetExhaustedErr = errors.New("time budget exhausted")
 )
 
 func newChecker(cfg checkerConfig) *defaultChecker {
+	checkLevels, err := validateChecks(cfg.checks)
+	if err != nil {
+		panic(fmt.Sprintf("health: invalid check configuration: %v", err))
+	}
+
 	checkState := map[string]CheckState{}
 	for _, check := range cfg.checks {
 		checkState[check.Name] = CheckState{Status: StatusUnknown}
 	}
 
+	if cfg.aggregator == nil {
+		cfg.aggregator = WorstStatusAggregator
+	}
+
+	if cfg.clock == nil {
+		cfg.clock = realClock{}
+	}
+
+	if cfg.logger == nil {
+		cfg.logger = noopLogger{}
+	}
+
+	if cfg.silences == nil {
+		cfg.silences = newSilenceRegistry()
+	}
+
 	checker := defaultChecker{
-		cfg:   cfg,
-		state: CheckerState{Status: StatusUnknown, CheckState: checkState},
+		cfg:              cfg,
+		state:            CheckerState{Status: StatusUnknown, CheckState: checkState},
+		checkLevels:      checkLevels,
+		periodicChecks:   map[string]context.CancelFunc{},
+		publisherWorkers: newPublisherWorkers(cfg.publishers),
+		history:          map[string]*checkHistory{},
+		subscribers:      map[chan CheckerState]struct{}{},
+		nextRuns:         map[*Check]time.Time{},
+		inFlight:         map[string]struct{}{},
+		maintenance:      map[string]Maintenance{},
+		availability:     map[string]*availabilityTracker{},
+		incidents:        map[string]*incidentTracker{},
+		disabled:         map[string]struct{}{},
 	}
 
 	if !cfg.autostartDisabled {
@@ -219,9 +682,18 @@ func (ck *defaultChecker) Start() {
 
 	if !ck.started {
 		ctx, cancel := context.WithCancel(context.Background())
+		ck.ctx = ctx
 		ck.cancel = cancel
 
 		ck.started = true
+		ck.startedAt = ck.cfg.clock.Now()
+		if !ck.stateRestored {
+			ck.restoreState(ctx)
+			ck.stateRestored = true
+		}
+		ck.startPublishers(ctx)
+		ck.startListenerWorker(ctx)
+		ck.startStartupGracePeriod(ctx)
 		defer ck.startPeriodicChecks(ctx)
 
 		// We run the initial check execution in a separate goroutine so that server startup is not blocked in case of
@@ -237,14 +709,46 @@ func (ck *defaultChecker) Start() {
 
 // Stop implements Checker.Stop. Please refer to Checker.Stop for more information.
 func (ck *defaultChecker) Stop() {
-	ck.cancel()
+	ck.mtx.Lock()
+	cancel := ck.cancel
+	ck.mtx.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	// Attention: The lock must not be held while waiting, since running periodic checks require it to complete.
+	cancel()
 	ck.wg.Wait()
 
 	ck.mtx.Lock()
 	defer ck.mtx.Unlock()
 
 	ck.started = false
-	ck.periodicCheckCount = 0
+	ck.ctx = nil
+	ck.cancel = nil
+	ck.periodicChecks = map[string]context.CancelFunc{}
+}
+
+// Pause implements Checker.Pause. Please refer to Checker.Pause for more information.
+func (ck *defaultChecker) Pause() {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+	ck.paused = true
+}
+
+// Resume implements Checker.Resume. Please refer to Checker.Resume for more information.
+func (ck *defaultChecker) Resume() {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+	ck.paused = false
+}
+
+// IsPaused implements Checker.IsPaused. Please refer to Checker.IsPaused for more information.
+func (ck *defaultChecker) IsPaused() bool {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+	return ck.paused
 }
 
 // GetRunningPeriodicCheckCount implements Checker.GetRunningPeriodicCheckCount.
@@ -252,7 +756,188 @@ func (ck *defaultChecker) Stop() {
 func (ck *defaultChecker) GetRunningPeriodicCheckCount() int {
 	ck.mtx.Lock()
 	defer ck.mtx.Unlock()
-	return ck.periodicCheckCount
+	return len(ck.periodicChecks)
+}
+
+// GetRunningPeriodicCheckNames implements Checker.GetRunningPeriodicCheckNames.
+// Please refer to Checker.GetRunningPeriodicCheckNames for more information.
+func (ck *defaultChecker) GetRunningPeriodicCheckNames() []string {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	names := make([]string, 0, len(ck.periodicChecks))
+	for name := range ck.periodicChecks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// GetCheckState implements Checker.GetCheckState. Please refer to Checker.GetCheckState for more information.
+func (ck *defaultChecker) GetCheckState(name string) (CheckState, bool) {
+	// Only the state lock is required here (see updateState). This way, reading a checks state
+	// does not block until a concurrently running Checker.Check call has completed.
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	state, ok := ck.state.CheckState[name]
+	return state, ok
+}
+
+// GetCheckHistory implements Checker.GetCheckHistory. Please refer to Checker.GetCheckHistory for more information.
+func (ck *defaultChecker) GetCheckHistory(name string) ([]CheckHistoryEntry, bool) {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return nil, false
+	}
+
+	history, ok := ck.history[name]
+	if !ok {
+		return []CheckHistoryEntry{}, true
+	}
+
+	return history.list(), true
+}
+
+// AddCheck implements Checker.AddCheck. Please refer to Checker.AddCheck for more information.
+func (ck *defaultChecker) AddCheck(check Check) error {
+	return ck.addCheck(&check)
+}
+
+// AddPeriodicCheck implements Checker.AddPeriodicCheck. Please refer to Checker.AddPeriodicCheck for more information.
+func (ck *defaultChecker) AddPeriodicCheck(refreshPeriod time.Duration, initialDelay time.Duration, check Check) error {
+	check.updateInterval = refreshPeriod
+	check.initialDelay = initialDelay
+	return ck.addCheck(&check)
+}
+
+func (ck *defaultChecker) addCheck(check *Check) error {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	if _, ok := ck.cfg.checks[check.Name]; ok {
+		return fmt.Errorf("check %q already exists", check.Name)
+	}
+
+	if err := parseSchedule(check); err != nil {
+		return err
+	}
+
+	checks := make(map[string]*Check, len(ck.cfg.checks)+1)
+	for name, c := range ck.cfg.checks {
+		checks[name] = c
+	}
+	checks[check.Name] = check
+
+	checkLevels, err := orderByDependencies(checks)
+	if err != nil {
+		return err
+	}
+
+	ck.stateMtx.Lock()
+	ck.cfg.checks = checks
+	ck.checkLevels = checkLevels
+	ck.stateMtx.Unlock()
+
+	ck.updateState(context.Background(), checkResult{check.Name, CheckState{Status: StatusUnknown}})
+
+	if ck.started && isPeriodicCheck(check) {
+		ck.startPeriodicCheck(ck.ctx, check)
+	}
+
+	return nil
+}
+
+// RemoveCheck implements Checker.RemoveCheck. Please refer to Checker.RemoveCheck for more information.
+func (ck *defaultChecker) RemoveCheck(name string) error {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	removed, ok := ck.cfg.checks[name]
+	if !ok {
+		return fmt.Errorf("check %q does not exist", name)
+	}
+
+	checks := make(map[string]*Check, len(ck.cfg.checks))
+	for checkName, check := range ck.cfg.checks {
+		if checkName == name {
+			continue
+		}
+
+		for _, dependency := range check.DependsOn {
+			if dependency == name {
+				return fmt.Errorf("check %q cannot be removed, because check %q depends on it", name, checkName)
+			}
+		}
+
+		checks[checkName] = check
+	}
+
+	checkLevels, err := orderByDependencies(checks)
+	if err != nil {
+		return err
+	}
+
+	if cancel, ok := ck.periodicChecks[name]; ok {
+		cancel()
+		delete(ck.periodicChecks, name)
+	}
+
+	ck.stateMtx.Lock()
+	ck.cfg.checks = checks
+	ck.checkLevels = checkLevels
+	delete(ck.state.CheckState, name)
+	delete(ck.history, name)
+	delete(ck.maintenance, name)
+	delete(ck.disabled, name)
+	delete(ck.availability, name)
+	delete(ck.incidents, name)
+	ck.stateMtx.Unlock()
+
+	// Recalculates the aggregated status without the removed check.
+	ck.updateState(context.Background())
+
+	disposeCheck(removed, ck.cfg.logger)
+
+	return nil
+}
+
+// SetUnhealthy implements Checker.SetUnhealthy. Please refer to Checker.SetUnhealthy for more information.
+func (ck *defaultChecker) SetUnhealthy(reason string) {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	if reason == "" {
+		reason = "unhealthy"
+	}
+
+	ck.stateMtx.Lock()
+	ck.unhealthyReason = reason
+	ck.state.UnhealthyReason = reason
+	ck.stateMtx.Unlock()
+
+	ck.updateState(context.Background())
+}
+
+// Shutdown implements Checker.Shutdown. Please refer to Checker.Shutdown for more information.
+func (ck *defaultChecker) Shutdown(ctx context.Context) error {
+	ck.SetUnhealthy("shutting down")
+
+	stopped := make(chan struct{})
+	go func() {
+		ck.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // IsStarted implements Checker.IsStarted. Please refer to Checker.IsStarted for more information.
@@ -264,165 +949,731 @@ func (ck *defaultChecker) IsStarted() bool {
 
 // Check implements Checker.Check. Please refer to Checker.Check for more information.
 func (ck *defaultChecker) Check(ctx context.Context) CheckerResult {
+	return ck.CheckWithFilter(ctx, nil)
+}
+
+// CheckWithFilter implements Checker.CheckWithFilter. Please refer to Checker.CheckWithFilter for more information.
+func (ck *defaultChecker) CheckWithFilter(ctx context.Context, filter CheckFilter) CheckerResult {
+	if ck.cfg.staleAfter > 0 {
+		return ck.checkWithSoftDeadline(ctx, filter)
+	}
+	return ck.check(ctx, filter, false)
+}
+
+// CheckNow implements Checker.CheckNow. Please refer to Checker.CheckNow for more information.
+func (ck *defaultChecker) CheckNow(ctx context.Context, name string) (CheckResult, error) {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return CheckResult{}, fmt.Errorf("check %q does not exist", name)
+	}
+
+	if !ck.paused {
+		ctx, cancel := context.WithTimeout(ctx, ck.cfg.timeout)
+		defer cancel()
+
+		ck.runChecks(ctx, func(check Check) bool { return check.Name == name }, true)
+	}
+
+	return ck.mapStateToCheckResult(name), nil
+}
+
+// CheckAllNow implements Checker.CheckAllNow. Please refer to Checker.CheckAllNow for more information.
+func (ck *defaultChecker) CheckAllNow(ctx context.Context) CheckerResult {
+	return ck.check(ctx, nil, true)
+}
+
+// check executes all checks that are accepted by the filter. If force is true, periodic checks are executed
+// as well and cached results are ignored (see Checker.CheckNow).
+func (ck *defaultChecker) check(ctx context.Context, filter CheckFilter, force bool) CheckerResult {
+	runFilter := filter
+	if ck.cfg.singleFlight && !force {
+		runFilter = ck.joinInFlightChecks(filter)
+	}
+
 	ck.mtx.Lock()
 	defer ck.mtx.Unlock()
 
+	if ck.paused {
+		result := ck.mapStateToCheckerResult(filter)
+		if ck.unhealthyReason == "" {
+			result.Status = StatusMaintenance
+		}
+		return result
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, ck.cfg.timeout)
 	defer cancel()
 
-	ck.runSynchronousChecks(ctx)
+	ck.runChecks(ctx, runFilter, force)
 
-	return ck.mapStateToCheckerResult()
+	return ck.mapStateToCheckerResult(filter)
 }
 
-func (ck *defaultChecker) runSynchronousChecks(ctx context.Context) {
+// GetCheckNames implements Checker.GetCheckNames. Please refer to Checker.GetCheckNames for more information.
+func (ck *defaultChecker) GetCheckNames(filter CheckFilter) []string {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	names := make([]string, 0, len(ck.cfg.checks))
+	for _, check := range ck.cfg.checks {
+		if isIncluded(filter, check) {
+			names = append(names, check.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// runChecks executes all synchronous checks that are accepted by the filter and whose cached result has
+// expired. If force is true, periodic checks are executed as well and cached results are ignored.
+// Must be called while holding ck.mtx.
+func (ck *defaultChecker) runChecks(ctx context.Context, filter CheckFilter, force bool) {
 	var (
-		numChecks          = len(ck.cfg.checks)
-		numInitiatedChecks = 0
-		resChan            = make(chan checkResult, numChecks)
+		results   = make([]checkResult, 0, len(ck.cfg.checks))
+		newStates = make(map[string]CheckState, len(ck.cfg.checks))
+		slots     chan struct{}
+		budget    *deadlineBudget
 	)
 
-	for _, check := range ck.cfg.checks {
-		check := check
+	if ck.cfg.maxConcurrentChecks > 0 {
+		slots = make(chan struct{}, ck.cfg.maxConcurrentChecks)
+	}
+
+	if ck.cfg.deadlineBudget {
+		budget = ck.newDeadlineBudget(filter, force)
+	}
+
+	inFlight := ck.markInFlight(filter, force)
+	defer ck.unmarkInFlight(inFlight)
+
+	listener := checkResultListenerFrom(ctx)
+	report := func(result checkResult) {
+		results = append(results, result)
+		if listener != nil && !ck.cfg.detailsDisabled {
+			listener(result.checkName, ck.mapCheckStateToResult(result.checkName, result.newState))
+		}
+	}
+
+	// Checks are executed level by level in the order of their dependencies (see Check.DependsOn),
+	// so that a check only runs after the checks it depends on have been completed. All checks of the
+	// same level are executed concurrently, unless sequential execution is enabled (see WithSequentialExecution).
+	for level, checks := range ck.checkLevels {
+		var (
+			numInitiatedChecks = 0
+			resChan            = make(chan checkResult, len(checks))
+		)
+
+		for _, check := range checks {
+			check := check
+
+			if !ck.isDue(check, filter, force) {
+				continue
+			}
 
-		if !isPeriodicCheck(check) {
 			checkState := ck.state.CheckState[check.Name]
 
-			if !isCacheExpired(ck.cfg.cacheTTL, &checkState) {
+			if dependency, dependencyState, ok := ck.findUnavailableDependency(check, newStates); ok {
+				checkState = skipCheck(ctx, &ck.cfg, check, checkState, dependencyUnavailableErr(dependency, dependencyState))
+				newStates[check.Name] = checkState
+				report(checkResult{check.Name, checkState})
+				continue
+			}
+
+			if ck.cfg.sequentialExecution && ctx.Err() != nil {
+				checkState = skipCheck(ctx, &ck.cfg, check, checkState, BudgetExhaustedErr)
+				newStates[check.Name] = checkState
+				report(checkResult{check.Name, checkState})
 				continue
 			}
 
+			if !force {
+				if sharedState, ok := ck.loadSharedState(ctx, check); ok {
+					notifyStatusListeners(withCheckMetadata(ctx, check), &ck.cfg, check, checkState, sharedState)
+					newStates[check.Name] = sharedState
+					report(checkResult{check.Name, sharedState})
+					continue
+				}
+			}
+
 			numInitiatedChecks++
+			inGracePeriod := ck.isInStartupGracePeriod()
 
-			go func() {
-				withCheckContext(ctx, check, func(ctx context.Context) {
-					_, checkState := executeCheck(ctx, &ck.cfg, check, checkState)
+			// Wait for a free slot, so that no more than the configured number
+			// of checks are executed at the same time (see WithMaxConcurrentChecks).
+			if slots != nil {
+				slots <- struct{}{}
+			}
+
+			// The deadline is determined after a slot was acquired, so that checks that had to wait
+			// for a slot do not get the budget that has already been used up while waiting.
+			checkCtx, cancel := budget.withDeadline(ctx, level, numInitiatedChecks-1)
+
+			run := func() {
+				defer cancel()
+				if slots != nil {
+					defer func() { <-slots }()
+				}
+
+				withCheckContext(checkCtx, check, func(ctx context.Context) {
+					_, checkState := executeCheck(ctx, &ck.cfg, check, checkState, inGracePeriod)
+					ck.storeSharedState(ctx, check, checkState)
 					resChan <- checkResult{check.Name, checkState}
 				})
-			}()
+			}
+
+			if ck.cfg.sequentialExecution {
+				run()
+			} else {
+				go run()
+			}
 		}
-	}
 
-	results := make([]checkResult, 0, numInitiatedChecks)
-	for len(results) < numInitiatedChecks {
-		results = append(results, <-resChan)
+		for i := 0; i < numInitiatedChecks; i++ {
+			result := <-resChan
+			newStates[result.checkName] = result.newState
+			report(result)
+		}
 	}
 
 	ck.updateState(ctx, results...)
 }
 
+// isDue returns true if the check needs to be executed by runChecks, i.e., if it is accepted by the filter and its
+// cached result has expired. Periodic checks and cached results are only considered if force is false.
+// Must be called while holding ck.mtx.
+func (ck *defaultChecker) isDue(check *Check, filter CheckFilter, force bool) bool {
+	if (isPeriodicCheck(check) && !force) || !isIncluded(filter, check) {
+		return false
+	}
+
+	if _, inMaintenance := ck.activeMaintenance(check.Name); inMaintenance {
+		return false
+	}
+
+	if ck.isDisabled(check.Name) {
+		return false
+	}
+
+	if force {
+		return true
+	}
+
+	checkState := ck.state.CheckState[check.Name]
+
+	return isCacheExpired(ck.cfg.clock.Now(), ck.cacheTTL(check), &checkState) && !ck.isDelayed(check)
+}
+
+// findUnavailableDependency returns the name and state of the first dependency of the check
+// (see Check.DependsOn) that is not available. States in newStates take precedence over
+// the current state of the checker. Must be called while holding ck.mtx.
+func (ck *defaultChecker) findUnavailableDependency(
+	check *Check,
+	newStates map[string]CheckState,
+) (string, CheckState, bool) {
+	for _, dependency := range check.DependsOn {
+		state, ok := newStates[dependency]
+		if !ok {
+			state = ck.state.CheckState[dependency]
+		}
+
+		if state.Status == StatusDown || state.Status == StatusSkipped {
+			return dependency, state, true
+		}
+	}
+
+	return "", CheckState{}, false
+}
+
 func (ck *defaultChecker) startPeriodicChecks(ctx context.Context) {
 	ck.mtx.Lock()
 	defer ck.mtx.Unlock()
 
 	// Start periodic checks.
 	for _, check := range ck.cfg.checks {
-		check := check
-
 		if isPeriodicCheck(check) {
-			// ATTENTION: Access to check and ck.state.CheckState is not synchronized here,
-			// 	assuming that the accessed values are never changed, such as
-			//  - ck.state.CheckState[check.Name]
-			//  - check object itself (there will never be a new Check object created for the configured check)
-			//	- check.updateInterval (used by isPeriodicCheck)
-			//  - check.initialDelay
-			// ALSO:
-			//  - The check state itself is never synchronized on, since the only place where values can be changed are
-			//    within this goroutine.
-
-			ck.periodicCheckCount++
-			ck.wg.Add(1)
-
-			go func() {
-				defer ck.wg.Done()
-
-				if check.initialDelay > 0 {
-					if waitForStopSignal(ctx, check.initialDelay) {
-						return
-					}
+			ck.startPeriodicCheck(ctx, check)
+		}
+	}
+}
+
+// startPeriodicCheck starts a goroutine that periodically executes the check. The goroutine
+// is stopped when either the provided context is cancelled or when the check is removed
+// (see Checker.RemoveCheck). Must be called while holding ck.mtx.
+func (ck *defaultChecker) startPeriodicCheck(ctx context.Context, check *Check) {
+	// ATTENTION: Access to check is not synchronized here, assuming that the accessed values are
+	// 	never changed, such as
+	//  - check object itself (there will never be a new Check object created for the configured check,
+	//	  a check that is added again after it was removed will be a new object, see Checker.AddCheck)
+	//	- check.updateInterval (used by isPeriodicCheck)
+	//  - check.initialDelay
+	//  - check.Backoff, check.IntervalJitter and check.schedule
+	// ALSO:
+	//  - The check state itself is only changed within this goroutine. It is written using updateState
+	//	  only if the check was not removed in the meantime (see isRegistered).
+
+	ctx, cancel := context.WithCancel(ctx)
+	ck.periodicChecks[check.Name] = cancel
+	ck.wg.Add(1)
+
+	go func() {
+		defer ck.wg.Done()
+		defer cancel()
+		defer ck.setNextRun(check, 0)
+
+		var (
+			contiguousFails uint
+			jitter          = ck.intervalJitter(check)
+		)
+
+		if check.schedule == nil {
+			if initialDelay := initialDelay(check) + randomDuration(jitter); initialDelay > 0 {
+				ck.setNextRun(check, initialDelay)
+				if waitForStopSignal(ctx, ck.cfg.clock, initialDelay) {
+					return
 				}
+			}
+		}
 
-				for {
-					withCheckContext(ctx, check, func(ctx context.Context) {
-						ck.mtx.Lock()
-						checkState := ck.state.CheckState[check.Name]
-						ck.mtx.Unlock()
-
-						// ATTENTION: This function may panic, if panic handling is disabled
-						// 	via "check.DisablePanicRecovery".
-						//
-						// ATTENTION: executeCheck is executed with its own copy of the checks
-						// 	state (see checkState above). This means that if there is a global status
-						//	listener that is configured by the user with health.WithStatusListener,
-						//	and that global status listener changes this checks state as long as
-						//  executeCheck is running, the modifications made by the global listener
-						//  will be lost after the function completes, since we overwrite the state
-						//  below using updateState.
-						//  This means that global listeners should not change the checks state
-						//  or accept losing their updates. This will be the case especially for
-						//  long-running checks. Hence, the checkState is read-only for interceptors.
-						ctx, checkState = executeCheck(ctx, &ck.cfg, check, checkState)
-
-						ck.mtx.Lock()
-						ck.updateState(ctx, checkResult{check.Name, checkState})
-						ck.mtx.Unlock()
-					})
-
-					if waitForStopSignal(ctx, check.updateInterval) {
-						return
-					}
+		for {
+			withCheckContext(ctx, check, func(ctx context.Context) {
+				ck.mtx.Lock()
+				paused := ck.paused
+				_, inMaintenance := ck.activeMaintenance(check.Name)
+				disabled := ck.isDisabled(check.Name)
+				inGracePeriod := ck.isInStartupGracePeriod()
+				checkState := ck.state.CheckState[check.Name]
+				dependency, dependencyState, skip := ck.findUnavailableDependency(check, nil)
+				ck.mtx.Unlock()
+
+				if paused || inMaintenance || disabled {
+					return
+				}
+
+				if skip {
+					checkState = skipCheck(ctx, &ck.cfg, check, checkState, dependencyUnavailableErr(dependency, dependencyState))
+				} else {
+					// ATTENTION: This function may panic, if panic handling is disabled
+					// 	via "check.DisablePanicRecovery".
+					//
+					// ATTENTION: executeCheck is executed with its own copy of the checks
+					// 	state (see checkState above). This means that if there is a global status
+					//	listener that is configured by the user with health.WithStatusListener,
+					//	and that global status listener changes this checks state as long as
+					//  executeCheck is running, the modifications made by the global listener
+					//  will be lost after the function completes, since we overwrite the state
+					//  below using updateState.
+					//  This means that global listeners should not change the checks state
+					//  or accept losing their updates. This will be the case especially for
+					//  long-running checks. Hence, the checkState is read-only for interceptors.
+					ctx, checkState = executeCheck(ctx, &ck.cfg, check, checkState, inGracePeriod)
 				}
-			}()
+
+				contiguousFails = checkState.ContiguousFails
+
+				ck.mtx.Lock()
+				if ck.isRegistered(check) {
+					ck.updateState(ctx, checkResult{check.Name, checkState})
+				}
+				ck.mtx.Unlock()
+			})
+
+			delay, ok := nextExecutionDelay(ck.cfg.clock.Now(), check, contiguousFails)
+			if !ok {
+				// The schedule will never be due again, so we just wait until the check is stopped.
+				ck.setNextRun(check, 0)
+				<-ctx.Done()
+				return
+			}
+
+			delay += randomDuration(jitter)
+			ck.setNextRun(check, delay)
+			plannedAt := ck.cfg.clock.Now().Add(delay)
+
+			if waitForStopSignal(ctx, ck.cfg.clock, delay) {
+				return
+			}
+
+			logSchedulerDrift(ck.cfg.logger, check, plannedAt, ck.cfg.clock.Now())
 		}
-	}
+	}()
+}
+
+// isRegistered returns true if the check is still part of the checker configuration
+// (i.e., it was not removed using Checker.RemoveCheck). Must be called while holding ck.mtx.
+func (ck *defaultChecker) isRegistered(check *Check) bool {
+	return ck.cfg.checks[check.Name] == check
 }
 
+// updateState must be called while holding ck.mtx. It additionally acquires the state lock,
+// so that the state can be read by holding either one of these locks (see Checker.GetCheckState).
 func (ck *defaultChecker) updateState(ctx context.Context, updates ...checkResult) {
+	ck.stateMtx.Lock()
+
+	// The previous state is only copied if it is required (see WithStatusTransitionListener).
+	var oldState CheckerState
+	if ck.cfg.statusTransitionListener != nil {
+		oldState = copyCheckerState(ck.state)
+	}
+
 	for _, update := range updates {
+		if check, ok := ck.cfg.checks[update.checkName]; ok {
+			update.newState.Silenced = ck.cfg.silences.isSilenced(ck.cfg.clock.Now(), check)
+		}
+		ck.trackAvailability(&update)
 		ck.state.CheckState[update.checkName] = update.newState
+		ck.recordHistory(update)
+		ck.trackIncident(update)
 	}
 
 	oldStatus := ck.state.Status
-	ck.state.Status = aggregateStatus(ck.state.CheckState)
+	ck.state.Status = ck.aggregateStatus(nil)
+	ck.systemIncidents.record(ck.cfg.clock.Now(), ck.state.Status)
+	statusChanged := oldStatus != ck.state.Status
+
+	// The state is copied for each listener, because a listener that timed out may still
+	// access it (see WithListenerTimeout) and listeners must not see each other's modifications.
+	var listenerState, transitionState CheckerState
+	if statusChanged && ck.cfg.statusChangeListener != nil {
+		listenerState = copyCheckerState(ck.state)
+	}
+	if statusChanged && ck.cfg.statusTransitionListener != nil {
+		transitionState = copyCheckerState(ck.state)
+	}
+
+	ck.publish()
+	ck.stateMtx.Unlock()
+
+	// Listeners are called after the state lock was released, so that they can read the state of the Checker
+	// (e.g., using Checker.GetCheckState). They are still called in order, since callers hold ck.mtx.
+	if !statusChanged {
+		return
+	}
+
+	if ck.cfg.statusChangeListener != nil {
+		callListener(&ck.cfg, "status listener", func() {
+			ck.cfg.statusChangeListener(ctx, listenerState)
+		})
+	}
 
-	if oldStatus != ck.state.Status && ck.cfg.statusChangeListener != nil {
-		ck.cfg.statusChangeListener(ctx, ck.state)
+	if ck.cfg.statusTransitionListener != nil {
+		callListener(&ck.cfg, "status transition listener", func() {
+			ck.cfg.statusTransitionListener(ctx, oldState, transitionState)
+		})
 	}
 }
 
-func (ck *defaultChecker) mapStateToCheckerResult() CheckerResult {
+// recordHistory appends an evaluation result to the history of the check (see WithHistorySize).
+// Must be called while holding the state lock.
+func (ck *defaultChecker) recordHistory(update checkResult) {
+	if ck.cfg.historySize == 0 {
+		return
+	}
+
+	// Updates without an evaluation result (e.g., when a check is added, see Checker.AddCheck) are not recorded.
+	if update.newState.LastCheckedAt.IsZero() && update.newState.Status != StatusSkipped {
+		return
+	}
+
+	history, ok := ck.history[update.checkName]
+	if !ok {
+		history = newCheckHistory(ck.cfg.historySize)
+		ck.history[update.checkName] = history
+	}
+
+	history.add(newCheckHistoryEntry(ck.cfg.clock.Now(), update.newState))
+}
+
+// publish passes a copy of the current state to all publishers (see WithPublisher) without blocking.
+// Must be called while holding the state lock.
+func (ck *defaultChecker) publish() {
+	ck.notifySubscribers()
+
+	if len(ck.publisherWorkers) == 0 {
+		return
+	}
+
+	for _, worker := range ck.publisherWorkers {
+		if err := worker.enqueue(copyCheckerState(ck.state)); err != nil {
+			ck.handleErr(err)
+		}
+	}
+}
+
+// startPublishers starts a worker goroutine for each publisher (see WithPublisher).
+// Must be called while holding ck.mtx.
+func (ck *defaultChecker) startPublishers(ctx context.Context) {
+	for _, worker := range ck.publisherWorkers {
+		worker := worker
+		ck.wg.Add(1)
+
+		go func() {
+			defer ck.wg.Done()
+			worker.run(ctx, ck.handleErr)
+		}()
+	}
+}
+
+func (ck *defaultChecker) mapStateToCheckerResult(filter CheckFilter) CheckerResult {
 	var (
 		checkResults map[string]CheckResult
-		numChecks    = len(ck.cfg.checks)
+		checks       = ck.cfg.checks
 		status       = ck.state.Status
 	)
 
-	if numChecks > 0 && !ck.cfg.detailsDisabled {
-		checkResults = make(map[string]CheckResult, numChecks)
-		for _, check := range ck.cfg.checks {
-			checkState := ck.state.CheckState[check.Name]
-			checkResults[check.Name] = CheckResult{
-				Status:    checkState.Status,
-				Error:     checkState.Result,
-				Timestamp: checkState.LastCheckedAt,
+	if filter != nil {
+		checks = make(map[string]*Check, len(ck.cfg.checks))
+		for name, check := range ck.cfg.checks {
+			if filter(*check) {
+				checks[name] = check
+			}
+		}
+		status = ck.aggregateStatus(filter)
+	}
+
+	if len(checks) > 0 && !ck.cfg.detailsDisabled {
+		checkResults = make(map[string]CheckResult, len(checks))
+		for _, check := range checks {
+			checkResults[check.Name] = ck.mapStateToCheckResult(check.Name)
+			if check.federation != nil {
+				check.federation.mergeInto(check.Name, checkResults)
 			}
 		}
 	}
 
-	return CheckerResult{Status: status, Details: checkResults, Info: ck.cfg.info}
+	result := CheckerResult{Status: status, Details: checkResults, Info: ck.cfg.info}
+	if filter == nil {
+		result.Incidents = ck.incidentStatsResult("")
+	}
+
+	return result
+}
+
+func (ck *defaultChecker) mapStateToCheckResult(name string) CheckResult {
+	return ck.mapCheckStateToResult(name, ck.state.CheckState[name])
+}
+
+// mapCheckStateToResult creates the result of the check with the given name from the provided check state.
+// Must be called while holding ck.mtx or the state lock.
+func (ck *defaultChecker) mapCheckStateToResult(name string, checkState CheckState) CheckResult {
+	checkResult := CheckResult{
+		Status:       checkState.Status,
+		Error:        checkState.Result,
+		Code:         errorCode(checkState.Result),
+		Timestamp:    checkState.LastCheckedAt,
+		Data:         checkState.Data,
+		Metadata:     ck.cfg.checks[name].Metadata,
+		TimedOut:     checkState.TimedOut,
+		Duration:     checkState.LastCheckDuration,
+		Attempts:     checkState.Attempts,
+		Silenced:     ck.cfg.silences.isSilenced(ck.cfg.clock.Now(), ck.cfg.checks[name]),
+		Availability: ck.availabilityReport(name),
+		Incidents:    ck.incidentStatsResult(name),
+	}
+
+	if maintenance, ok := ck.activeMaintenance(name); ok {
+		checkResult.Status = StatusMaintenance
+		checkResult.Maintenance = &maintenance
+	} else if ck.isDisabled(name) {
+		checkResult.Status = StatusDisabled
+	}
+
+	if history, ok := ck.history[name]; ok {
+		checkResult.History = history.list()
+	}
+
+	return checkResult
+}
+
+// aggregateStatus returns the aggregated status of all checks that are accepted by the filter.
+// If the system was marked as unavailable (see Checker.SetUnhealthy), StatusDown is returned.
+func (ck *defaultChecker) aggregateStatus(filter CheckFilter) AvailabilityStatus {
+	if ck.unhealthyReason != "" {
+		return StatusDown
+	}
+	return ck.cfg.aggregator(ck.selectAggregatedCheckStates(filter))
+}
+
+// selectAggregatedCheckStates returns the states of all checks that are accepted by the filter
+// and contribute to the aggregated status (i.e., all checks that are not marked as non-critical;
+// see Check.NonCritical).
+func (ck *defaultChecker) selectAggregatedCheckStates(filter CheckFilter) map[string]CheckState {
+	checkStates := make(map[string]CheckState, len(ck.cfg.checks))
+	for name, check := range ck.cfg.checks {
+		if _, inMaintenance := ck.activeMaintenance(name); inMaintenance {
+			continue
+		}
+
+		if ck.isDisabled(name) {
+			continue
+		}
+
+		if !check.NonCritical && isIncluded(filter, check) {
+			checkStates[name] = ck.state.CheckState[name]
+		}
+	}
+	return checkStates
+}
+
+// cacheTTL returns the cache duration of the check (see Check.CacheDuration),
+// falling back to the global cache duration (see WithCacheDuration).
+func (ck *defaultChecker) cacheTTL(check *Check) time.Duration {
+	if check.CacheDuration > 0 {
+		return check.CacheDuration
+	}
+	return ck.cfg.cacheTTL
 }
 
-func isCacheExpired(cacheDuration time.Duration, state *CheckState) bool {
-	return state.LastCheckedAt.IsZero() || state.LastCheckedAt.Before(time.Now().Add(-cacheDuration))
+// startStartupGracePeriod marks all checks that were not executed yet as starting and starts a goroutine
+// that ends the startup grace period after it has passed (see WithStartupGracePeriod).
+// Must be called while holding ck.mtx.
+func (ck *defaultChecker) startStartupGracePeriod(ctx context.Context) {
+	if ck.cfg.startupGracePeriod <= 0 {
+		return
+	}
+
+	ck.startupDeadline = ck.startedAt.Add(ck.cfg.startupGracePeriod)
+
+	var results []checkResult
+	for name, state := range ck.state.CheckState {
+		if state.Status == StatusUnknown && state.LastCheckedAt.IsZero() {
+			state.Status = StatusStarting
+			results = append(results, checkResult{name, state})
+		}
+	}
+	ck.updateState(ctx, results...)
+
+	ck.wg.Add(1)
+	go func() {
+		defer ck.wg.Done()
+		if !waitForStopSignal(ctx, ck.cfg.clock, ck.cfg.startupGracePeriod) {
+			ck.endStartupGracePeriod(ctx)
+		}
+	}()
+}
+
+// endStartupGracePeriod re-evaluates the status of all checks that are still reported as starting
+// (see WithStartupGracePeriod).
+func (ck *defaultChecker) endStartupGracePeriod(ctx context.Context) {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	var results []checkResult
+	for name, check := range ck.cfg.checks {
+		state := ck.state.CheckState[name]
+		if state.Status != StatusStarting {
+			continue
+		}
+
+		oldState := state
+		state.Status = evaluateStatus(ck.cfg.clock.Now(), &state, check)
+		notifyStatusListeners(withCheckMetadata(ctx, check), &ck.cfg, check, oldState, state)
+
+		results = append(results, checkResult{name, state})
+	}
+
+	ck.updateState(ctx, results...)
+}
+
+// isInStartupGracePeriod returns true if the startup grace period has not passed yet
+// (see WithStartupGracePeriod). Must be called while holding ck.mtx.
+func (ck *defaultChecker) isInStartupGracePeriod() bool {
+	return !ck.startupDeadline.IsZero() && ck.cfg.clock.Now().Before(ck.startupDeadline)
+}
+
+// isDelayed returns true if the check must not be executed yet, because its initial delay
+// has not passed since the Checker was started (see Check.InitialDelay). Must be called while holding ck.mtx.
+func (ck *defaultChecker) isDelayed(check *Check) bool {
+	return !ck.startedAt.IsZero() && ck.cfg.clock.Now().Sub(ck.startedAt) < check.InitialDelay
+}
+
+// initialDelay returns the initial delay of a periodic check, which is the larger value of the
+// initial delay that was passed to WithPeriodicCheck and Check.InitialDelay.
+func initialDelay(check *Check) time.Duration {
+	if check.InitialDelay > check.initialDelay {
+		return check.InitialDelay
+	}
+	return check.initialDelay
+}
+
+// intervalJitter returns the interval jitter of the check (see Check.IntervalJitter),
+// falling back to the global interval jitter (see WithIntervalJitter).
+func (ck *defaultChecker) intervalJitter(check *Check) time.Duration {
+	if check.IntervalJitter > 0 {
+		return check.IntervalJitter
+	}
+	return ck.cfg.intervalJitter
+}
+
+// randomDuration returns a random duration in the half-open interval [0, max).
+func randomDuration(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
+func isCacheExpired(now time.Time, cacheDuration time.Duration, state *CheckState) bool {
+	return state.LastCheckedAt.IsZero() || state.LastCheckedAt.Before(now.Add(-cacheDuration))
+}
+
+func isIncluded(filter CheckFilter, check *Check) bool {
+	return filter == nil || filter(*check)
 }
 
 func isPeriodicCheck(check *Check) bool {
-	return check.updateInterval > 0
+	return check.updateInterval > 0 || check.schedule != nil
+}
+
+// validateChecks parses the schedules of the checks (see Check.Schedule) and groups them by their
+// dependencies (see orderByDependencies). An error is returned if any check is invalid.
+func validateChecks(checks map[string]*Check) ([][]*Check, error) {
+	for _, check := range checks {
+		if err := parseSchedule(check); err != nil {
+			return nil, err
+		}
+	}
+
+	return orderByDependencies(checks)
+}
+
+// parseSchedule parses the cron expression of the check (see Check.Schedule).
+func parseSchedule(check *Check) error {
+	if check.Schedule == "" {
+		return nil
+	}
+
+	schedule, err := cron.ParseStandard(check.Schedule)
+	if err != nil {
+		return fmt.Errorf("check %q has an invalid schedule %q: %w", check.Name, check.Schedule, err)
+	}
+
+	check.schedule = schedule
+
+	return nil
+}
+
+// nextExecutionDelay returns the time to wait before the next execution of a periodic check. The second
+// return value is false, if the check has a schedule (see Check.Schedule) that will never be due again.
+func nextExecutionDelay(now time.Time, check *Check, contiguousFails uint) (time.Duration, bool) {
+	if check.schedule == nil {
+		return check.Backoff.interval(check.updateInterval, contiguousFails), true
+	}
+
+	next := check.schedule.Next(now)
+	if next.IsZero() {
+		return 0, false
+	}
+
+	return next.Sub(now), true
 }
 
-func waitForStopSignal(ctx context.Context, waitTime time.Duration) bool {
+func waitForStopSignal(ctx context.Context, clock Clock, waitTime time.Duration) bool {
 	select {
-	case <-time.After(waitTime):
+	case <-clock.After(waitTime):
 		return false
 	case <-ctx.Done():
 		return true
@@ -435,7 +1686,7 @@ func withCheckContext(ctx context.Context, check *Check, f func(checkCtx context
 		ctx, cancel = context.WithTimeout(ctx, check.Timeout)
 	}
 	defer cancel()
-	f(ctx)
+	f(withCheckMetadata(ctx, check))
 }
 
 func executeCheck(
@@ -443,11 +1694,16 @@ func executeCheck(
 	cfg *checkerConfig,
 	check *Check,
 	oldState CheckState,
+	inGracePeriod bool,
 ) (context.Context, CheckState) {
+	if check.CircuitBreaker.isOpen(cfg.clock.Now(), &oldState) {
+		return ctx, openCircuit(oldState)
+	}
+
 	newState := oldState
 
 	if newState.FirstCheckStartedAt.IsZero() {
-		newState.FirstCheckStartedAt = time.Now().UTC()
+		newState.FirstCheckStartedAt = cfg.clock.Now().UTC()
 	}
 
 	// We copy explicitly to not affect the underlying array of the slices as a side effect.
@@ -457,57 +1713,195 @@ func executeCheck(
 	interceptors = append(interceptors, cfg.interceptors...)
 	interceptors = append(interceptors, check.Interceptors...)
 
+	cfg.logger.Debug("health check started", "check", check.Name)
+
 	newState = withInterceptors(interceptors, func(ctx context.Context, _ string, state CheckState) CheckState {
-		checkFuncResult := executeCheckFunc(ctx, check)
-		return createNextCheckState(checkFuncResult, check, state)
+		startedAt := cfg.clock.Now()
+		data, attempts, checkFuncResult := executeCheckFuncWithRetries(ctx, cfg, check)
+		now := cfg.clock.Now()
+		nextState := createNextCheckState(now, checkFuncResult, check, state)
+		nextState.Data = data
+		nextState.Attempts = attempts
+		nextState.LastCheckDuration = now.Sub(startedAt)
+		if cfg.flapDetection != nil {
+			nextState = cfg.flapDetection.detectFlapping(now, state, nextState)
+		}
+		if inGracePeriod && nextState.Status == StatusDown {
+			nextState.Status = StatusStarting
+		}
+		return nextState
 	})(ctx, check.Name, newState)
 
-	if check.StatusListener != nil && oldState.Status != newState.Status {
-		check.StatusListener(ctx, check.Name, newState)
-	}
+	logCheckResult(cfg.logger, check, &newState)
+	notifyStatusListeners(ctx, cfg, check, oldState, newState)
+	callLifecycleHooks(ctx, cfg, check, oldState, newState)
 
 	return ctx, newState
 }
 
-func executeCheckFunc(ctx context.Context, check *Check) error {
+// notifyStatusListeners calls the status listeners of the check (see Check.StatusListener
+// and Check.StatusTransitionListener) if the status has changed and the check is not silenced (see Silence).
+func notifyStatusListeners(ctx context.Context, cfg *checkerConfig, check *Check, oldState, newState CheckState) {
+	if oldState.Status == newState.Status || cfg.silences.isSilenced(cfg.clock.Now(), check) {
+		return
+	}
+
+	if check.StatusListener != nil {
+		callListener(cfg, "status listener of check "+check.Name, func() {
+			check.StatusListener(ctx, check.Name, newState)
+		})
+	}
+
+	if check.StatusTransitionListener != nil {
+		callListener(cfg, "status transition listener of check "+check.Name, func() {
+			check.StatusTransitionListener(ctx, check.Name, oldState, newState)
+		})
+	}
+}
+
+func dependencyUnavailableErr(dependency string, dependencyState CheckState) error {
+	return fmt.Errorf("%w: check %q is %s", DependencyUnavailableErr, dependency, dependencyState.Status)
+}
+
+func skipCheck(ctx context.Context, cfg *checkerConfig, check *Check, oldState CheckState, reason error) CheckState {
+	newState := oldState
+	newState.Status = StatusSkipped
+	newState.Result = reason
+
+	notifyStatusListeners(withCheckMetadata(ctx, check), cfg, check, oldState, newState)
+
+	return newState
+}
+
+// executeCheckFuncWithRetries executes the check function and retries it according to the retry policy of the
+// check (see Check.Retry). It returns the result of the last attempt along with the number of attempts.
+func executeCheckFuncWithRetries(
+	ctx context.Context,
+	cfg *checkerConfig,
+	check *Check,
+) (map[string]interface{}, uint, error) {
+	data, err := executeCheckFunc(ctx, cfg, check)
+	interval := check.Retry.Interval
+	attempts := uint(1)
+
+	for ; isRetryable(err) && attempts <= check.Retry.MaxRetries; attempts++ {
+		if waitForStopSignal(ctx, cfg.clock, interval) {
+			return data, attempts, err
+		}
+
+		data, err = executeCheckFunc(ctx, cfg, check)
+		interval = check.Retry.nextInterval(interval)
+	}
+
+	return data, attempts, err
+}
+
+// isRetryable returns true if the check function failed (see Check.Retry). A DegradedError is not
+// retried, because the component is still available.
+func isRetryable(err error) bool {
+	var degradedErr *DegradedError
+	return err != nil && !errors.As(err, &degradedErr)
+}
+
+func (p *RetryPolicy) nextInterval(interval time.Duration) time.Duration {
+	if p.Multiplier > 1 {
+		interval = time.Duration(float64(interval) * p.Multiplier)
+	}
+
+	if p.MaxInterval > 0 && interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+
+	return interval
+}
+
+// interval returns the time to wait before the next execution of a periodic check,
+// based on the number of contiguous failures (see BackoffPolicy).
+func (p *BackoffPolicy) interval(refreshPeriod time.Duration, contiguousFails uint) time.Duration {
+	if p.Multiplier <= 1 || contiguousFails == 0 {
+		return refreshPeriod
+	}
+
+	interval := float64(p.InitialInterval)
+	if interval <= 0 {
+		interval = float64(refreshPeriod)
+	}
+
+	interval *= math.Pow(p.Multiplier, float64(contiguousFails-1))
+
+	if p.MaxInterval > 0 && interval > float64(p.MaxInterval) {
+		interval = float64(p.MaxInterval)
+	}
+
+	if p.Jitter > 0 {
+		interval *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+
+	if interval > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(interval)
+}
+
+func executeCheckFunc(ctx context.Context, cfg *checkerConfig, check *Check) (map[string]interface{}, error) {
+	type checkFuncResult struct {
+		data map[string]interface{}
+		err  error
+	}
+
 	// If this channel is not bounded, we may have a goroutine leak (e.g., when ctx.Done signals first then
 	// sending the check result into the channel will block forever).
-	res := make(chan error, 1)
+	res := make(chan checkFuncResult, 1)
 
 	go func() {
 		defer func() {
 			if !check.DisablePanicRecovery {
 				if r := recover(); r != nil {
-					// TODO: Provide a configurable panic handler configuration option, so developers can decide
-					// 	what to do with panics.
-					err, ok := r.(error)
-					if ok {
-						res <- err
-					} else {
-						res <- fmt.Errorf("%v", r)
+					panicErr := sanitizePanicError(cfg, &PanicError{Value: r, Stack: debug.Stack()})
+					cfg.logger.Error("health check panicked", "check", check.Name, "error", panicErr)
+					if cfg.panicHandler != nil {
+						cfg.panicHandler(check.Name, panicErr)
+					}
+					res <- checkFuncResult{
+						data: map[string]interface{}{"panicStack": truncate(string(panicErr.Stack), maxPanicStackLength)},
+						err:  panicErr,
 					}
 				}
 			}
 		}()
 
-		res <- check.Check(ctx)
+		if check.CheckWithData != nil {
+			data, err := check.CheckWithData(ctx)
+			res <- checkFuncResult{data, sanitizeError(cfg, err)}
+			return
+		}
+
+		res <- checkFuncResult{err: sanitizeError(cfg, check.Check(ctx))}
 	}()
 
 	select {
-	case err := <-res:
-		return err
+	case result := <-res:
+		// A check function that adheres to the context deadline returns the context error
+		// instead of running until the deadline is handled above.
+		if errors.Is(result.err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return result.data, fmt.Errorf("%w: %v", CheckTimeoutErr, result.err)
+		}
+		return result.data, result.err
 	case <-ctx.Done():
-		return CheckTimeoutErr
+		return nil, CheckTimeoutErr
 	}
 }
 
-func createNextCheckState(result error, check *Check, state CheckState) CheckState {
-	now := time.Now().UTC()
+func createNextCheckState(now time.Time, result error, check *Check, state CheckState) CheckState {
+	now = now.UTC()
 
 	state.Result = result
+	state.TimedOut = errors.Is(result, CheckTimeoutErr)
 	state.LastCheckedAt = now
+	state.CheckCount++
 
-	if state.Result == nil {
+	if state.Result == nil || isDegraded(state.Result) {
 		state.ContiguousFails = 0
 		state.LastSuccessAt = now
 	} else {
@@ -515,18 +1909,75 @@ func createNextCheckState(result error, check *Check, state CheckState) CheckSta
 		state.LastFailureAt = now
 	}
 
-	state.Status = evaluateCheckStatus(&state, check.MaxTimeInError, check.MaxContiguousFails)
+	if check.FailureRateThreshold > 0 {
+		state.recentFailures = appendToWindow(state.recentFailures, state.Result != nil && !isDegraded(state.Result),
+			failureRateWindow(check))
+	}
+
+	state.Status = evaluateStatus(now, &state, check)
 
 	return state
 }
 
-func evaluateCheckStatus(state *CheckState, maxTimeInError time.Duration, maxFails uint) AvailabilityStatus {
+// evaluateStatus evaluates the status of a check based on its failure rate (see Check.FailureRateThreshold)
+// or based on its failure count and time in error (see Check.MaxContiguousFails and Check.MaxTimeInError).
+func evaluateStatus(now time.Time, state *CheckState, check *Check) AvailabilityStatus {
+	if check.FailureRateThreshold > 0 {
+		return evaluateFailureRate(state, check.FailureRateThreshold)
+	}
+	return evaluateCheckStatus(now, state, check.MaxTimeInError, check.MaxContiguousFails)
+}
+
+func evaluateFailureRate(state *CheckState, threshold float64) AvailabilityStatus {
+	if state.LastCheckedAt.IsZero() {
+		return StatusUnknown
+	}
+
+	failures := 0
+	for _, failed := range state.recentFailures {
+		if failed {
+			failures++
+		}
+	}
+
+	if len(state.recentFailures) > 0 && float64(failures)/float64(len(state.recentFailures)) > threshold {
+		return StatusDown
+	} else if isDegraded(state.Result) {
+		return StatusDegraded
+	}
+
+	return StatusUp
+}
+
+func failureRateWindow(check *Check) int {
+	if check.FailureRateWindow > 0 {
+		return int(check.FailureRateWindow)
+	}
+	return 10
+}
+
+// appendToWindow appends a value to a sliding window of the given size. It always creates a new slice,
+// because the old slice may still be referenced by another copy of the check state (e.g., by a Publisher).
+func appendToWindow(window []bool, value bool, size int) []bool {
+	if len(window) >= size {
+		window = window[len(window)-size+1:]
+	}
+
+	result := make([]bool, 0, len(window)+1)
+	result = append(result, window...)
+
+	return append(result, value)
+}
+
+func evaluateCheckStatus(now time.Time, state *CheckState, maxTimeInError time.Duration, maxFails uint) AvailabilityStatus {
 	if state.LastCheckedAt.IsZero() {
 		return StatusUnknown
+	} else if isDegraded(state.Result) {
+		return StatusDegraded
 	} else if state.Result != nil {
-		maxTimeInErrorSinceStartPassed := !state.FirstCheckStartedAt.Add(maxTimeInError).After(time.Now())
+		maxTimeInErrorSinceStartPassed := !state.FirstCheckStartedAt.Add(maxTimeInError).After(now)
 		maxTimeInErrorSinceLastSuccessPassed := state.LastSuccessAt.IsZero() ||
-			!state.LastSuccessAt.Add(maxTimeInError).After(time.Now())
+			!state.LastSuccessAt.Add(maxTimeInError).After(now)
 
 		timeInErrorThresholdCrossed := maxTimeInErrorSinceStartPassed && maxTimeInErrorSinceLastSuccessPassed
 		failCountThresholdCrossed := state.ContiguousFails >= maxFails
@@ -551,6 +2002,85 @@ func aggregateStatus(results map[string]CheckState) AvailabilityStatus {
 	return status
 }
 
+// orderByDependencies groups checks into levels, so that each check is placed on a higher level
+// than all checks it depends on (see Check.DependsOn). Checks within a level are sorted by name.
+// An error is returned if a check depends on an unknown check or if there is a dependency cycle.
+func orderByDependencies(checks map[string]*Check) ([][]*Check, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		marks  = make(map[string]int, len(checks))
+		levels = make(map[string]int, len(checks))
+		visit  func(check *Check, path []string) error
+	)
+
+	visit = func(check *Check, path []string) error {
+		switch marks[check.Name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(append(path, check.Name), " -> "))
+		}
+
+		marks[check.Name] = visiting
+		level := 0
+
+		for _, dependencyName := range check.DependsOn {
+			dependency, ok := checks[dependencyName]
+			if !ok {
+				return fmt.Errorf("check %q depends on unknown check %q", check.Name, dependencyName)
+			}
+
+			if err := visit(dependency, append(path, check.Name)); err != nil {
+				return err
+			}
+
+			if levels[dependencyName]+1 > level {
+				level = levels[dependencyName] + 1
+			}
+		}
+
+		marks[check.Name] = visited
+		levels[check.Name] = level
+
+		return nil
+	}
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result [][]*Check
+	for _, name := range names {
+		if err := visit(checks[name], nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range names {
+		level := levels[name]
+		for len(result) <= level {
+			result = append(result, nil)
+		}
+		result[level] = append(result[level], checks[name])
+	}
+
+	// Within a level, checks with a higher priority come first (see Check.Priority).
+	for _, levelChecks := range result {
+		sort.SliceStable(levelChecks, func(i, j int) bool {
+			return levelChecks[i].Priority > levelChecks[j].Priority
+		})
+	}
+
+	return result, nil
+}
+
 func withInterceptors(interceptors []Interceptor, target InterceptorFunc) InterceptorFunc {
 	chain := target
 
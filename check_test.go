@@ -2,9 +2,12 @@ package health
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -39,7 +42,7 @@ func doTestEvaluateAvailabilityStatus(
 	state CheckState,
 ) {
 	// Act
-	result := evaluateCheckStatus(&state, maxTimeInError, maxFails)
+	result := evaluateCheckStatus(time.Now(), &state, maxTimeInError, maxFails)
 
 	// Assert
 	assert.Equal(t, expectedStatus, result)
@@ -108,9 +111,42 @@ func TestStartStopManualPeriodicChecks(t *testing.T) {
 
 	ckr.Start()
 	assert.Equal(t, 1, ckr.GetRunningPeriodicCheckCount())
+	assert.Equal(t, []string{"check"}, ckr.GetRunningPeriodicCheckNames())
 
 	ckr.Stop()
 	assert.Equal(t, 0, ckr.GetRunningPeriodicCheckCount())
+	assert.Empty(t, ckr.GetRunningPeriodicCheckNames())
+}
+
+func TestGetCheckStateReturnsCachedStateWithoutExecutingCheck(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	stateBefore, existsBefore := ckr.GetCheckState("database")
+	ckr.Check(context.Background())
+	stateAfter, existsAfter := ckr.GetCheckState("database")
+	_, unknownExists := ckr.GetCheckState("unknown")
+
+	// Assert
+	assert.True(t, existsBefore)
+	assert.Equal(t, StatusUnknown, stateBefore.Status)
+	assert.True(t, existsAfter)
+	assert.Equal(t, StatusUp, stateAfter.Status)
+	assert.False(t, stateAfter.LastCheckedAt.IsZero())
+	assert.False(t, unknownExists)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
 }
 
 func doTestCheckerCheckFunc(t *testing.T, updateInterval time.Duration, err error, expectedStatus AvailabilityStatus) {
@@ -179,3 +215,921 @@ func TestPanicRecovery(t *testing.T) {
 	assert.NotNil(t, checkRes.Error)
 	assert.Equal(t, (checkRes.Error).Error(), expectedPanicMsg)
 }
+
+func TestPanicHandlerAndStackTrace(t *testing.T) {
+	// Arrange
+	var handledCheck string
+	var handledErr *PanicError
+	panicErr := errors.New("nil pointer")
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithPanicHandler(func(checkName string, err *PanicError) {
+			handledCheck = checkName
+			handledErr = err
+		}),
+		WithCheck(Check{
+			Name: "iPanic",
+			Check: func(ctx context.Context) error {
+				panic(panicErr)
+			},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, "iPanic", handledCheck)
+	require.NotNil(t, handledErr)
+	assert.Equal(t, panicErr, handledErr.Value)
+	assert.Contains(t, string(handledErr.Stack), "TestPanicHandlerAndStackTrace")
+
+	checkRes := res.Details["iPanic"]
+	assert.ErrorIs(t, checkRes.Error, panicErr)
+	assert.Contains(t, checkRes.Data["panicStack"], "TestPanicHandlerAndStackTrace")
+	assert.LessOrEqual(t, len(checkRes.Data["panicStack"].(string)), maxPanicStackLength)
+}
+
+func TestRetriesOnlyRecordFinalOutcome(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if atomic.AddInt32(&calls, 1) < 3 {
+					return fmt.Errorf("transient error")
+				}
+				return nil
+			},
+			Retry: RetryPolicy{MaxRetries: 3, Interval: 1 * time.Millisecond, Multiplier: 2},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, uint(3), res.Details["database"].Attempts)
+	state, _ := ckr.GetCheckState("database")
+	assert.Equal(t, uint(0), state.ContiguousFails)
+	assert.True(t, state.LastFailureAt.IsZero())
+}
+
+func TestRetriesAbortWhenContextIsCancelled(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name:    "database",
+			Timeout: 50 * time.Millisecond,
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return fmt.Errorf("permanent error")
+			},
+			Retry: RetryPolicy{MaxRetries: 10, Interval: 1 * time.Hour},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDown, res.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, uint(1), res.Details["database"].Attempts)
+	state, _ := ckr.GetCheckState("database")
+	assert.Equal(t, uint(1), state.ContiguousFails)
+}
+
+func TestRetriesSkipDegradedResults(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return NewDegradedError(fmt.Errorf("replica lag"))
+			},
+			Retry: RetryPolicy{MaxRetries: 3, Interval: 1 * time.Hour},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDegraded, res.Details["database"].Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, uint(1), res.Details["database"].Attempts)
+}
+
+func TestRetryPolicyNextInterval(t *testing.T) {
+	constant := RetryPolicy{Interval: 1 * time.Second}
+	assert.Equal(t, 1*time.Second, constant.nextInterval(1*time.Second))
+
+	exponential := RetryPolicy{Interval: 1 * time.Second, Multiplier: 2, MaxInterval: 3 * time.Second}
+	assert.Equal(t, 2*time.Second, exponential.nextInterval(1*time.Second))
+	assert.Equal(t, 3*time.Second, exponential.nextInterval(2*time.Second))
+}
+
+func TestDependentCheckIsSkippedWhenDependencyIsDown(t *testing.T) {
+	// Arrange
+	var searchCalls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("database is down")
+			},
+		}),
+		WithCheck(Check{
+			Name:      "search",
+			DependsOn: []string{"database"},
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&searchCalls, 1)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDown, res.Status)
+	assert.Equal(t, StatusDown, res.Details["database"].Status)
+	assert.Equal(t, StatusSkipped, res.Details["search"].Status)
+	assert.ErrorIs(t, res.Details["search"].Error, DependencyUnavailableErr)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&searchCalls))
+}
+
+func TestDependentCheckIsExecutedWhenDependencyIsUp(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name:      "search",
+			DependsOn: []string{"database"},
+			Check:     func(ctx context.Context) error { return nil },
+		}),
+		WithCheck(Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Equal(t, StatusUp, res.Details["search"].Status)
+}
+
+func TestOrderByDependencies(t *testing.T) {
+	// Arrange
+	checks := map[string]*Check{
+		"a": {Name: "a"},
+		"b": {Name: "b", DependsOn: []string{"a"}},
+		"c": {Name: "c", DependsOn: []string{"a", "b"}},
+		"d": {Name: "d"},
+	}
+
+	// Act
+	levels, err := orderByDependencies(checks)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, levels, 3)
+	assert.Equal(t, []*Check{checks["a"], checks["d"]}, levels[0])
+	assert.Equal(t, []*Check{checks["b"]}, levels[1])
+	assert.Equal(t, []*Check{checks["c"]}, levels[2])
+}
+
+func TestNewCheckerPanicsOnInvalidDependencies(t *testing.T) {
+	assert.Panics(t, func() {
+		NewChecker(
+			WithDisabledAutostart(),
+			WithCheck(Check{Name: "a", DependsOn: []string{"b"}}),
+			WithCheck(Check{Name: "b", DependsOn: []string{"a"}}),
+		)
+	})
+
+	assert.Panics(t, func() {
+		NewChecker(
+			WithDisabledAutostart(),
+			WithCheck(Check{Name: "a", DependsOn: []string{"unknown"}}),
+		)
+	})
+}
+
+func TestStatusDownBeforeStatusDegraded(t *testing.T) {
+	// Arrange
+	testData := map[string]CheckState{"check1": {Status: StatusDegraded}, "check2": {Status: StatusDown}}
+
+	// Act
+	result := aggregateStatus(testData)
+
+	// Assert
+	assert.Equal(t, result, StatusDown)
+}
+
+func TestStatusDegradedBeforeStatusUp(t *testing.T) {
+	// Arrange
+	testData := map[string]CheckState{"check1": {Status: StatusUp}, "check2": {Status: StatusDegraded}}
+
+	// Act
+	result := aggregateStatus(testData)
+
+	// Assert
+	assert.Equal(t, result, StatusDegraded)
+}
+
+func TestWhenDegradedErrorThenStatusDegraded(t *testing.T) {
+	doTestEvaluateAvailabilityStatus(t, StatusDegraded, 0, 0, CheckState{
+		LastCheckedAt: time.Now(),
+		Result:        NewDegradedError(fmt.Errorf("slow responses")),
+	})
+}
+
+func TestDegradedCheckIsNotCountedAsFailure(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				return NewDegradedError(fmt.Errorf("slow responses"))
+			},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDegraded, res.Status)
+	assert.Equal(t, StatusDegraded, res.Details["database"].Status)
+	state, _ := ckr.GetCheckState("database")
+	assert.Equal(t, uint(0), state.ContiguousFails)
+	assert.True(t, state.LastFailureAt.IsZero())
+}
+
+func TestNonCriticalCheckDoesNotAffectAggregatedStatus(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+		WithCheck(Check{
+			Name:        "cache",
+			NonCritical: true,
+			Check:       func(ctx context.Context) error { return fmt.Errorf("cache is down") },
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Equal(t, StatusUp, res.Details["database"].Status)
+	assert.Equal(t, StatusDown, res.Details["cache"].Status)
+}
+
+func TestCheckStateContainsExecutionStatistics(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	ckr.Check(context.Background())
+
+	// Assert
+	state, _ := ckr.GetCheckState("database")
+	assert.Equal(t, uint(2), state.CheckCount)
+	assert.GreaterOrEqual(t, state.LastCheckDuration, 5*time.Millisecond)
+}
+
+func TestPausedCheckerDoesNotExecuteChecks(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{
+			Name: "check",
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	ckr.Pause()
+	pausedResult := ckr.Check(context.Background())
+	ckr.Resume()
+	resumedResult := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusMaintenance, pausedResult.Status)
+	assert.Equal(t, StatusUp, resumedResult.Status)
+	assert.False(t, ckr.IsPaused())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestStopWithoutStartHasNoEffect(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(WithDisabledAutostart())
+
+	// Act + Assert
+	assert.NotPanics(t, ckr.Stop)
+	assert.False(t, ckr.IsStarted())
+}
+
+func TestAddAndRemoveChecksAtRuntime(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(WithDisabledCache())
+	defer ckr.Stop()
+
+	// Act + Assert
+	require.NoError(t, ckr.AddCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}))
+	require.NoError(t, ckr.AddPeriodicCheck(1*time.Hour, 0, Check{
+		Name:      "tenant-db",
+		DependsOn: []string{"database"},
+		Check:     func(ctx context.Context) error { return nil },
+	}))
+	assert.Error(t, ckr.AddCheck(Check{Name: "database"}))
+	assert.Error(t, ckr.AddCheck(Check{Name: "other", DependsOn: []string{"unknown"}}))
+	assert.Equal(t, []string{"database", "tenant-db"}, ckr.GetCheckNames(nil))
+	assert.Equal(t, []string{"tenant-db"}, ckr.GetRunningPeriodicCheckNames())
+
+	assert.Error(t, ckr.RemoveCheck("database"))
+	require.NoError(t, ckr.RemoveCheck("tenant-db"))
+	require.NoError(t, ckr.RemoveCheck("database"))
+	assert.Error(t, ckr.RemoveCheck("database"))
+	assert.Empty(t, ckr.GetCheckNames(nil))
+	assert.Empty(t, ckr.GetRunningPeriodicCheckNames())
+	assert.Equal(t, StatusUp, ckr.Check(context.Background()).Status)
+}
+
+func TestCheckCacheDurationOverridesGlobalCacheDuration(t *testing.T) {
+	// Arrange
+	var cheapCalls, expensiveCalls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Nanosecond),
+		WithCheck(Check{
+			Name: "cheap",
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&cheapCalls, 1)
+				return nil
+			},
+		}),
+		WithCheck(Check{
+			Name:          "expensive",
+			CacheDuration: 1 * time.Hour,
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&expensiveCalls, 1)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	time.Sleep(1 * time.Millisecond)
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(2), atomic.LoadInt32(&cheapCalls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&expensiveCalls))
+}
+
+func TestTransitiveDependentChecksAreSkipped(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return fmt.Errorf("down") }}),
+		WithCheck(Check{Name: "orders-api", DependsOn: []string{"database"}, Check: func(ctx context.Context) error {
+			return nil
+		}}),
+		WithCheck(Check{Name: "checkout", DependsOn: []string{"orders-api"}, Check: func(ctx context.Context) error {
+			return nil
+		}}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusSkipped, res.Details["orders-api"].Status)
+	assert.Equal(t, StatusSkipped, res.Details["checkout"].Status)
+	assert.Contains(t, res.Details["checkout"].Error.Error(), "orders-api")
+}
+
+func TestBackoffPolicyInterval(t *testing.T) {
+	disabled := BackoffPolicy{}
+	assert.Equal(t, 5*time.Second, disabled.interval(5*time.Second, 3))
+
+	exponential := BackoffPolicy{Multiplier: 2, MaxInterval: 30 * time.Second}
+	assert.Equal(t, 5*time.Second, exponential.interval(5*time.Second, 0))
+	assert.Equal(t, 5*time.Second, exponential.interval(5*time.Second, 1))
+	assert.Equal(t, 20*time.Second, exponential.interval(5*time.Second, 3))
+	assert.Equal(t, 30*time.Second, exponential.interval(5*time.Second, 100))
+
+	withInitialInterval := BackoffPolicy{InitialInterval: 10 * time.Second, Multiplier: 3}
+	assert.Equal(t, 90*time.Second, withInitialInterval.interval(5*time.Second, 3))
+
+	withJitter := BackoffPolicy{InitialInterval: 10 * time.Second, Multiplier: 2, Jitter: 0.1}
+	interval := withJitter.interval(5*time.Second, 1)
+	assert.GreaterOrEqual(t, interval, 9*time.Second)
+	assert.LessOrEqual(t, interval, 11*time.Second)
+}
+
+func TestPeriodicCheckBacksOffWhileFailing(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithPeriodicCheck(5*time.Millisecond, 0, Check{
+			Name:    "database",
+			Backoff: BackoffPolicy{InitialInterval: 1 * time.Hour, Multiplier: 2},
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return fmt.Errorf("down")
+			},
+		}),
+	)
+
+	// Act
+	ckr.Start()
+	time.Sleep(50 * time.Millisecond)
+	ckr.Stop()
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRandomDuration(t *testing.T) {
+	assert.Equal(t, time.Duration(0), randomDuration(0))
+
+	for i := 0; i < 100; i++ {
+		d := randomDuration(10 * time.Millisecond)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.Less(t, d, 10*time.Millisecond)
+	}
+}
+
+func TestCheckIntervalJitterOverridesGlobalJitter(t *testing.T) {
+	// Arrange
+	ckr := newChecker(checkerConfig{intervalJitter: 1 * time.Second, autostartDisabled: true, checks: map[string]*Check{}})
+
+	// Act
+	globalJitter := ckr.intervalJitter(&Check{})
+	checkJitter := ckr.intervalJitter(&Check{IntervalJitter: 5 * time.Second})
+
+	// Assert
+	assert.Equal(t, 1*time.Second, globalJitter)
+	assert.Equal(t, 5*time.Second, checkJitter)
+}
+
+func TestScheduledCheckIsExecutedOnStart(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name:     "certificates",
+			Schedule: "CRON_TZ=Europe/Berlin 0 2 * * *",
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	ckr.Start()
+	time.Sleep(50 * time.Millisecond)
+	ckr.Check(context.Background())
+	runningChecks := ckr.GetRunningPeriodicCheckNames()
+	ckr.Stop()
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, []string{"certificates"}, runningChecks)
+}
+
+func TestNextExecutionDelayFollowsSchedule(t *testing.T) {
+	// Arrange
+	check := Check{Name: "certificates", Schedule: "@hourly"}
+	require.NoError(t, parseSchedule(&check))
+
+	// Act
+	delay, ok := nextExecutionDelay(time.Now(), &check, 5)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Greater(t, delay, time.Duration(0))
+	assert.LessOrEqual(t, delay, 1*time.Hour)
+}
+
+func TestInvalidScheduleIsRejected(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(WithDisabledAutostart())
+
+	// Act
+	err := ckr.AddCheck(Check{Name: "certificates", Schedule: "every night", Check: func(ctx context.Context) error {
+		return nil
+	}})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, ckr.GetCheckNames(nil))
+	assert.Panics(t, func() {
+		NewChecker(WithDisabledAutostart(), WithCheck(Check{Name: "certificates", Schedule: "61 * * * *"}))
+	})
+}
+
+func TestCheckNowIgnoresCacheAndPeriod(t *testing.T) {
+	// Arrange
+	var syncCalls, periodicCalls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&syncCalls, 1)
+				return nil
+			},
+		}),
+		WithPeriodicCheck(1*time.Hour, 1*time.Hour, Check{
+			Name: "search",
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&periodicCalls, 1)
+				return fmt.Errorf("down")
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	res, err := ckr.CheckNow(context.Background(), "search")
+	allRes := ckr.CheckAllNow(context.Background())
+	_, unknownErr := ckr.CheckNow(context.Background(), "unknown")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, StatusDown, res.Status)
+	assert.False(t, res.Timestamp.IsZero())
+	assert.Equal(t, StatusDown, allRes.Status)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&syncCalls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&periodicCalls))
+	assert.Error(t, unknownErr)
+}
+
+func TestCheckWithDataReportsData(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name: "database",
+			CheckWithData: func(ctx context.Context) (map[string]interface{}, error) {
+				return map[string]interface{}{"replicationLag": 3}, fmt.Errorf("replication lag too high")
+			},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+	state, _ := ckr.GetCheckState("database")
+	data, err := json.Marshal(res.Details["database"])
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, StatusDown, res.Details["database"].Status)
+	assert.Equal(t, map[string]interface{}{"replicationLag": 3}, res.Details["database"].Data)
+	assert.Equal(t, map[string]interface{}{"replicationLag": 3}, state.Data)
+	assert.Contains(t, string(data), `"data":{"replicationLag":3}`)
+}
+
+func TestStartupGracePeriodReportsFailingChecksAsStarting(t *testing.T) {
+	// Arrange
+	var statusChanges []AvailabilityStatus
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithStartupGracePeriod(100*time.Millisecond),
+		WithStatusListener(func(ctx context.Context, state CheckerState) {
+			statusChanges = append(statusChanges, state.Status)
+		}),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return fmt.Errorf("not ready") }}),
+	)
+
+	// Act
+	ckr.Start()
+	time.Sleep(20 * time.Millisecond)
+	resDuringGracePeriod := ckr.Check(context.Background())
+	time.Sleep(150 * time.Millisecond)
+	stateAfterGracePeriod, _ := ckr.GetCheckState("database")
+	resAfterGracePeriod := ckr.Check(context.Background())
+	ckr.Stop()
+
+	// Assert
+	assert.Equal(t, StatusStarting, resDuringGracePeriod.Status)
+	assert.Equal(t, StatusDown, stateAfterGracePeriod.Status)
+	assert.Equal(t, StatusDown, resAfterGracePeriod.Status)
+	assert.Equal(t, []AvailabilityStatus{StatusStarting, StatusDown}, statusChanges)
+}
+
+func TestCheckIsNotExecutedBeforeInitialDelay(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", InitialDelay: 1 * time.Hour, Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}}),
+	)
+
+	// Act
+	ckr.Start()
+	res := ckr.Check(context.Background())
+	forced, _ := ckr.CheckNow(context.Background(), "database")
+	ckr.Stop()
+
+	// Assert
+	assert.Equal(t, StatusUnknown, res.Status)
+	assert.Equal(t, StatusUp, forced.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestStartingStatusIsLessCriticalThanUnknown(t *testing.T) {
+	testData := map[string]CheckState{"check1": {Status: StatusStarting}, "check2": {Status: StatusDegraded}}
+	assert.Equal(t, StatusStarting, aggregateStatus(testData))
+
+	testData["check3"] = CheckState{Status: StatusUnknown}
+	assert.Equal(t, StatusUnknown, aggregateStatus(testData))
+}
+
+func TestFailureRateThreshold(t *testing.T) {
+	// Arrange
+	var calls int
+	outcomes := []bool{false, true, false, true, true, true, false, false, false}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{
+			Name:                 "database",
+			FailureRateThreshold: 0.5,
+			FailureRateWindow:    4,
+			Check: func(ctx context.Context) error {
+				failed := outcomes[calls]
+				calls++
+				if failed {
+					return fmt.Errorf("failed")
+				}
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	var statuses []AvailabilityStatus
+	for range outcomes {
+		statuses = append(statuses, ckr.Check(context.Background()).Status)
+	}
+
+	// Assert
+	assert.Equal(t, []AvailabilityStatus{
+		StatusUp,   // [ok]
+		StatusUp,   // [ok, fail] = 50%
+		StatusUp,   // [ok, fail, ok] = 33%
+		StatusUp,   // [ok, fail, ok, fail] = 50%
+		StatusDown, // [fail, ok, fail, fail] = 75%
+		StatusDown, // [ok, fail, fail, fail] = 75%
+		StatusDown, // [fail, fail, fail, ok] = 75%
+		StatusUp,   // [fail, fail, ok, ok] = 50%
+		StatusUp,   // [fail, ok, ok, ok] = 25%
+	}, statuses)
+}
+
+func TestAppendToWindow(t *testing.T) {
+	window := appendToWindow(nil, true, 2)
+	window = appendToWindow(window, false, 2)
+	window = appendToWindow(window, true, 2)
+	assert.Equal(t, []bool{false, true}, window)
+}
+
+func TestCheckTimeoutIsReportedSeparately(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name:    "slow",
+			Timeout: 10 * time.Millisecond,
+			Check: func(ctx context.Context) error {
+				time.Sleep(200 * time.Millisecond)
+				return nil
+			},
+		}),
+		WithCheck(Check{
+			Name:    "context-aware",
+			Timeout: 10 * time.Millisecond,
+			Check: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}),
+		WithCheck(Check{
+			Name: "failing",
+			Check: func(ctx context.Context) error {
+				return fmt.Errorf("connection refused")
+			},
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	for _, name := range []string{"slow", "context-aware"} {
+		state, _ := ckr.GetCheckState(name)
+		assert.True(t, state.TimedOut, name)
+		assert.ErrorIs(t, state.Result, CheckTimeoutErr, name)
+		assert.True(t, res.Details[name].TimedOut, name)
+	}
+
+	state, _ := ckr.GetCheckState("failing")
+	assert.False(t, state.TimedOut)
+	assert.False(t, res.Details["failing"].TimedOut)
+}
+
+func TestMaxConcurrentChecksLimitsConcurrency(t *testing.T) {
+	// Arrange
+	var running, maxRunning int32
+	check := func(ctx context.Context) error {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	opts := []CheckerOption{WithDisabledAutostart(), WithMaxConcurrentChecks(2)}
+	for i := 0; i < 6; i++ {
+		opts = append(opts, WithCheck(Check{Name: fmt.Sprintf("check-%d", i), Check: check}))
+	}
+	ckr := NewChecker(opts...)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Len(t, res.Details, 6)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+}
+
+func TestCheckResultsContainDurationsAndSlowestCheck(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "fast", Check: func(ctx context.Context) error {
+			return nil
+		}}),
+		WithCheck(Check{Name: "slow", Check: func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		}}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+	name, duration, ok := res.SlowestCheck()
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, "slow", name)
+	assert.GreaterOrEqual(t, duration, 20*time.Millisecond)
+	assert.Less(t, res.Details["fast"].Duration, res.Details["slow"].Duration)
+
+	data, err := json.Marshal(res.Details["slow"])
+	assert.NoError(t, err)
+
+	var unmarshalled CheckResult
+	assert.NoError(t, json.Unmarshal(data, &unmarshalled))
+	assert.Equal(t, duration, unmarshalled.Duration)
+}
+
+func TestStatusListenersCanReadCheckerState(t *testing.T) {
+	// Arrange
+	var (
+		ckr           Checker
+		listenerState CheckState
+		transitions   []AvailabilityStatus
+	)
+
+	ckr = NewChecker(
+		WithDisabledAutostart(),
+		WithHistorySize(2),
+		WithStatusListener(func(ctx context.Context, state CheckerState) {
+			listenerState, _ = ckr.GetCheckState("database")
+			ckr.GetState()
+			ckr.ListChecks()
+			ckr.GetCheckNames(nil)
+			ckr.GetCheckHistory("database")
+			ckr.GetAvailability("database", time.Hour)
+		}),
+		WithStatusTransitionListener(func(ctx context.Context, oldState, newState CheckerState) {
+			transitions = append(transitions, ckr.GetState().Status)
+		}),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return fmt.Errorf("failed") }}),
+	)
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ckr.Check(context.Background())
+	}()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("status listener deadlocked")
+	}
+	assert.Equal(t, StatusDown, listenerState.Status)
+	assert.Equal(t, []AvailabilityStatus{StatusDown}, transitions)
+}
+
+func TestStatusTransitionListenersReceiveOldAndNewState(t *testing.T) {
+	// Arrange
+	var (
+		fail              int32 = 1
+		checkTransitions  []string
+		systemTransitions []string
+	)
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithStatusTransitionListener(func(ctx context.Context, oldState, newState CheckerState) {
+			systemTransitions = append(systemTransitions, fmt.Sprintf("%s->%s (%d)",
+				oldState.Status, newState.Status, newState.CheckState["database"].CheckCount))
+		}),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if atomic.LoadInt32(&fail) == 1 {
+					return fmt.Errorf("failed")
+				}
+				return nil
+			},
+			StatusTransitionListener: func(ctx context.Context, name string, oldState, newState CheckState) {
+				checkTransitions = append(checkTransitions, fmt.Sprintf("%s:%s->%s", name, oldState.Status, newState.Status))
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	ckr.Check(context.Background())
+	atomic.StoreInt32(&fail, 0)
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, []string{"database:unknown->down", "database:down->up"}, checkTransitions)
+	assert.Equal(t, []string{"unknown->down (1)", "down->up (3)"}, systemTransitions)
+}
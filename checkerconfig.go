@@ -0,0 +1,358 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// CheckerConfig describes a Checker declaratively, so that timeouts, cache settings and checks
+	// can be changed without recompiling (see NewCheckerFromConfig). It can be unmarshalled from
+	// JSON or YAML and be overridden by environment variables (see CheckerConfig.ApplyEnv).
+	// Zero values mean that the respective default value is used.
+	CheckerConfig struct {
+		// Timeout is the global check timeout (see WithTimeout).
+		Timeout ConfigDuration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+		// CacheDuration is the global cache duration (see WithCacheDuration).
+		CacheDuration ConfigDuration `json:"cacheDuration,omitempty" yaml:"cacheDuration,omitempty"`
+		// DisableCache disables caching of check results (see WithDisabledCache).
+		DisableCache bool `json:"disableCache,omitempty" yaml:"disableCache,omitempty"`
+		// MaxConcurrentChecks limits the number of concurrently executed checks (see WithMaxConcurrentChecks).
+		MaxConcurrentChecks uint `json:"maxConcurrentChecks,omitempty" yaml:"maxConcurrentChecks,omitempty"`
+		// Info contains additional information about the service (see WithInfo).
+		Info map[string]interface{} `json:"info,omitempty" yaml:"info,omitempty"`
+		// Checks holds the declarative check configurations.
+		Checks []CheckConfig `json:"checks,omitempty" yaml:"checks,omitempty"`
+	}
+
+	// CheckConfig describes a single check declaratively (see CheckerConfig). The check function is
+	// created by the CheckFactory that was registered for the Type (see RegisterCheckFactory).
+	CheckConfig struct {
+		// Name is the name of the check (see Check.Name). Required.
+		Name string `json:"name" yaml:"name"`
+		// Type selects the CheckFactory that creates the check function (e.g., "http"). Required.
+		Type string `json:"type" yaml:"type"`
+		// Params holds type specific parameters that are passed to the CheckFactory (e.g., the URL of an HTTP check).
+		Params map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+		// Interval makes the check a periodic check that is executed with this refresh period (see WithPeriodicCheck).
+		Interval ConfigDuration `json:"interval,omitempty" yaml:"interval,omitempty"`
+		// InitialDelay is the initial delay of a periodic check (see WithPeriodicCheck).
+		InitialDelay ConfigDuration `json:"initialDelay,omitempty" yaml:"initialDelay,omitempty"`
+		// Schedule is a cron expression that makes the check a periodic check (see Check.Schedule).
+		Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+		// Timeout is the check timeout (see Check.Timeout).
+		Timeout ConfigDuration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+		// CacheDuration is the cache duration of the check (see Check.CacheDuration).
+		CacheDuration ConfigDuration `json:"cacheDuration,omitempty" yaml:"cacheDuration,omitempty"`
+		// MaxContiguousFails is the failure threshold of the check (see Check.MaxContiguousFails).
+		MaxContiguousFails uint `json:"maxContiguousFails,omitempty" yaml:"maxContiguousFails,omitempty"`
+		// MaxTimeInError is the time in error threshold of the check (see Check.MaxTimeInError).
+		MaxTimeInError ConfigDuration `json:"maxTimeInError,omitempty" yaml:"maxTimeInError,omitempty"`
+		// NonCritical marks the check as informational (see Check.NonCritical).
+		NonCritical bool `json:"nonCritical,omitempty" yaml:"nonCritical,omitempty"`
+		// DependsOn holds the names of the checks this check depends on (see Check.DependsOn).
+		DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+		// Tags holds the tags of the check (see Check.Tags).
+		Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+		// Priority is the execution priority of the check (see Check.Priority).
+		Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
+	}
+
+	// ConfigDuration is a time.Duration that is represented as a string in configuration files
+	// (e.g., "1m30s", see time.ParseDuration).
+	ConfigDuration time.Duration
+
+	// CheckFactory creates a check function from the type specific parameters of a declarative check
+	// (see CheckConfig.Params). It should return an error if a required parameter is missing or invalid.
+	CheckFactory func(params map[string]string) (func(ctx context.Context) error, error)
+
+	// DisposableCheckFactory works like CheckFactory, but additionally returns a function that releases the
+	// resources held by the check function (e.g., a connection pool). The dispose function is called when the
+	// check is replaced or removed (see Checker.Reload and Checker.RemoveCheck). It may be nil.
+	DisposableCheckFactory func(params map[string]string) (check func(ctx context.Context) error, dispose func() error, err error)
+)
+
+var (
+	checkFactoriesMtx sync.RWMutex
+	checkFactories    = map[string]DisposableCheckFactory{}
+)
+
+// RegisterCheckFactory makes a CheckFactory available for declarative checks of the provided type
+// (see CheckConfig.Type). It panics if a factory for this type was already registered. Package checks
+// registers factories for its check functions (e.g., "http", "tcp" and "sql"), so it needs to be imported
+// when using these types.
+func RegisterCheckFactory(checkType string, factory CheckFactory) {
+	RegisterDisposableCheckFactory(checkType, func(params map[string]string) (func(ctx context.Context) error, func() error, error) {
+		check, err := factory(params)
+		return check, nil, err
+	})
+}
+
+// RegisterDisposableCheckFactory makes a DisposableCheckFactory available for declarative checks of the provided
+// type (see CheckConfig.Type). It panics if a factory for this type was already registered.
+func RegisterDisposableCheckFactory(checkType string, factory DisposableCheckFactory) {
+	checkFactoriesMtx.Lock()
+	defer checkFactoriesMtx.Unlock()
+
+	if _, ok := checkFactories[checkType]; ok {
+		panic(fmt.Sprintf("health: check factory for type %q is already registered", checkType))
+	}
+
+	checkFactories[checkType] = factory
+}
+
+// NewCheckerFromConfig creates a new Checker from a declarative configuration (see CheckerConfig).
+// The provided options are applied after the configuration, so they can be used to add settings
+// that cannot be expressed declaratively (e.g., listeners or additional checks). In contrast to
+// NewChecker, it returns an error if the configuration is invalid, including checks that were added
+// using the provided options (e.g., if a check depends on an unknown check; see Check.DependsOn).
+func NewCheckerFromConfig(cfg CheckerConfig, options ...CheckerOption) (Checker, error) {
+	checks, err := cfg.newChecks(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []CheckerOption{cfg.applyGlobalSettings, func(cfg *checkerConfig) {
+		for _, check := range checks {
+			cfg.checks[check.Name] = check
+		}
+	}}
+
+	checkerCfg := newCheckerConfig(append(opts, options...))
+	if _, err := validateChecks(checkerCfg.checks); err != nil {
+		disposeCreatedChecks(checks, nil, nil)
+		return nil, err
+	}
+
+	return newChecker(checkerCfg), nil
+}
+
+// applyGlobalSettings applies all settings of the configuration except for the checks.
+// Zero values are replaced by default values.
+func (cfg *CheckerConfig) applyGlobalSettings(checkerCfg *checkerConfig) {
+	checkerCfg.info = cfg.Info
+	checkerCfg.maxConcurrentChecks = cfg.MaxConcurrentChecks
+	checkerCfg.timeout = defaultTimeout
+	checkerCfg.cacheTTL = defaultCacheDuration
+
+	if cfg.Timeout > 0 {
+		checkerCfg.timeout = time.Duration(cfg.Timeout)
+	}
+
+	if cfg.DisableCache {
+		checkerCfg.cacheTTL = 0
+	} else if cfg.CacheDuration > 0 {
+		checkerCfg.cacheTTL = time.Duration(cfg.CacheDuration)
+	}
+}
+
+// newChecks creates and validates the checks of the configuration. Existing checks whose configuration
+// has not changed are reused instead of being created again (see Checker.Reload). If an error is returned,
+// all checks that were created have been disposed already.
+func (cfg *CheckerConfig) newChecks(existing map[string]*Check) (_ map[string]*Check, err error) {
+	checks := make(map[string]*Check, len(cfg.Checks))
+
+	defer func() {
+		if err != nil {
+			disposeCreatedChecks(checks, existing, nil)
+		}
+	}()
+
+	for idx := range cfg.Checks {
+		checkCfg := &cfg.Checks[idx]
+		if _, ok := checks[checkCfg.Name]; ok {
+			return nil, fmt.Errorf("duplicate check %q", checkCfg.Name)
+		}
+
+		if check, ok := existing[checkCfg.Name]; ok && check.config != nil && reflect.DeepEqual(check.config, checkCfg) {
+			checks[check.Name] = check
+			continue
+		}
+
+		check, err := checkCfg.newCheck()
+		if err != nil {
+			return nil, err
+		}
+
+		checks[check.Name] = check
+	}
+
+	if _, err := orderByDependencies(checks); err != nil {
+		return nil, err
+	}
+
+	return checks, nil
+}
+
+// disposeCreatedChecks disposes all checks that are not part of existing (see DisposableCheckFactory).
+// Errors are logged using the logger, if it is not nil.
+func disposeCreatedChecks(checks map[string]*Check, existing map[string]*Check, logger Logger) {
+	for name, check := range checks {
+		if existing[name] != check {
+			disposeCheck(check, logger)
+		}
+	}
+}
+
+// disposeCheck releases the resources of the check (see DisposableCheckFactory). Errors are logged
+// using the logger, if it is not nil.
+func disposeCheck(check *Check, logger Logger) {
+	if check.dispose == nil {
+		return
+	}
+
+	if err := check.dispose(); err != nil && logger != nil {
+		logger.Error("health check disposal failed", "check", check.Name, "error", err)
+	}
+}
+
+// newCheck creates a Check using the registered CheckFactory of the configured type.
+func (cfg *CheckConfig) newCheck() (*Check, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("check name is missing")
+	}
+
+	checkFactoriesMtx.RLock()
+	factory, ok := checkFactories[cfg.Type]
+	checkFactoriesMtx.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("check %q has unknown type %q", cfg.Name, cfg.Type)
+	}
+
+	checkFunc, dispose, err := factory(cfg.Params)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create check %q: %w", cfg.Name, err)
+	}
+
+	checkCfg := *cfg
+
+	check := Check{
+		Name:               cfg.Name,
+		Check:              checkFunc,
+		Timeout:            time.Duration(cfg.Timeout),
+		CacheDuration:      time.Duration(cfg.CacheDuration),
+		MaxContiguousFails: cfg.MaxContiguousFails,
+		MaxTimeInError:     time.Duration(cfg.MaxTimeInError),
+		Schedule:           cfg.Schedule,
+		NonCritical:        cfg.NonCritical,
+		DependsOn:          cfg.DependsOn,
+		Tags:               cfg.Tags,
+		Priority:           cfg.Priority,
+		updateInterval:     time.Duration(cfg.Interval),
+		initialDelay:       time.Duration(cfg.InitialDelay),
+		config:             &checkCfg,
+		dispose:            dispose,
+	}
+
+	if err := parseSchedule(&check); err != nil {
+		disposeCheck(&check, nil)
+		return nil, err
+	}
+
+	return &check, nil
+}
+
+// ApplyEnv overrides configuration values with the values of environment variables. Variable names start
+// with the provided prefix (e.g., "HEALTH_"). The following variables are supported:
+//   - <prefix>TIMEOUT, <prefix>CACHE_DURATION and <prefix>MAX_CONCURRENT_CHECKS override the global settings.
+//   - <prefix>CHECK_<NAME>_INTERVAL, <prefix>CHECK_<NAME>_TIMEOUT, <prefix>CHECK_<NAME>_CACHE_DURATION,
+//     <prefix>CHECK_<NAME>_MAX_CONTIGUOUS_FAILS and <prefix>CHECK_<NAME>_MAX_TIME_IN_ERROR override the
+//     settings of the check with the respective name. Check names are converted to upper case and all
+//     characters other than letters and digits are replaced by underscores (e.g., "CHECK_ORDER_DB_TIMEOUT"
+//     for a check named "order-db").
+//
+// Durations use the format of time.ParseDuration. An error is returned if a value is invalid.
+func (cfg *CheckerConfig) ApplyEnv(prefix string) error {
+	return cfg.applyEnv(prefix, os.LookupEnv)
+}
+
+func (cfg *CheckerConfig) applyEnv(prefix string, lookup func(key string) (string, bool)) error {
+	env := envOverrides{prefix: prefix, lookup: lookup}
+
+	env.duration("TIMEOUT", &cfg.Timeout)
+	env.duration("CACHE_DURATION", &cfg.CacheDuration)
+	env.uint("MAX_CONCURRENT_CHECKS", &cfg.MaxConcurrentChecks)
+
+	for idx := range cfg.Checks {
+		check := &cfg.Checks[idx]
+		name := "CHECK_" + envName(check.Name) + "_"
+
+		env.duration(name+"INTERVAL", &check.Interval)
+		env.duration(name+"TIMEOUT", &check.Timeout)
+		env.duration(name+"CACHE_DURATION", &check.CacheDuration)
+		env.uint(name+"MAX_CONTIGUOUS_FAILS", &check.MaxContiguousFails)
+		env.duration(name+"MAX_TIME_IN_ERROR", &check.MaxTimeInError)
+	}
+
+	if len(env.errs) > 0 {
+		sort.Strings(env.errs)
+		return fmt.Errorf("invalid environment variables: %s", strings.Join(env.errs, "; "))
+	}
+
+	return nil
+}
+
+// envOverrides reads configuration values from environment variables and collects all errors.
+type envOverrides struct {
+	prefix string
+	lookup func(key string) (string, bool)
+	errs   []string
+}
+
+func (e *envOverrides) duration(name string, target *ConfigDuration) {
+	if value, ok := e.lookup(e.prefix + name); ok {
+		if err := target.UnmarshalText([]byte(value)); err != nil {
+			e.errs = append(e.errs, fmt.Sprintf("%s%s: %v", e.prefix, name, err))
+		}
+	}
+}
+
+func (e *envOverrides) uint(name string, target *uint) {
+	if value, ok := e.lookup(e.prefix + name); ok {
+		parsed, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			e.errs = append(e.errs, fmt.Sprintf("%s%s: %v", e.prefix, name, err))
+			return
+		}
+		*target = uint(parsed)
+	}
+}
+
+// envName converts a check name into the form that is used in environment variable names (see ApplyEnv).
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d ConfigDuration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *ConfigDuration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = ConfigDuration(duration)
+
+	return nil
+}
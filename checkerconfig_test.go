@@ -0,0 +1,152 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	RegisterCheckFactory("static", func(params map[string]string) (func(ctx context.Context) error, error) {
+		if params["error"] == "" {
+			return func(ctx context.Context) error { return nil }, nil
+		}
+		return func(ctx context.Context) error { return errors.New(params["error"]) }, nil
+	})
+}
+
+func TestNewCheckerFromYAMLConfig(t *testing.T) {
+	// Arrange
+	var cfg CheckerConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+timeout: 5s
+disableCache: true
+checks:
+  - name: database
+    type: static
+    maxTimeInError: 1m
+    tags: [ready]
+  - name: search
+    type: static
+    interval: 1h
+    initialDelay: 2s
+    dependsOn: [database]
+    params:
+      error: connection refused
+`), &cfg))
+
+	// Act
+	ckr, err := NewCheckerFromConfig(cfg, WithDisabledAutostart())
+	require.NoError(t, err)
+	res := ckr.CheckAllNow(context.Background())
+
+	// Assert
+	checker := ckr.(*defaultChecker)
+	assert.Equal(t, 5*time.Second, checker.cfg.timeout)
+	assert.Equal(t, time.Duration(0), checker.cfg.cacheTTL)
+	assert.Equal(t, time.Minute, checker.cfg.checks["database"].MaxTimeInError)
+	assert.Equal(t, []string{"ready"}, checker.cfg.checks["database"].Tags)
+	assert.Equal(t, time.Hour, checker.cfg.checks["search"].updateInterval)
+	assert.Equal(t, 2*time.Second, checker.cfg.checks["search"].initialDelay)
+	assert.Equal(t, StatusDown, res.Status)
+	assert.EqualError(t, res.Details["search"].Error, "connection refused")
+}
+
+func TestNewCheckerFromJSONConfig(t *testing.T) {
+	// Arrange
+	var cfg CheckerConfig
+	require.NoError(t, json.Unmarshal([]byte(
+		`{"cacheDuration":"30s","checks":[{"name":"database","type":"static","timeout":"500ms"}]}`), &cfg))
+
+	// Act
+	ckr, err := NewCheckerFromConfig(cfg, WithDisabledAutostart())
+
+	// Assert
+	require.NoError(t, err)
+	checker := ckr.(*defaultChecker)
+	assert.Equal(t, 30*time.Second, checker.cfg.cacheTTL)
+	assert.Equal(t, 500*time.Millisecond, checker.cfg.checks["database"].Timeout)
+}
+
+func TestNewCheckerFromInvalidConfig(t *testing.T) {
+	tests := map[string]CheckerConfig{
+		"unknown type":       {Checks: []CheckConfig{{Name: "database", Type: "unknown"}}},
+		"missing name":       {Checks: []CheckConfig{{Type: "static"}}},
+		"duplicate check":    {Checks: []CheckConfig{{Name: "database", Type: "static"}, {Name: "database", Type: "static"}}},
+		"unknown dependency": {Checks: []CheckConfig{{Name: "database", Type: "static", DependsOn: []string{"other"}}}},
+		"invalid schedule":   {Checks: []CheckConfig{{Name: "database", Type: "static", Schedule: "invalid"}}},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			ckr, err := NewCheckerFromConfig(cfg, WithDisabledAutostart())
+
+			// Assert
+			assert.Error(t, err)
+			assert.Nil(t, ckr)
+		})
+	}
+}
+
+func TestNewCheckerFromConfigWithInvalidOptions(t *testing.T) {
+	// Arrange
+	cfg := CheckerConfig{Checks: []CheckConfig{
+		{Name: "database", Type: "disposable", Params: map[string]string{"id": "invalid-options"}},
+	}}
+	search := Check{Name: "search", DependsOn: []string{"unknown"}, Check: func(ctx context.Context) error { return nil }}
+
+	// Act
+	ckr, err := NewCheckerFromConfig(cfg, WithDisabledAutostart(), WithCheck(search))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, ckr)
+	created, disposed := disposableChecks.counts("invalid-options")
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 1, disposed)
+}
+
+func TestCheckerConfigApplyEnv(t *testing.T) {
+	// Arrange
+	cfg := CheckerConfig{Checks: []CheckConfig{{Name: "order-db", Type: "static", Interval: ConfigDuration(time.Minute)}}}
+	env := map[string]string{
+		"HEALTH_TIMEOUT":                             "3s",
+		"HEALTH_MAX_CONCURRENT_CHECKS":               "4",
+		"HEALTH_CHECK_ORDER_DB_INTERVAL":             "10s",
+		"HEALTH_CHECK_ORDER_DB_MAX_CONTIGUOUS_FAILS": "3",
+	}
+
+	// Act
+	err := cfg.applyEnv("HEALTH_", func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ConfigDuration(3*time.Second), cfg.Timeout)
+	assert.Equal(t, uint(4), cfg.MaxConcurrentChecks)
+	assert.Equal(t, ConfigDuration(10*time.Second), cfg.Checks[0].Interval)
+	assert.Equal(t, uint(3), cfg.Checks[0].MaxContiguousFails)
+}
+
+func TestCheckerConfigApplyEnvReportsInvalidValues(t *testing.T) {
+	// Arrange
+	cfg := CheckerConfig{}
+
+	// Act
+	err := cfg.applyEnv("HEALTH_", func(key string) (string, bool) {
+		return "invalid", key == "HEALTH_TIMEOUT" || key == "HEALTH_MAX_CONCURRENT_CHECKS"
+	})
+
+	// Assert
+	assert.ErrorContains(t, err, "HEALTH_TIMEOUT")
+	assert.ErrorContains(t, err, "HEALTH_MAX_CONCURRENT_CHECKS")
+}
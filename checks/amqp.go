@@ -0,0 +1,79 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+)
+
+// amqpProtocolHeader is the protocol header of AMQP 0-9-1.
+var amqpProtocolHeader = []byte{'A', 'M', 'Q', 'P', 0, 0, 9, 1}
+
+// AMQPDial creates a check function that connects to the AMQP 0-9-1 broker (e.g., RabbitMQ) at the
+// provided URL (e.g., "amqp://localhost:5672"). The check sends the AMQP protocol header and fails if
+// the broker does not respond with a Connection.Start method. The connection is closed right after,
+// so no credentials are required. TLS is enabled automatically for "amqps" URLs.
+func AMQPDial(brokerURL string, options ...Option) func(ctx context.Context) error {
+	cfg := newDialConfig(options)
+
+	return func(ctx context.Context) error {
+		u, err := url.Parse(brokerURL)
+		if err != nil {
+			return fmt.Errorf("invalid AMQP URL: %w", err)
+		}
+
+		cfg := cfg
+		addr := u.Host
+		switch u.Scheme {
+		case "amqp":
+			if u.Port() == "" {
+				addr = net.JoinHostPort(u.Hostname(), "5672")
+			}
+		case "amqps":
+			if u.Port() == "" {
+				addr = net.JoinHostPort(u.Hostname(), "5671")
+			}
+			if cfg.tlsConfig == nil {
+				cfg.tlsConfig = &tls.Config{}
+			}
+		default:
+			return fmt.Errorf("invalid AMQP URL scheme %q", u.Scheme)
+		}
+
+		conn, err := dial(ctx, addr, cfg)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		if cfg.skipValidation {
+			return nil
+		}
+
+		if _, err := conn.Write(amqpProtocolHeader); err != nil {
+			return fmt.Errorf("cannot send protocol header to %s: %w", addr, err)
+		}
+
+		// A method frame starts with the frame type (1), the channel (2 bytes) and the payload size
+		// (4 bytes), followed by the class ID (2 bytes) and the method ID (2 bytes).
+		frame := make([]byte, 11)
+		if _, err := io.ReadFull(conn, frame); err != nil {
+			return fmt.Errorf("cannot read response from %s: %w", addr, err)
+		}
+
+		if bytes.HasPrefix(frame, []byte("AMQP")) {
+			return fmt.Errorf("broker at %s does not support AMQP 0-9-1", addr)
+		}
+
+		if frame[0] != 1 || binary.BigEndian.Uint16(frame[7:9]) != 10 || binary.BigEndian.Uint16(frame[9:11]) != 10 {
+			return fmt.Errorf("unexpected response from %s: expected Connection.Start method", addr)
+		}
+
+		return nil
+	}
+}
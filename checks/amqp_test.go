@@ -0,0 +1,91 @@
+package checks
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// connectionStartFrame is the beginning of a Connection.Start method frame (class 10, method 10).
+var connectionStartFrame = []byte{1, 0, 0, 0, 0, 0, 4, 0, 10, 0, 10, 0xCE}
+
+// fakeAMQPBroker reads the protocol header and answers it with the provided response. It returns the URL
+// of the broker and a function that waits for the broker to complete and returns the protocol header.
+func fakeAMQPBroker(t *testing.T, response []byte) (string, func() []byte) {
+	header := make([]byte, len(amqpProtocolHeader))
+
+	addr, done := startFakeServer(t, func(conn net.Conn) {
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		conn.Write(response) //nolint:errcheck
+	})
+
+	return "amqp://guest:guest@" + addr + "/vhost", func() []byte {
+		<-done
+		return header
+	}
+}
+
+func TestAMQPDial(t *testing.T) {
+	// Arrange
+	url, header := fakeAMQPBroker(t, connectionStartFrame)
+
+	// Act
+	err := AMQPDial(url)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, amqpProtocolHeader, header())
+}
+
+func TestAMQPDialFailsOnUnsupportedProtocolVersion(t *testing.T) {
+	// Arrange
+	url, _ := fakeAMQPBroker(t, []byte{'A', 'M', 'Q', 'P', 0, 1, 0, 0, 0, 0, 0})
+
+	// Act
+	err := AMQPDial(url)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "does not support AMQP 0-9-1")
+}
+
+func TestAMQPDialFailsOnMalformedResponse(t *testing.T) {
+	tests := map[string][]byte{
+		"unexpected method": {1, 0, 0, 0, 0, 0, 4, 0, 10, 0, 11, 0xCE},
+		"unexpected frame":  {8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xCE},
+		"truncated frame":   {1, 0, 0},
+	}
+
+	for name, response := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			url, _ := fakeAMQPBroker(t, response)
+
+			// Act
+			err := AMQPDial(url)(context.Background())
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAMQPDialFailsOnInvalidURL(t *testing.T) {
+	// Act
+	err := AMQPDial("http://localhost:5672")(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, `invalid AMQP URL scheme "http"`)
+}
+
+func TestAMQPDialFailsIfBrokerIsUnreachable(t *testing.T) {
+	// Act
+	err := AMQPDial("amqp://" + closedAddr(t))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot connect")
+}
@@ -0,0 +1,87 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// CertificateExpiry creates a check function that connects to the TLS server at the provided address
+// (e.g., "example.com:443") and inspects the expiry date of the leaf certificate. The check reports the
+// component as degraded (see health.NewDegradedError) if the certificate expires within warnWithin and fails
+// if it expires within failWithin. The certificate chain is verified by default. Use WithTLS to provide a custom
+// TLS configuration (e.g., custom root CAs). The measured values are reported as check data, so the function
+// must be used as health.Check.CheckWithData.
+func CertificateExpiry(addr string, warnWithin, failWithin time.Duration, options ...Option) func(ctx context.Context) (map[string]interface{}, error) {
+	cfg := newDialConfig(options)
+	if cfg.tlsConfig == nil {
+		cfg.tlsConfig = &tls.Config{}
+	}
+
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		conn, err := dial(ctx, addr, cfg)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+
+		certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+		if len(certs) == 0 {
+			return nil, fmt.Errorf("server %s did not present a certificate", addr)
+		}
+
+		return checkCertificateExpiry(certs[0], warnWithin, failWithin)
+	}
+}
+
+// CertificateFileExpiry works like CertificateExpiry, but inspects the first certificate
+// of the PEM encoded certificate file at the provided path.
+func CertificateFileExpiry(path string, warnWithin, failWithin time.Duration) func(ctx context.Context) (map[string]interface{}, error) {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read certificate file: %w", err)
+		}
+
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse certificate in %s: %w", path, err)
+			}
+
+			return checkCertificateExpiry(cert, warnWithin, failWithin)
+		}
+
+		return nil, errors.New("no certificate found in " + path)
+	}
+}
+
+func checkCertificateExpiry(cert *x509.Certificate, warnWithin, failWithin time.Duration) (map[string]interface{}, error) {
+	remaining := time.Until(cert.NotAfter)
+	data := map[string]interface{}{
+		"subject":         cert.Subject.String(),
+		"notAfter":        cert.NotAfter.UTC(),
+		"daysUntilExpiry": int(math.Floor(remaining.Hours() / 24)),
+	}
+
+	if remaining <= failWithin {
+		return data, fmt.Errorf("certificate %q expires at %s", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+	} else if remaining <= warnWithin {
+		return data, health.NewDegradedError(
+			fmt.Errorf("certificate %q expires at %s", cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339)))
+	}
+
+	return data, nil
+}
@@ -0,0 +1,152 @@
+package checks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificate creates a self-signed certificate for 127.0.0.1 that expires after the provided duration.
+func newTestCertificate(t *testing.T, validFor time.Duration) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "health.test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validFor),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// writeCertificateFile writes the certificate PEM encoded into a temporary file, preceded by a private key block.
+func writeCertificateFile(t *testing.T, cert tls.Certificate) string {
+	t.Helper()
+
+	key, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})...)
+
+	path := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func startTLSServer(t *testing.T, cert tls.Certificate) string {
+	addr, _ := startFakeServer(t, func(conn net.Conn) {
+		tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{cert}}).Handshake() //nolint:errcheck
+	})
+	return addr
+}
+
+func TestCertificateFileExpiry(t *testing.T) {
+	// Arrange
+	path := writeCertificateFile(t, newTestCertificate(t, 100*24*time.Hour+time.Hour))
+
+	// Act
+	data, err := CertificateFileExpiry(path, 30*24*time.Hour, 7*24*time.Hour)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "CN=health.test", data["subject"])
+	assert.Equal(t, 100, data["daysUntilExpiry"])
+}
+
+func TestCertificateFileExpiryReportsDegraded(t *testing.T) {
+	// Arrange
+	path := writeCertificateFile(t, newTestCertificate(t, 20*24*time.Hour))
+
+	// Act
+	_, err := CertificateFileExpiry(path, 30*24*time.Hour, 7*24*time.Hour)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, `certificate "CN=health.test" expires at`)
+	assert.True(t, isDegradedErr(err))
+}
+
+func TestCertificateFileExpiryFailsIfCertificateExpiresSoon(t *testing.T) {
+	// Arrange
+	path := writeCertificateFile(t, newTestCertificate(t, 24*time.Hour))
+
+	// Act
+	_, err := CertificateFileExpiry(path, 30*24*time.Hour, 7*24*time.Hour)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, `certificate "CN=health.test" expires at`)
+	assert.False(t, isDegradedErr(err))
+}
+
+func TestCertificateFileExpiryFailsWithoutCertificate(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte{1}}), 0o600))
+
+	// Act
+	_, err := CertificateFileExpiry(path, 0, 0)(context.Background())
+	_, missingErr := CertificateFileExpiry(filepath.Join(t.TempDir(), "missing.pem"), 0, 0)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "no certificate found in "+path)
+	assert.ErrorContains(t, missingErr, "cannot read certificate file")
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	// Arrange
+	cert := newTestCertificate(t, 20*24*time.Hour)
+	addr := startTLSServer(t, cert)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert.Leaf)
+
+	// Act
+	data, err := CertificateExpiry(addr, 30*24*time.Hour, 7*24*time.Hour, WithTLS(&tls.Config{RootCAs: roots}))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, `certificate "CN=health.test" expires at`)
+	assert.True(t, isDegradedErr(err))
+	assert.Equal(t, 19, data["daysUntilExpiry"])
+}
+
+func TestCertificateExpiryVerifiesCertificateChain(t *testing.T) {
+	// Arrange
+	addr := startTLSServer(t, newTestCertificate(t, 100*24*time.Hour))
+
+	// Act
+	_, err := CertificateExpiry(addr, 30*24*time.Hour, 7*24*time.Hour)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "TLS handshake with "+addr+" failed")
+}
@@ -0,0 +1,96 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexliesenfeld/health"
+)
+
+type (
+	// DiskUsageOption configures a disk usage check (see DiskUsage).
+	DiskUsageOption func(cfg *diskUsageConfig)
+
+	diskUsageConfig struct {
+		degradedUsedPercent float64
+	}
+
+	diskStats struct {
+		totalBytes, usedBytes, freeBytes uint64
+		totalInodes, freeInodes          uint64
+	}
+)
+
+// WithDegradedUsedPercent configures a usage threshold in percent (e.g., 80) above which the disk usage check
+// reports the checked component as degraded (see health.NewDegradedError). It must be lower than the maximum
+// usage that was passed to DiskUsage. By default, the check never reports a degraded status.
+func WithDegradedUsedPercent(usedPercent float64) DiskUsageOption {
+	return func(cfg *diskUsageConfig) {
+		cfg.degradedUsedPercent = usedPercent
+	}
+}
+
+// DiskUsage creates a check function that verifies that the file system that contains the provided path
+// has enough free space and inodes left. The check fails if more than maxUsedPercent (e.g., 90) of the
+// space or the inodes are used. Like df, the usage is relative to the space that is usable by unprivileged
+// users, so space that is reserved for the root user is not counted as used.
+// The measured values are reported as check data, so the function must be used as health.Check.CheckWithData.
+// It is only supported on Linux, macOS and FreeBSD.
+func DiskUsage(path string, maxUsedPercent float64, options ...DiskUsageOption) func(ctx context.Context) (map[string]interface{}, error) {
+	var cfg diskUsageConfig
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		stats, err := readDiskStats(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read disk usage of %s: %w", path, err)
+		}
+
+		return evaluateDiskUsage(path, stats, maxUsedPercent, cfg)
+	}
+}
+
+// evaluateDiskUsage creates the result of a disk usage check from the file system statistics (see DiskUsage).
+func evaluateDiskUsage(path string, stats diskStats, maxUsedPercent float64, cfg diskUsageConfig) (map[string]interface{}, error) {
+	usedPercent := usagePercent(stats.usedBytes, stats.freeBytes)
+	inodesUsedPercent := usagePercent(stats.totalInodes-stats.freeInodes, stats.freeInodes)
+
+	data := map[string]interface{}{
+		"path":              path,
+		"totalBytes":        stats.totalBytes,
+		"usedBytes":         stats.usedBytes,
+		"freeBytes":         stats.freeBytes,
+		"usedPercent":       usedPercent,
+		"totalInodes":       stats.totalInodes,
+		"freeInodes":        stats.freeInodes,
+		"inodesUsedPercent": inodesUsedPercent,
+	}
+
+	if usedPercent > maxUsedPercent {
+		return data, fmt.Errorf("disk usage of %s is %.1f%% (max. %.1f%%)", path, usedPercent, maxUsedPercent)
+	} else if inodesUsedPercent > maxUsedPercent {
+		return data, fmt.Errorf("inode usage of %s is %.1f%% (max. %.1f%%)", path, inodesUsedPercent, maxUsedPercent)
+	}
+
+	if cfg.degradedUsedPercent > 0 {
+		if usedPercent > cfg.degradedUsedPercent {
+			return data, health.NewDegradedError(fmt.Errorf("disk usage of %s is %.1f%%", path, usedPercent))
+		} else if inodesUsedPercent > cfg.degradedUsedPercent {
+			return data, health.NewDegradedError(fmt.Errorf("inode usage of %s is %.1f%%", path, inodesUsedPercent))
+		}
+	}
+
+	return data, nil
+}
+
+// usagePercent returns the used share of the space that is usable by unprivileged users in percent (like df).
+// Space that is reserved for the root user is neither counted as used nor as free. File systems that do not
+// report any usable space (e.g., inodes on some file systems) are reported as unused.
+func usagePercent(used, free uint64) float64 {
+	if used+free == 0 {
+		return 0
+	}
+	return float64(used) / float64(used+free) * 100
+}
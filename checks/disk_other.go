@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !freebsd
+
+package checks
+
+import "errors"
+
+func readDiskStats(path string) (diskStats, error) {
+	return diskStats{}, errors.New("disk usage check is not supported on this platform")
+}
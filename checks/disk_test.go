@@ -0,0 +1,65 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDiskStats describes a file system with 1000 bytes, 100 of which are reserved for the root user.
+func testDiskStats(usedBytes uint64) diskStats {
+	return diskStats{
+		totalBytes:  1000,
+		usedBytes:   usedBytes,
+		freeBytes:   900 - usedBytes,
+		totalInodes: 100,
+		freeInodes:  90,
+	}
+}
+
+func TestEvaluateDiskUsageDoesNotCountReservedSpaceAsUsed(t *testing.T) {
+	// Act
+	data, err := evaluateDiskUsage("/data", testDiskStats(450), 90, diskUsageConfig{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 50.0, data["usedPercent"])
+	assert.Equal(t, 10.0, data["inodesUsedPercent"])
+	assert.Equal(t, uint64(1000), data["totalBytes"])
+	assert.Equal(t, uint64(450), data["usedBytes"])
+	assert.Equal(t, uint64(450), data["freeBytes"])
+}
+
+func TestEvaluateDiskUsageFailsAboveMaximum(t *testing.T) {
+	// Act
+	_, err := evaluateDiskUsage("/data", testDiskStats(855), 90, diskUsageConfig{degradedUsedPercent: 80})
+
+	// Assert
+	assert.EqualError(t, err, "disk usage of /data is 95.0% (max. 90.0%)")
+	assert.False(t, isDegradedErr(err))
+}
+
+func TestEvaluateDiskUsageFailsIfInodesAreExhausted(t *testing.T) {
+	// Arrange
+	stats := testDiskStats(0)
+	stats.freeInodes = 5
+
+	// Act
+	_, err := evaluateDiskUsage("/data", stats, 90, diskUsageConfig{})
+
+	// Assert
+	assert.EqualError(t, err, "inode usage of /data is 95.0% (max. 90.0%)")
+}
+
+func TestEvaluateDiskUsageReportsDegraded(t *testing.T) {
+	// Act
+	_, err := evaluateDiskUsage("/data", testDiskStats(765), 90, diskUsageConfig{degradedUsedPercent: 80})
+
+	// Assert
+	assert.EqualError(t, err, "disk usage of /data is 85.0%")
+	assert.True(t, isDegradedErr(err))
+}
+
+func TestUsagePercentOfEmptyFileSystem(t *testing.T) {
+	assert.Equal(t, 0.0, usagePercent(0, 0))
+}
@@ -0,0 +1,20 @@
+//go:build linux || darwin || freebsd
+
+package checks
+
+import "syscall"
+
+func readDiskStats(path string) (diskStats, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return diskStats{}, err
+	}
+
+	return diskStats{
+		totalBytes:  uint64(stat.Blocks) * uint64(stat.Bsize),
+		usedBytes:   (uint64(stat.Blocks) - uint64(stat.Bfree)) * uint64(stat.Bsize),
+		freeBytes:   uint64(stat.Bavail) * uint64(stat.Bsize),
+		totalInodes: uint64(stat.Files),
+		freeInodes:  uint64(stat.Ffree),
+	}, nil
+}
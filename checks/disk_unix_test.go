@@ -0,0 +1,36 @@
+//go:build linux || darwin || freebsd
+
+package checks
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskUsage(t *testing.T) {
+	// Arrange
+	path := t.TempDir()
+
+	// Act
+	data, err := DiskUsage(path, 100)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, path, data["path"])
+	assert.Greater(t, data["totalBytes"], uint64(0))
+	assert.LessOrEqual(t, data["usedBytes"], data["totalBytes"])
+}
+
+func TestDiskUsageFailsIfPathDoesNotExist(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "missing")
+
+	// Act
+	_, err := DiskUsage(path, 100)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot read disk usage of "+path)
+}
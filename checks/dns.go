@@ -0,0 +1,28 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// DNSResolve creates a check function that verifies that the provided host name can be resolved
+// to at least one address. It uses net.DefaultResolver. The lookup fails if it does not complete
+// within DefaultTimeout or the deadline of the check context, whichever comes first.
+func DNSResolve(host string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+
+		addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return fmt.Errorf("cannot resolve %s: %w", host, err)
+		}
+
+		if len(addrs) == 0 {
+			return fmt.Errorf("no addresses found for %s", host)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSResolve(t *testing.T) {
+	// Act
+	err := DNSResolve("localhost")(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestDNSResolveFailsIfContextIsDone(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := DNSResolve("example.com")(ctx)
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot resolve example.com")
+}
@@ -0,0 +1,10 @@
+// Package checks provides ready-made check functions for commonly checked dependencies, such as
+// databases, TCP services, HTTP endpoints, DNS names and message brokers. All functions returned by
+// this package can be used as a health.Check check function and adhere to the deadline of the passed context.
+//
+// The Redis, Kafka, AMQP and MongoDB checks speak the respective wire protocol directly, so that
+// this package does not depend on any client libraries. They can be configured using Option.
+//
+// Importing this package also registers check factories for declarative checks, so that the check
+// functions can be used with health.NewCheckerFromConfig (e.g., a check of type "http").
+package checks
@@ -0,0 +1,156 @@
+package checks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// The following check types are registered for declarative checks (see health.NewCheckerFromConfig):
+//   - "http": HTTPGet with parameters "url" (required) and "expectedStatus" (default 200).
+//   - "tcp": TCPDial with parameters "addr" (required) and "timeout" (e.g., "2s", default DefaultTimeout).
+//   - "dns": DNSResolve with parameter "host" (required).
+//   - "sql": SQLPing with parameters "driver" and "dsn" (both required). The database driver must be
+//     imported by the application (see database/sql.Register).
+//   - "redis": RedisPing with parameters "addr" (required), "username" and "password".
+//   - "kafka": KafkaBrokerReachable with parameter "brokers" (required, comma separated).
+//   - "amqp": AMQPDial with parameter "url" (required).
+//   - "mongo": MongoPing with parameter "addr" (required).
+func init() {
+	health.RegisterCheckFactory("http", newHTTPCheck)
+	health.RegisterCheckFactory("tcp", newTCPCheck)
+	health.RegisterCheckFactory("dns", newDNSCheck)
+	health.RegisterDisposableCheckFactory("sql", newSQLCheck)
+	health.RegisterCheckFactory("redis", newRedisCheck)
+	health.RegisterCheckFactory("kafka", newKafkaCheck)
+	health.RegisterCheckFactory("amqp", newAMQPCheck)
+	health.RegisterCheckFactory("mongo", newMongoCheck)
+}
+
+func newHTTPCheck(params map[string]string) (func(ctx context.Context) error, error) {
+	url, err := requiredParam(params, "url")
+	if err != nil {
+		return nil, err
+	}
+
+	expectedStatus := 200
+	if value, ok := params["expectedStatus"]; ok {
+		if expectedStatus, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("invalid parameter \"expectedStatus\": %w", err)
+		}
+	}
+
+	return HTTPGet(url, expectedStatus), nil
+}
+
+func newTCPCheck(params map[string]string) (func(ctx context.Context) error, error) {
+	addr, err := requiredParam(params, "addr")
+	if err != nil {
+		return nil, err
+	}
+
+	var timeout time.Duration
+	if value, ok := params["timeout"]; ok {
+		if timeout, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid parameter \"timeout\": %w", err)
+		}
+	}
+
+	return TCPDial(addr, timeout), nil
+}
+
+func newDNSCheck(params map[string]string) (func(ctx context.Context) error, error) {
+	host, err := requiredParam(params, "host")
+	if err != nil {
+		return nil, err
+	}
+
+	return DNSResolve(host), nil
+}
+
+func newSQLCheck(params map[string]string) (func(ctx context.Context) error, func() error, error) {
+	driver, err := requiredParam(params, "driver")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dsn, err := requiredParam(params, "dsn")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// sql.Open does not connect to the database, so this does not fail if the database is unavailable.
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open database: %w", err)
+	}
+
+	// The connection pool is closed when the check is replaced or removed (see health.Checker.Reload).
+	return SQLPing(db), db.Close, nil
+}
+
+func newRedisCheck(params map[string]string) (func(ctx context.Context) error, error) {
+	addr, err := requiredParam(params, "addr")
+	if err != nil {
+		return nil, err
+	}
+
+	var options []Option
+	if params["password"] != "" {
+		options = append(options, WithCredentials(params["username"], params["password"]))
+	}
+
+	return RedisPing(addr, options...), nil
+}
+
+func newKafkaCheck(params map[string]string) (func(ctx context.Context) error, error) {
+	brokers, err := requiredParam(params, "brokers")
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []string
+	for _, broker := range strings.Split(brokers, ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			addrs = append(addrs, broker)
+		}
+	}
+
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("parameter %q is required", "brokers")
+	}
+
+	return KafkaBrokerReachable(addrs), nil
+}
+
+func newAMQPCheck(params map[string]string) (func(ctx context.Context) error, error) {
+	url, err := requiredParam(params, "url")
+	if err != nil {
+		return nil, err
+	}
+
+	return AMQPDial(url), nil
+}
+
+func newMongoCheck(params map[string]string) (func(ctx context.Context) error, error) {
+	addr, err := requiredParam(params, "addr")
+	if err != nil {
+		return nil, err
+	}
+
+	return MongoPing(addr), nil
+}
+
+func requiredParam(params map[string]string, name string) (string, error) {
+	value := params[name]
+	if value == "" {
+		return "", fmt.Errorf("parameter %q is required", name)
+	}
+
+	return value, nil
+}
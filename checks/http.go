@@ -0,0 +1,46 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// defaultHTTPClient is used by HTTPGet. Unlike http.DefaultClient, it has a timeout.
+var defaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+// HTTPGet creates a check function that sends an HTTP GET request to the provided URL and
+// verifies that the response has the expected HTTP status code. It uses a client with a timeout
+// of DefaultTimeout, but otherwise the same configuration as http.DefaultClient.
+// Use HTTPGetWithClient to provide a custom client (e.g., to configure TLS).
+func HTTPGet(url string, expectedStatus int) func(ctx context.Context) error {
+	return HTTPGetWithClient(defaultHTTPClient, url, expectedStatus)
+}
+
+// HTTPGetWithClient works like HTTPGet, but uses the provided http.Client to send the request.
+// Only the timeout of the client and the deadline of the check context are applied to the request.
+func HTTPGetWithClient(client *http.Client, url string, expectedStatus int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("cannot create request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("request to %s failed: %w", url, err)
+		}
+		defer resp.Body.Close()
+
+		// Drain the body, so that the underlying connection can be reused.
+		//nolint:errcheck
+		io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode != expectedStatus {
+			return fmt.Errorf("unexpected status code from %s: got %d, want %d", url, resp.StatusCode, expectedStatus)
+		}
+
+		return nil
+	}
+}
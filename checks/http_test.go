@@ -0,0 +1,72 @@
+package checks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPGet(t *testing.T) {
+	// Arrange
+	var method string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// Act
+	err := HTTPGet(srv.URL, http.StatusNoContent)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, method)
+}
+
+func TestHTTPGetFailsOnUnexpectedStatusCode(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	// Act
+	err := HTTPGet(srv.URL, http.StatusOK)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "unexpected status code from "+srv.URL+": got 503, want 200")
+}
+
+func TestHTTPGetFailsIfServerIsUnreachable(t *testing.T) {
+	// Act
+	err := HTTPGet("http://"+closedAddr(t), http.StatusOK)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "request to")
+}
+
+func TestHTTPGetUsesDefaultTimeout(t *testing.T) {
+	assert.Equal(t, DefaultTimeout, defaultHTTPClient.Timeout)
+}
+
+func TestHTTPGetWithClientAppliesClientTimeout(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+
+	// Act
+	err := HTTPGetWithClient(client, srv.URL, http.StatusOK)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
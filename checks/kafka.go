@@ -0,0 +1,90 @@
+package checks
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	kafkaAPIVersionsKey     = 18
+	kafkaClientID           = "health"
+	kafkaCorrelationID      = 1
+	kafkaMaxResponseSize    = 1 << 20
+	kafkaUnsupportedVersion = 35
+)
+
+// KafkaBrokerReachable creates a check function that verifies that at least one of the provided Kafka
+// brokers (e.g., "localhost:9092") is reachable. Brokers are tried in order. The check sends an
+// ApiVersions request to each broker and fails if no broker responds with a valid response.
+func KafkaBrokerReachable(brokers []string, options ...Option) func(ctx context.Context) error {
+	cfg := newDialConfig(options)
+
+	return func(ctx context.Context) error {
+		if len(brokers) == 0 {
+			return errors.New("no kafka brokers configured")
+		}
+
+		var errs []string
+		for _, broker := range brokers {
+			err := checkKafkaBroker(ctx, broker, cfg)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err.Error())
+		}
+
+		return fmt.Errorf("no kafka broker reachable: %s", strings.Join(errs, "; "))
+	}
+}
+
+func checkKafkaBroker(ctx context.Context, broker string, cfg dialConfig) error {
+	conn, err := dial(ctx, broker, cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if cfg.skipValidation {
+		return nil
+	}
+
+	// ApiVersions request (version 0): size, API key, API version, correlation ID and client ID.
+	req := make([]byte, 14, 14+len(kafkaClientID))
+	binary.BigEndian.PutUint32(req[0:4], uint32(10+len(kafkaClientID)))
+	binary.BigEndian.PutUint16(req[4:6], kafkaAPIVersionsKey)
+	binary.BigEndian.PutUint16(req[6:8], 0)
+	binary.BigEndian.PutUint32(req[8:12], kafkaCorrelationID)
+	binary.BigEndian.PutUint16(req[12:14], uint16(len(kafkaClientID)))
+	req = append(req, kafkaClientID...)
+
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("cannot send request to %s: %w", broker, err)
+	}
+
+	// The response starts with the size, the correlation ID and the error code.
+	header := make([]byte, 10)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return fmt.Errorf("cannot read response from %s: %w", broker, err)
+	}
+
+	size := binary.BigEndian.Uint32(header[0:4])
+	if size < 6 || size > kafkaMaxResponseSize {
+		return fmt.Errorf("invalid response from %s: unexpected size %d", broker, size)
+	}
+
+	if correlationID := binary.BigEndian.Uint32(header[4:8]); correlationID != kafkaCorrelationID {
+		return fmt.Errorf("invalid response from %s: unexpected correlation ID %d", broker, correlationID)
+	}
+
+	// Brokers that do not support version 0 anymore still respond, but with an error code
+	// that indicates an unsupported version. This still proves that the broker is reachable.
+	if errorCode := int16(binary.BigEndian.Uint16(header[8:10])); errorCode != 0 && errorCode != kafkaUnsupportedVersion {
+		return fmt.Errorf("broker %s responded with error code %d", broker, errorCode)
+	}
+
+	return nil
+}
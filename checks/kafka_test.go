@@ -0,0 +1,121 @@
+package checks
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeKafkaBroker reads a single request and answers it with a response header that holds the provided
+// size, correlation ID and error code. It returns the address of the broker and a function that waits for
+// the broker to complete and returns the API key of the request.
+func fakeKafkaBroker(t *testing.T, size, correlationID uint32, errorCode int16) (string, func() uint16) {
+	var apiKey uint16
+
+	addr, done := startFakeServer(t, func(conn net.Conn) {
+		sizeBuf := make([]byte, 4)
+		if _, err := io.ReadFull(conn, sizeBuf); err != nil {
+			return
+		}
+
+		req := make([]byte, binary.BigEndian.Uint32(sizeBuf))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+		apiKey = binary.BigEndian.Uint16(req[0:2])
+
+		resp := make([]byte, 10)
+		binary.BigEndian.PutUint32(resp[0:4], size)
+		binary.BigEndian.PutUint32(resp[4:8], correlationID)
+		binary.BigEndian.PutUint16(resp[8:10], uint16(errorCode))
+		conn.Write(resp) //nolint:errcheck
+	})
+
+	return addr, func() uint16 {
+		<-done
+		return apiKey
+	}
+}
+
+func TestKafkaBrokerReachable(t *testing.T) {
+	// Arrange
+	addr, apiKey := fakeKafkaBroker(t, 6, kafkaCorrelationID, 0)
+
+	// Act
+	err := KafkaBrokerReachable([]string{addr})(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(kafkaAPIVersionsKey), apiKey())
+}
+
+func TestKafkaBrokerReachableAcceptsUnsupportedVersion(t *testing.T) {
+	// Arrange
+	addr, _ := fakeKafkaBroker(t, 6, kafkaCorrelationID, kafkaUnsupportedVersion)
+
+	// Act
+	err := KafkaBrokerReachable([]string{addr})(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestKafkaBrokerReachableTriesAllBrokers(t *testing.T) {
+	// Arrange
+	addr, _ := fakeKafkaBroker(t, 6, kafkaCorrelationID, 0)
+
+	// Act
+	err := KafkaBrokerReachable([]string{closedAddr(t), addr})(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestKafkaBrokerReachableFailsOnErrorCode(t *testing.T) {
+	// Arrange
+	addr, _ := fakeKafkaBroker(t, 6, kafkaCorrelationID, 31)
+
+	// Act
+	err := KafkaBrokerReachable([]string{addr})(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "responded with error code 31")
+}
+
+func TestKafkaBrokerReachableFailsOnMalformedResponse(t *testing.T) {
+	tests := map[string]struct {
+		size, correlationID uint32
+		expectedErr         string
+	}{
+		"too small":              {size: 2, correlationID: kafkaCorrelationID, expectedErr: "unexpected size 2"},
+		"too large":              {size: kafkaMaxResponseSize + 1, correlationID: kafkaCorrelationID, expectedErr: "unexpected size"},
+		"unknown correlation ID": {size: 6, correlationID: 42, expectedErr: "unexpected correlation ID 42"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			addr, _ := fakeKafkaBroker(t, tt.size, tt.correlationID, 0)
+
+			// Act
+			err := KafkaBrokerReachable([]string{addr})(context.Background())
+
+			// Assert
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestKafkaBrokerReachableFailsIfNoBrokerIsReachable(t *testing.T) {
+	// Act
+	err := KafkaBrokerReachable([]string{closedAddr(t), closedAddr(t)})(context.Background())
+	noBrokersErr := KafkaBrokerReachable(nil)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "no kafka broker reachable")
+	assert.EqualError(t, noBrokersErr, "no kafka brokers configured")
+}
@@ -0,0 +1,164 @@
+package checks
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	mongoOpMsg           = 2013
+	mongoMaxResponseSize = 1 << 20
+)
+
+// MongoPing creates a check function that connects to the MongoDB server at the provided address
+// (e.g., "localhost:27017") and runs the ping command. The check fails if the server does not respond
+// with "ok: 1". The ping command does not require authentication.
+func MongoPing(addr string, options ...Option) func(ctx context.Context) error {
+	cfg := newDialConfig(options)
+
+	return func(ctx context.Context) error {
+		conn, err := dial(ctx, addr, cfg)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		if cfg.skipValidation {
+			return nil
+		}
+
+		if _, err := conn.Write(encodeMongoPing()); err != nil {
+			return fmt.Errorf("cannot send ping command to %s: %w", addr, err)
+		}
+
+		header := make([]byte, 16)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return fmt.Errorf("cannot read response from %s: %w", addr, err)
+		}
+
+		size := binary.LittleEndian.Uint32(header[0:4])
+		if size < 21 || size > mongoMaxResponseSize {
+			return fmt.Errorf("invalid response from %s: unexpected size %d", addr, size)
+		}
+
+		if opCode := binary.LittleEndian.Uint32(header[12:16]); opCode != mongoOpMsg {
+			return fmt.Errorf("invalid response from %s: unexpected op code %d", addr, opCode)
+		}
+
+		body := make([]byte, size-16)
+		if _, err := io.ReadFull(conn, body); err != nil {
+			return fmt.Errorf("cannot read response from %s: %w", addr, err)
+		}
+
+		// The body consists of the flag bits (4 bytes) and a section of kind 0 (1 byte) with a single document.
+		ok, err := mongoOK(body[5:])
+		if err != nil {
+			return fmt.Errorf("invalid response from %s: %w", addr, err)
+		}
+
+		if !ok {
+			return fmt.Errorf("mongodb ping to %s failed", addr)
+		}
+
+		return nil
+	}
+}
+
+// encodeMongoPing creates an OP_MSG message that contains the document {ping: 1, $db: "admin"}.
+func encodeMongoPing() []byte {
+	doc := []byte("\x00\x00\x00\x00\x10ping\x00\x01\x00\x00\x00\x02$db\x00\x06\x00\x00\x00admin\x00\x00")
+	binary.LittleEndian.PutUint32(doc[0:4], uint32(len(doc)))
+
+	msg := make([]byte, 21, 21+len(doc))
+	binary.LittleEndian.PutUint32(msg[0:4], uint32(21+len(doc)))
+	binary.LittleEndian.PutUint32(msg[4:8], 1)
+	binary.LittleEndian.PutUint32(msg[12:16], mongoOpMsg)
+
+	return append(msg, doc...)
+}
+
+// mongoOK reads the top-level "ok" field from a BSON document.
+func mongoOK(doc []byte) (bool, error) {
+	if len(doc) < 5 || int(binary.LittleEndian.Uint32(doc[0:4])) > len(doc) {
+		return false, errors.New("truncated document")
+	}
+
+	for pos := 4; pos < len(doc) && doc[pos] != 0; {
+		elemType := doc[pos]
+		pos++
+
+		nameEnd := pos
+		for nameEnd < len(doc) && doc[nameEnd] != 0 {
+			nameEnd++
+		}
+		if nameEnd >= len(doc) {
+			return false, errors.New("truncated document")
+		}
+		name := string(doc[pos:nameEnd])
+		pos = nameEnd + 1
+
+		size, err := bsonValueSize(elemType, doc[pos:])
+		if err != nil {
+			return false, err
+		}
+		if pos+size > len(doc) {
+			return false, errors.New("truncated document")
+		}
+
+		if name == "ok" {
+			value := doc[pos : pos+size]
+			switch elemType {
+			case 0x01:
+				return math.Float64frombits(binary.LittleEndian.Uint64(value)) == 1, nil
+			case 0x10:
+				return binary.LittleEndian.Uint32(value) == 1, nil
+			case 0x12:
+				return binary.LittleEndian.Uint64(value) == 1, nil
+			default:
+				return false, fmt.Errorf("unexpected type %#x of field ok", elemType)
+			}
+		}
+
+		pos += size
+	}
+
+	return false, errors.New("field ok not found")
+}
+
+// bsonValueSize returns the size of a BSON value of the provided type.
+func bsonValueSize(elemType byte, value []byte) (int, error) {
+	switch elemType {
+	case 0x0A: // null
+		return 0, nil
+	case 0x08: // boolean
+		return 1, nil
+	case 0x10: // int32
+		return 4, nil
+	case 0x01, 0x09, 0x11, 0x12: // double, datetime, timestamp, int64
+		return 8, nil
+	case 0x07: // object ID
+		return 12, nil
+	case 0x13: // decimal128
+		return 16, nil
+	}
+
+	if len(value) < 4 {
+		return 0, errors.New("truncated document")
+	}
+	size := int(binary.LittleEndian.Uint32(value[0:4]))
+
+	switch elemType {
+	case 0x02: // string
+		return 4 + size, nil
+	case 0x03, 0x04: // document, array
+		return size, nil
+	case 0x05: // binary
+		return 5 + size, nil
+	default:
+		return 0, fmt.Errorf("unsupported type %#x", elemType)
+	}
+}
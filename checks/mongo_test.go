@@ -0,0 +1,127 @@
+package checks
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeMongoServer reads a single message and answers it with the provided response. It returns the address
+// of the server and a function that waits for the server to complete and returns the op code of the message.
+func fakeMongoServer(t *testing.T, response []byte) (string, func() uint32) {
+	var opCode uint32
+
+	addr, done := startFakeServer(t, func(conn net.Conn) {
+		header := make([]byte, 16)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		opCode = binary.LittleEndian.Uint32(header[12:16])
+
+		body := make([]byte, binary.LittleEndian.Uint32(header[0:4])-16)
+		if _, err := io.ReadFull(conn, body); err != nil {
+			return
+		}
+		conn.Write(response) //nolint:errcheck
+	})
+
+	return addr, func() uint32 {
+		<-done
+		return opCode
+	}
+}
+
+// mongoReply creates an OP_MSG message with the op code that holds the provided BSON elements.
+func mongoReply(opCode uint32, elements ...[]byte) []byte {
+	doc := make([]byte, 4)
+	for _, element := range elements {
+		doc = append(doc, element...)
+	}
+	doc = append(doc, 0)
+	binary.LittleEndian.PutUint32(doc[0:4], uint32(len(doc)))
+
+	msg := make([]byte, 21, 21+len(doc))
+	binary.LittleEndian.PutUint32(msg[0:4], uint32(21+len(doc)))
+	binary.LittleEndian.PutUint32(msg[12:16], opCode)
+
+	return append(msg, doc...)
+}
+
+func bsonDouble(name string, value float64) []byte {
+	element := append([]byte{0x01}, name+"\x00"...)
+	element = append(element, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(element[len(element)-8:], math.Float64bits(value))
+	return element
+}
+
+func bsonString(name, value string) []byte {
+	element := append([]byte{0x02}, name+"\x00"...)
+	element = append(element, make([]byte, 4)...)
+	binary.LittleEndian.PutUint32(element[len(element)-4:], uint32(len(value)+1))
+	return append(element, value+"\x00"...)
+}
+
+func TestMongoPing(t *testing.T) {
+	// Arrange
+	addr, opCode := fakeMongoServer(t, mongoReply(mongoOpMsg, bsonString("version", "7.0.2"), bsonDouble("ok", 1)))
+
+	// Act
+	err := MongoPing(addr)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(mongoOpMsg), opCode())
+}
+
+func TestMongoPingFailsIfServerIsNotOK(t *testing.T) {
+	// Arrange
+	addr, _ := fakeMongoServer(t, mongoReply(mongoOpMsg, bsonDouble("ok", 0), bsonString("errmsg", "not primary")))
+
+	// Act
+	err := MongoPing(addr)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "mongodb ping to "+addr+" failed")
+}
+
+func TestMongoPingFailsOnMalformedResponse(t *testing.T) {
+	truncated := mongoReply(mongoOpMsg, bsonDouble("ok", 1))
+	binary.LittleEndian.PutUint32(truncated[21:25], 100)
+
+	tests := map[string]struct {
+		response    []byte
+		expectedErr string
+	}{
+		"unexpected op code": {mongoReply(1, bsonDouble("ok", 1)), "unexpected op code 1"},
+		"missing field ok":   {mongoReply(mongoOpMsg, bsonString("version", "7.0.2")), "field ok not found"},
+		"unexpected type":    {mongoReply(mongoOpMsg, bsonString("ok", "1")), "unexpected type 0x2 of field ok"},
+		"truncated document": {truncated, "truncated document"},
+		"truncated message":  {mongoReply(mongoOpMsg, bsonDouble("ok", 1))[:30], "cannot read response"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			addr, _ := fakeMongoServer(t, tt.response)
+
+			// Act
+			err := MongoPing(addr)(context.Background())
+
+			// Assert
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestMongoPingFailsIfServerIsUnreachable(t *testing.T) {
+	// Act
+	err := MongoPing(closedAddr(t))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot connect")
+}
@@ -0,0 +1,110 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultTimeout is the timeout of the checks of this package that is used if no timeout was configured
+// (see WithTimeout). It is applied in addition to the deadline of the check context, so that a check does
+// not hang forever if the check context has no deadline.
+const DefaultTimeout = 10 * time.Second
+
+type (
+	// Option configures the connectivity checks of this package
+	// (see RedisPing, KafkaBrokerReachable, AMQPDial and MongoPing).
+	Option func(cfg *dialConfig)
+
+	dialConfig struct {
+		timeout            time.Duration
+		tlsConfig          *tls.Config
+		skipValidation     bool
+		username, password string
+	}
+)
+
+// WithTimeout sets a timeout for the whole check, including connecting, sending the request and
+// reading the response. The timeout is applied in addition to the deadline of the check context.
+// Default is DefaultTimeout. A timeout of 0 means that only the deadline of the check context is used.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *dialConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithTLS enables TLS using the provided configuration.
+func WithTLS(config *tls.Config) Option {
+	return func(cfg *dialConfig) {
+		cfg.tlsConfig = config
+	}
+}
+
+// WithoutResponseValidation disables the validation of the response. By default, a check sends a
+// protocol-level request (e.g., a Redis PING) and fails if the response is not the expected one.
+// With this option, a check only verifies that a connection can be established.
+func WithoutResponseValidation() Option {
+	return func(cfg *dialConfig) {
+		cfg.skipValidation = true
+	}
+}
+
+// WithCredentials sets the credentials that are used to authenticate before the response is validated
+// (currently only used by RedisPing). Leave the username empty to use the legacy Redis AUTH command.
+func WithCredentials(username, password string) Option {
+	return func(cfg *dialConfig) {
+		cfg.username = username
+		cfg.password = password
+	}
+}
+
+func newDialConfig(options []Option) dialConfig {
+	cfg := dialConfig{timeout: DefaultTimeout}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// dial establishes a connection to the provided address. The deadline of the connection is set to
+// the deadline of the context or the configured timeout, whichever comes first.
+func dial(ctx context.Context, addr string, cfg dialConfig) (net.Conn, error) {
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	dialer := net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s: %w", addr, err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("cannot set deadline: %w", err)
+		}
+	}
+
+	if cfg.tlsConfig != nil {
+		tlsConfig := cfg.tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(addr)
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("TLS handshake with %s failed: %w", addr, err)
+		}
+
+		return tlsConn, nil
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RedisPing creates a check function that connects to the Redis server at the provided address
+// (e.g., "localhost:6379") and sends a PING command. The check fails if the server does not respond
+// with PONG. Use WithCredentials if the server requires authentication.
+func RedisPing(addr string, options ...Option) func(ctx context.Context) error {
+	cfg := newDialConfig(options)
+
+	return func(ctx context.Context) error {
+		conn, err := dial(ctx, addr, cfg)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		if cfg.skipValidation {
+			return nil
+		}
+
+		reader := bufio.NewReader(conn)
+
+		if cfg.password != "" {
+			args := []string{"AUTH", cfg.password}
+			if cfg.username != "" {
+				args = []string{"AUTH", cfg.username, cfg.password}
+			}
+
+			if _, err := conn.Write(encodeRedisCommand(args...)); err != nil {
+				return fmt.Errorf("cannot send AUTH command to %s: %w", addr, err)
+			}
+
+			if reply, err := readRedisReply(reader); err != nil {
+				return fmt.Errorf("cannot authenticate at %s: %w", addr, err)
+			} else if reply != "OK" {
+				return fmt.Errorf("cannot authenticate at %s: unexpected reply %q", addr, reply)
+			}
+		}
+
+		if _, err := conn.Write(encodeRedisCommand("PING")); err != nil {
+			return fmt.Errorf("cannot send PING command to %s: %w", addr, err)
+		}
+
+		reply, err := readRedisReply(reader)
+		if err != nil {
+			return fmt.Errorf("redis ping to %s failed: %w", addr, err)
+		}
+
+		if reply != "PONG" {
+			return fmt.Errorf("redis ping to %s failed: unexpected reply %q", addr, reply)
+		}
+
+		return nil
+	}
+}
+
+func encodeRedisCommand(args ...string) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return []byte(sb.String())
+}
+
+// readRedisReply reads a simple string reply. Error replies are returned as errors.
+func readRedisReply(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("cannot read reply: %w", err)
+	}
+
+	line = strings.TrimSuffix(line, "\r\n")
+	if strings.HasPrefix(line, "-") {
+		return "", fmt.Errorf("server responded with error: %s", strings.TrimPrefix(line, "-"))
+	}
+
+	return strings.TrimPrefix(line, "+"), nil
+}
@@ -0,0 +1,147 @@
+package checks
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedisServer reads commands and answers each of them with the next reply. It returns the address of
+// the server and a function that waits for the server to complete and returns the commands it received.
+func fakeRedisServer(t *testing.T, replies ...string) (string, func() [][]string) {
+	var commands [][]string
+
+	addr, done := startFakeServer(t, func(conn net.Conn) {
+		reader := bufio.NewReader(conn)
+		for _, reply := range replies {
+			command, err := readRedisCommand(reader)
+			if err != nil {
+				return
+			}
+			commands = append(commands, command)
+			conn.Write([]byte(reply)) //nolint:errcheck
+		}
+	})
+
+	return addr, func() [][]string {
+		<-done
+		return commands
+	}
+}
+
+func readRedisCommand(reader *bufio.Reader) ([]string, error) {
+	var count int
+	if _, err := fmt.Fscanf(reader, "*%d\r\n", &count); err != nil {
+		return nil, err
+	}
+
+	command := make([]string, count)
+	for i := range command {
+		var length int
+		if _, err := fmt.Fscanf(reader, "$%d\r\n", &length); err != nil {
+			return nil, err
+		}
+
+		arg := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		command[i] = string(arg[:length])
+	}
+
+	return command, nil
+}
+
+func TestRedisPing(t *testing.T) {
+	// Arrange
+	addr, commands := fakeRedisServer(t, "+PONG\r\n")
+
+	// Act
+	err := RedisPing(addr)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"PING"}}, commands())
+}
+
+func TestRedisPingAuthenticates(t *testing.T) {
+	// Arrange
+	addr, commands := fakeRedisServer(t, "+OK\r\n", "+PONG\r\n")
+
+	// Act
+	err := RedisPing(addr, WithCredentials("app", "secret"))(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"AUTH", "app", "secret"}, {"PING"}}, commands())
+}
+
+func TestRedisPingFailsOnErrorReply(t *testing.T) {
+	// Arrange
+	addr, _ := fakeRedisServer(t, "-NOAUTH Authentication required.\r\n")
+
+	// Act
+	err := RedisPing(addr)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "server responded with error: NOAUTH Authentication required.")
+}
+
+func TestRedisPingFailsOnFailedAuthentication(t *testing.T) {
+	// Arrange
+	addr, commands := fakeRedisServer(t, "-WRONGPASS invalid username-password pair\r\n")
+
+	// Act
+	err := RedisPing(addr, WithCredentials("", "secret"))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot authenticate")
+	assert.Equal(t, [][]string{{"AUTH", "secret"}}, commands())
+}
+
+func TestRedisPingFailsOnUnexpectedReply(t *testing.T) {
+	// Arrange
+	addr, _ := fakeRedisServer(t, "+HELLO\r\n")
+
+	// Act
+	err := RedisPing(addr)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, `unexpected reply "HELLO"`)
+}
+
+func TestRedisPingFailsOnIncompleteReply(t *testing.T) {
+	// Arrange
+	addr, _ := fakeRedisServer(t, "+PO")
+
+	// Act
+	err := RedisPing(addr)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot read reply")
+}
+
+func TestRedisPingFailsIfServerIsUnreachable(t *testing.T) {
+	// Act
+	err := RedisPing(closedAddr(t))(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot connect")
+}
+
+func TestRedisPingWithoutResponseValidation(t *testing.T) {
+	// Arrange
+	addr, commands := fakeRedisServer(t)
+
+	// Act
+	err := RedisPing(addr, WithoutResponseValidation())(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, commands())
+}
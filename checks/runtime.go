@@ -0,0 +1,137 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+const (
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+	gcPausesMetric    = "/gc/pauses:seconds"
+)
+
+// GoroutineCount creates a check function that fails if the number of goroutines exceeds the provided maximum.
+// If the number of goroutines only exceeds degraded, the component is reported as degraded instead
+// (see health.NewDegradedError). A value of 0 disables the degraded threshold. A steadily growing number
+// of goroutines usually indicates a goroutine leak. The measured value is reported as check data, so the
+// function must be used as health.Check.CheckWithData.
+func GoroutineCount(degraded, max int) func(ctx context.Context) (map[string]interface{}, error) {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		count := runtime.NumGoroutine()
+		data := map[string]interface{}{"goroutines": count}
+
+		if count > max {
+			return data, fmt.Errorf("number of goroutines is %d (max. %d)", count, max)
+		}
+
+		if degraded > 0 && count > degraded {
+			return data, health.NewDegradedError(
+				fmt.Errorf("number of goroutines is %d (degraded above %d)", count, degraded),
+			)
+		}
+
+		return data, nil
+	}
+}
+
+// HeapAlloc creates a check function that fails if the memory occupied by live and not yet swept heap objects
+// exceeds the provided maximum in bytes. If it only exceeds degradedBytes, the component is reported as
+// degraded instead (see health.NewDegradedError). A value of 0 disables the degraded threshold. This allows
+// a service to report memory exhaustion before it is killed by the operating system. The measured value is
+// reported as check data, so the function must be used as health.Check.CheckWithData.
+func HeapAlloc(degradedBytes, maxBytes uint64) func(ctx context.Context) (map[string]interface{}, error) {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		samples := []metrics.Sample{{Name: heapObjectsMetric}}
+		metrics.Read(samples)
+
+		if samples[0].Value.Kind() != metrics.KindUint64 {
+			return nil, fmt.Errorf("runtime metric %s is not supported", heapObjectsMetric)
+		}
+
+		heapAlloc := samples[0].Value.Uint64()
+		data := map[string]interface{}{"heapAllocBytes": heapAlloc}
+
+		if heapAlloc > maxBytes {
+			return data, fmt.Errorf("heap allocation is %d bytes (max. %d bytes)", heapAlloc, maxBytes)
+		}
+
+		if degradedBytes > 0 && heapAlloc > degradedBytes {
+			return data, health.NewDegradedError(
+				fmt.Errorf("heap allocation is %d bytes (degraded above %d bytes)", heapAlloc, degradedBytes),
+			)
+		}
+
+		return data, nil
+	}
+}
+
+// GCPause creates a check function that fails if a garbage collection pause that occurred since the previous
+// execution of the check took longer than the provided maximum. If the pause only took longer than
+// degradedPause, the component is reported as degraded instead (see health.NewDegradedError). A value of 0
+// disables the degraded threshold. Pause durations are read from a runtime histogram, so the reported value
+// is the upper bound of the histogram bucket of the longest pause. The measured value is reported as check
+// data, so the function must be used as health.Check.CheckWithData.
+func GCPause(degradedPause, maxPause time.Duration) func(ctx context.Context) (map[string]interface{}, error) {
+	var (
+		mtx        sync.Mutex
+		prevCounts []uint64
+	)
+
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		samples := []metrics.Sample{{Name: gcPausesMetric}}
+		metrics.Read(samples)
+
+		if samples[0].Value.Kind() != metrics.KindFloat64Histogram {
+			return nil, fmt.Errorf("runtime metric %s is not supported", gcPausesMetric)
+		}
+
+		histogram := samples[0].Value.Float64Histogram()
+
+		mtx.Lock()
+		longestPause := longestNewSample(histogram, prevCounts)
+		prevCounts = append(prevCounts[:0], histogram.Counts...)
+		mtx.Unlock()
+
+		data := map[string]interface{}{"longestGCPause": longestPause.String()}
+
+		if longestPause > maxPause {
+			return data, fmt.Errorf("garbage collection pause took up to %s (max. %s)", longestPause, maxPause)
+		}
+
+		if degradedPause > 0 && longestPause > degradedPause {
+			return data, health.NewDegradedError(
+				fmt.Errorf("garbage collection pause took up to %s (degraded above %s)", longestPause, degradedPause),
+			)
+		}
+
+		return data, nil
+	}
+}
+
+// longestNewSample returns the upper bound of the highest histogram bucket that has gained samples
+// compared to the previous bucket counts.
+func longestNewSample(histogram *metrics.Float64Histogram, prevCounts []uint64) time.Duration {
+	for i := len(histogram.Counts) - 1; i >= 0; i-- {
+		var prevCount uint64
+		if i < len(prevCounts) {
+			prevCount = prevCounts[i]
+		}
+
+		if histogram.Counts[i] > prevCount {
+			upperBound := histogram.Buckets[i+1]
+			if math.IsInf(upperBound, 1) {
+				upperBound = histogram.Buckets[i]
+			}
+			return time.Duration(upperBound * float64(time.Second))
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,118 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func isDegradedErr(err error) bool {
+	var degradedErr *health.DegradedError
+	return errors.As(err, &degradedErr)
+}
+
+func TestGoroutineCount(t *testing.T) {
+	// Act
+	data, err := GoroutineCount(0, math.MaxInt32)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Greater(t, data["goroutines"], 0)
+}
+
+func TestGoroutineCountReportsDegraded(t *testing.T) {
+	// Act
+	_, err := GoroutineCount(1, math.MaxInt32)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "degraded above 1")
+	assert.True(t, isDegradedErr(err))
+}
+
+func TestGoroutineCountFailsAboveMaximum(t *testing.T) {
+	// Act
+	_, err := GoroutineCount(1, 1)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "(max. 1)")
+	assert.False(t, isDegradedErr(err))
+}
+
+func TestHeapAlloc(t *testing.T) {
+	// Act
+	data, err := HeapAlloc(0, math.MaxUint64)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Greater(t, data["heapAllocBytes"], uint64(0))
+}
+
+func TestHeapAllocReportsDegraded(t *testing.T) {
+	// Act
+	_, err := HeapAlloc(1, math.MaxUint64)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "degraded above 1 bytes")
+	assert.True(t, isDegradedErr(err))
+}
+
+func TestHeapAllocFailsAboveMaximum(t *testing.T) {
+	// Act
+	_, err := HeapAlloc(1, 1)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "(max. 1 bytes)")
+	assert.False(t, isDegradedErr(err))
+}
+
+func TestGCPauseReportsDegraded(t *testing.T) {
+	// Arrange
+	check := GCPause(time.Nanosecond, time.Hour)
+	runtime.GC()
+
+	// Act
+	data, err := check(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "degraded above 1ns")
+	assert.True(t, isDegradedErr(err))
+	assert.NotEqual(t, "0s", data["longestGCPause"])
+}
+
+func TestGCPauseFailsAboveMaximum(t *testing.T) {
+	// Arrange
+	check := GCPause(0, time.Nanosecond)
+	runtime.GC()
+
+	// Act
+	_, err := check(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "(max. 1ns)")
+	assert.False(t, isDegradedErr(err))
+}
+
+func TestLongestNewSampleOnlyConsidersNewSamples(t *testing.T) {
+	// Arrange
+	histogram := &metrics.Float64Histogram{
+		Counts:  []uint64{5, 2, 1},
+		Buckets: []float64{0, 0.001, 0.01, math.Inf(1)},
+	}
+
+	// Act
+	initial := longestNewSample(histogram, nil)
+	unchanged := longestNewSample(histogram, []uint64{5, 2, 1})
+	newSample := longestNewSample(histogram, []uint64{5, 1, 1})
+
+	// Assert
+	assert.Equal(t, 10*time.Millisecond, initial)
+	assert.Equal(t, time.Duration(0), unchanged)
+	assert.Equal(t, 10*time.Millisecond, newSample)
+}
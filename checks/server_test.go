@@ -0,0 +1,50 @@
+package checks
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeServer starts a TCP server that accepts a single connection and passes it to handle.
+// It returns the address of the server and a channel that is closed once handle has returned.
+// The server is stopped when the test completes.
+func startFakeServer(t *testing.T, handle func(conn net.Conn)) (string, <-chan struct{}) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		listener.Close()
+		<-done
+	})
+
+	go func() {
+		defer close(done)
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		handle(conn)
+	}()
+
+	return listener.Addr().String(), done
+}
+
+// closedAddr returns the address of a TCP port that does not accept connections.
+func closedAddr(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	return addr
+}
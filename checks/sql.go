@@ -0,0 +1,23 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+)
+
+// Pinger is implemented by types that can verify a connection to a database,
+// such as *sql.DB or *sql.Conn.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// SQLPing creates a check function that verifies the connection to a database by pinging it
+// (e.g., using *sql.DB).
+func SQLPing(db Pinger) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("database ping failed: %w", err)
+		}
+		return nil
+	}
+}
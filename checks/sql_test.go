@@ -0,0 +1,44 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pingerMock struct {
+	err   error
+	calls int
+}
+
+func (m *pingerMock) PingContext(ctx context.Context) error {
+	m.calls++
+	return m.err
+}
+
+func TestSQLPing(t *testing.T) {
+	// Arrange
+	db := &pingerMock{}
+
+	// Act
+	err := SQLPing(db)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, db.calls)
+}
+
+func TestSQLPingFailsIfPingFails(t *testing.T) {
+	// Arrange
+	pingErr := errors.New("connection refused")
+	db := &pingerMock{err: pingErr}
+
+	// Act
+	err := SQLPing(db)(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "database ping failed: connection refused")
+	assert.True(t, errors.Is(err, pingErr))
+}
@@ -0,0 +1,29 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// TCPDial creates a check function that verifies that a TCP connection can be established
+// to the provided address (e.g., "localhost:5432"). The connection is closed immediately after
+// it was established. The timeout is applied in addition to the deadline of the check context.
+// If the timeout is not positive, DefaultTimeout is used.
+func TCPDial(addr string, timeout time.Duration) func(ctx context.Context) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return func(ctx context.Context) error {
+		dialer := net.Dialer{Timeout: timeout}
+
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return fmt.Errorf("cannot connect to %s: %w", addr, err)
+		}
+
+		return conn.Close()
+	}
+}
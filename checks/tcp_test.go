@@ -0,0 +1,46 @@
+package checks
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTCPDial(t *testing.T) {
+	// Arrange
+	addr, done := startFakeServer(t, func(conn net.Conn) {})
+
+	// Act
+	err := TCPDial(addr, 0)(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	<-done
+}
+
+func TestTCPDialFailsIfServerIsUnreachable(t *testing.T) {
+	// Arrange
+	addr := closedAddr(t)
+
+	// Act
+	err := TCPDial(addr, time.Second)(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot connect to "+addr)
+}
+
+func TestTCPDialFailsIfContextIsDone(t *testing.T) {
+	// Arrange
+	addr, _ := startFakeServer(t, func(conn net.Conn) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := TCPDial(addr, 0)(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,49 @@
+package health
+
+import "time"
+
+// CircuitOpenDataKey is the key of the entry in CheckState.Data (and CheckResult.Data) that is set
+// to "open" while the circuit breaker of a check is open (see CircuitBreakerPolicy).
+const CircuitOpenDataKey = "circuit"
+
+// CircuitBreakerPolicy configures a circuit breaker that stops executing a check function that keeps failing.
+// After FailureThreshold contiguous failures (see CheckState.ContiguousFails), the circuit opens and the check
+// function is not executed for OpenDuration. In the meantime, the check keeps reporting its last failure, and
+// its data contains the entry CircuitOpenDataKey with value "open". After OpenDuration has passed, the circuit
+// is half-open: the check function is executed once more. If it fails, the circuit opens again, otherwise it
+// is closed. In contrast to Check.Backoff, the circuit breaker also applies to synchronous checks.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is the number of contiguous failures after which the circuit opens.
+	// A value of 0 disables the circuit breaker.
+	FailureThreshold uint
+
+	// OpenDuration defines how long the circuit stays open before the check function is executed
+	// again to probe whether the checked component has recovered. Default is 30 seconds.
+	OpenDuration time.Duration
+}
+
+// isOpen returns true if the circuit is open, i.e., if the check function must not be executed.
+func (p *CircuitBreakerPolicy) isOpen(now time.Time, state *CheckState) bool {
+	if p.FailureThreshold == 0 || state.ContiguousFails < p.FailureThreshold {
+		return false
+	}
+
+	openDuration := p.OpenDuration
+	if openDuration <= 0 {
+		openDuration = 30 * time.Second
+	}
+
+	return now.Sub(state.LastFailureAt) < openDuration
+}
+
+// openCircuit returns the state that is reported while the circuit is open, which
+// is the last known state of the check, marked with CircuitOpenDataKey.
+func openCircuit(state CheckState) CheckState {
+	state.Data = copyData(state.Data)
+	if state.Data == nil {
+		state.Data = make(map[string]interface{}, 1)
+	}
+	state.Data[CircuitOpenDataKey] = "open"
+
+	return state
+}
@@ -0,0 +1,80 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerOpensAfterFailureThreshold(t *testing.T) {
+	// Arrange
+	var calls int32
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithCheck(Check{
+			Name:           "database",
+			CircuitBreaker: CircuitBreakerPolicy{FailureThreshold: 2, OpenDuration: 1 * time.Minute},
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				return errors.New("connection refused")
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	clock.advance(1 * time.Second)
+	ckr.Check(context.Background())
+	clock.advance(1 * time.Second)
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, StatusDown, result.Status)
+	assert.EqualError(t, result.Details["database"].Error, "connection refused")
+	assert.Equal(t, "open", result.Details["database"].Data[CircuitOpenDataKey])
+}
+
+func TestCircuitBreakerClosesAfterSuccessfulProbe(t *testing.T) {
+	// Arrange
+	var calls int32
+	var failing atomic.Value
+	failing.Store(true)
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithCheck(Check{
+			Name:           "database",
+			CircuitBreaker: CircuitBreakerPolicy{FailureThreshold: 1, OpenDuration: 1 * time.Minute},
+			Check: func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+				if failing.Load().(bool) {
+					return errors.New("connection refused")
+				}
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	clock.advance(30 * time.Second)
+	ckr.Check(context.Background())
+	failing.Store(false)
+	clock.advance(31 * time.Second)
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, StatusUp, result.Status)
+	assert.NotContains(t, result.Details["database"].Data, CircuitOpenDataKey)
+}
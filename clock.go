@@ -0,0 +1,56 @@
+package health
+
+import "time"
+
+type (
+	// Clock provides the current time and timers to a Checker (see WithClock). Implementations must be
+	// safe for concurrent use. A fake implementation allows to test time based behaviour, such as periodic
+	// checks, result caching and Check.MaxTimeInError, without real sleeps (see healthtest.FakeClock).
+	Clock interface {
+		// Now returns the current time.
+		Now() time.Time
+		// After returns a channel that receives the current time after at least the provided duration has passed.
+		After(d time.Duration) <-chan time.Time
+		// NewTicker returns a Ticker that delivers the current time on its channel after each period.
+		NewTicker(d time.Duration) Ticker
+	}
+
+	// Ticker delivers ticks at regular intervals (see Clock.NewTicker).
+	Ticker interface {
+		// C returns the channel on which ticks are delivered.
+		C() <-chan time.Time
+		// Stop turns off the ticker. No more ticks will be delivered after Stop returns.
+		Stop()
+	}
+
+	realClock struct{}
+
+	realTicker struct {
+		ticker *time.Ticker
+	}
+)
+
+// Now implements Clock.Now.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After implements Clock.After.
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTicker implements Clock.NewTicker.
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+// C implements Ticker.C.
+func (t *realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+// Stop implements Ticker.Stop.
+func (t *realTicker) Stop() {
+	t.ticker.Stop()
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// manualClock is a Clock whose time only changes when it is advanced. Its timers never fire.
+type manualClock struct {
+	realClock
+	mtx sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+func (c *manualClock) After(time.Duration) <-chan time.Time {
+	return nil
+}
+
+func (c *manualClock) advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestClockIsUsedForCacheExpiry(t *testing.T) {
+	// Arrange
+	var calls int32
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithClock(clock),
+		WithCacheDuration(1*time.Minute),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	clock.advance(30 * time.Second)
+	ckr.Check(context.Background())
+	callsBeforeExpiry := atomic.LoadInt32(&calls)
+	clock.advance(31 * time.Second)
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(1), callsBeforeExpiry)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	state, _ := ckr.GetCheckState("database")
+	assert.Equal(t, clock.Now(), state.LastCheckedAt)
+}
+
+func TestClockIsUsedForMaxTimeInError(t *testing.T) {
+	// Arrange
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithCheck(Check{Name: "database", MaxTimeInError: 1 * time.Minute, Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+	)
+
+	// Act
+	first := ckr.Check(context.Background())
+	clock.advance(2 * time.Minute)
+	second := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, first.Status)
+	assert.Equal(t, StatusDown, second.Status)
+}
@@ -0,0 +1,110 @@
+package health
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+)
+
+// compressingWriter buffers the response body, so that it can be compressed if it is large enough
+// (see WithCompression). The buffered response is written by calling finish.
+type compressingWriter struct {
+	http.ResponseWriter
+	encoding   string
+	minSize    int
+	statusCode int
+	buf        bytes.Buffer
+}
+
+// newCompressingWriter wraps the http.ResponseWriter if the client accepts a supported encoding
+// (see WithCompression). Otherwise, it returns nil.
+func newCompressingWriter(w http.ResponseWriter, r *http.Request, minSize int) *compressingWriter {
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+	if encoding == "" {
+		return nil
+	}
+
+	return &compressingWriter{ResponseWriter: w, encoding: encoding, minSize: minSize}
+}
+
+// WriteHeader implements http.ResponseWriter.WriteHeader. Informational responses
+// (such as 103 Early Hints, see WithEarlyHints) are written immediately.
+func (cw *compressingWriter) WriteHeader(statusCode int) {
+	if statusCode >= 100 && statusCode < 200 {
+		cw.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+
+	if cw.statusCode == 0 {
+		cw.statusCode = statusCode
+	}
+}
+
+// Write implements http.ResponseWriter.Write.
+func (cw *compressingWriter) Write(data []byte) (int, error) {
+	cw.WriteHeader(http.StatusOK)
+	return cw.buf.Write(data)
+}
+
+// Unwrap returns the underlying http.ResponseWriter (see http.ResponseController).
+func (cw *compressingWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// finish writes the buffered response. The body is compressed if it is at least as large as the size threshold.
+func (cw *compressingWriter) finish() error {
+	if cw.statusCode == 0 {
+		return nil
+	}
+
+	if cw.buf.Len() == 0 || cw.buf.Len() < cw.minSize {
+		cw.ResponseWriter.WriteHeader(cw.statusCode)
+		_, err := cw.ResponseWriter.Write(cw.buf.Bytes())
+		return err
+	}
+
+	var (
+		compressed bytes.Buffer
+		encoder    io.WriteCloser
+	)
+	if cw.encoding == "gzip" {
+		encoder = gzip.NewWriter(&compressed)
+	} else {
+		encoder = zlib.NewWriter(&compressed)
+	}
+
+	if _, err := encoder.Write(cw.buf.Bytes()); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	cw.Header().Set("Content-Encoding", cw.encoding)
+	cw.Header().Del("Content-Length")
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+	_, err := cw.ResponseWriter.Write(compressed.Bytes())
+
+	return err
+}
+
+// negotiateEncoding selects the response encoding based on the Accept-Encoding header of the request.
+// gzip is preferred over deflate. It returns an empty string if no supported encoding is accepted.
+func negotiateEncoding(acceptEncoding string) string {
+	accepted := map[string]bool{}
+	for _, coding := range parseQualityValues(acceptEncoding) {
+		accepted[coding.value] = coding.quality > 0
+	}
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		if allowed, listed := accepted[encoding]; allowed || (!listed && accepted["*"]) {
+			return encoding
+		}
+	}
+
+	return ""
+}
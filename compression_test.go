@@ -0,0 +1,99 @@
+package health
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompressionTestChecker(numChecks int) Checker {
+	options := []CheckerOption{WithDisabledAutostart()}
+	for i := 0; i < numChecks; i++ {
+		options = append(options, WithCheck(Check{
+			Name:  fmt.Sprintf("component-%d", i),
+			Check: func(ctx context.Context) error { return nil },
+		}))
+	}
+	return NewChecker(options...)
+}
+
+func TestHandlerWithCompressionCompressesLargeResponses(t *testing.T) {
+	// Arrange
+	handler := NewHandler(newCompressionTestChecker(50), WithCompression(1024))
+	gzipRequest := httptest.NewRequest(http.MethodGet, "/health", nil)
+	gzipRequest.Header.Set("Accept-Encoding", "deflate, gzip")
+	deflateRequest := httptest.NewRequest(http.MethodGet, "/health", nil)
+	deflateRequest.Header.Set("Accept-Encoding", "gzip;q=0, deflate")
+
+	// Act
+	gzipResp := httptest.NewRecorder()
+	handler.ServeHTTP(gzipResp, gzipRequest)
+	deflateResp := httptest.NewRecorder()
+	handler.ServeHTTP(deflateResp, deflateRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, gzipResp.Code)
+	assert.Equal(t, "gzip", gzipResp.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", gzipResp.Header().Get("Vary"))
+	gzipReader, err := gzip.NewReader(gzipResp.Body)
+	require.NoError(t, err)
+	var gzipResult CheckerResult
+	require.NoError(t, json.NewDecoder(gzipReader).Decode(&gzipResult))
+	assert.Len(t, gzipResult.Details, 50)
+
+	assert.Equal(t, "deflate", deflateResp.Header().Get("Content-Encoding"))
+	zlibReader, err := zlib.NewReader(deflateResp.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zlibReader)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(body))
+}
+
+func TestHandlerWithCompressionSkipsSmallResponses(t *testing.T) {
+	// Arrange
+	handler := NewHandler(newCompressionTestChecker(1), WithCompression(1024))
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+
+	// Act
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, request)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.True(t, json.Valid(w.Body.Bytes()))
+}
+
+func TestHandlerWithCompressionRespectsAcceptEncoding(t *testing.T) {
+	// Arrange
+	handler := NewHandler(newCompressionTestChecker(50), WithCompression(0))
+
+	// Act
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.True(t, json.Valid(w.Body.Bytes()))
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	assert.Equal(t, "gzip", negotiateEncoding("gzip"))
+	assert.Equal(t, "gzip", negotiateEncoding("br, deflate, gzip;q=0.5"))
+	assert.Equal(t, "deflate", negotiateEncoding("gzip;q=0, deflate"))
+	assert.Equal(t, "gzip", negotiateEncoding("*"))
+	assert.Equal(t, "", negotiateEncoding("br"))
+	assert.Equal(t, "", negotiateEncoding(""))
+	assert.Equal(t, "deflate", negotiateEncoding("gzip;q=invalid, deflate"))
+}
@@ -2,7 +2,12 @@ package health
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
+
+	"github.com/robfig/cron/v3"
 )
 
 type (
@@ -13,13 +18,24 @@ type (
 
 		// Check is the check function that will be executed to check availability.
 		// This function must return an error if the checked service is considered
-		// not available. Check is a required attribute.
+		// not available. Either Check or CheckWithData is required.
 		Check func(ctx context.Context) error // Required
 
+		// CheckWithData is an alternative to Check for check functions that report additional data about
+		// the checked component (such as replication lag, queue depth, or version information). The returned
+		// data is available in CheckState.Data and CheckResult.Data (i.e., in the "data" field of the component
+		// in JSON responses). It is reported even if an error is returned. If set, Check is ignored.
+		CheckWithData func(ctx context.Context) (map[string]interface{}, error) // Optional
+
 		// Timeout will override the global timeout value, if it is smaller than
 		// the global timeout (see WithTimeout).
 		Timeout time.Duration // Optional
 
+		// CacheDuration will override the global cache duration (see WithCacheDuration) for this check.
+		// It defines for how long the result of a synchronous check will be cached. A value of 0 means
+		// that the global cache duration is used. This value is ignored for periodic checks.
+		CacheDuration time.Duration // Optional
+
 		// MaxTimeInError will set a duration for how long a service must be
 		// in an error state until it is considered down/unavailable.
 		MaxTimeInError time.Duration // Optional
@@ -28,10 +44,31 @@ type (
 		// check fails until the service is considered down/unavailable.
 		MaxContiguousFails uint // Optional
 
+		// FailureRateThreshold sets the maximum fraction of failed executions (e.g., 0.5 for 50%) within the
+		// last FailureRateWindow executions. If the failure rate exceeds this threshold, the check is considered
+		// down/unavailable, even if the last execution succeeded. If it does not exceed the threshold, the check
+		// is considered up, even if the last execution failed. This way, occasional failures of frequently executed
+		// checks do not flip the status, but sustained failures do. If set, MaxContiguousFails and MaxTimeInError
+		// are ignored. A value of 0 disables the failure rate threshold (default).
+		FailureRateThreshold float64 // Optional
+
+		// FailureRateWindow sets the number of most recent executions that are considered to calculate the
+		// failure rate (see FailureRateThreshold). Default is 10.
+		FailureRateWindow uint // Optional
+
 		// StatusListener allows to set a listener that will be called
 		// whenever the AvailabilityStatus (e.g. from "up" to "down").
 		StatusListener func(ctx context.Context, name string, state CheckState) // Optional
 
+		// StatusTransitionListener works like StatusListener, but receives the previous and the current state
+		// of the check. This allows to react to specific transitions (e.g., to send a recovery notification
+		// when the status changes from "down" to "up") without keeping track of the previous state.
+		StatusTransitionListener func(ctx context.Context, name string, oldState, newState CheckState) // Optional
+
+		// Hooks are called when the result of the check changes between success and failure
+		// (e.g., to notify once on failure and once on recovery). See LifecycleHooks for more information.
+		Hooks LifecycleHooks // Optional
+
 		// Interceptors holds a list of Interceptor instances that will be executed one after another in the
 		// order as they appear in the list.
 		Interceptors []Interceptor
@@ -40,10 +77,147 @@ type (
 		// panics will be automatically converted into errors instead.
 		DisablePanicRecovery bool
 
+		// Retry configures how often and in which intervals the check function will be retried
+		// within a single check execution before a failure is recorded (see RetryPolicy).
+		// Retries are disabled by default.
+		Retry RetryPolicy // Optional
+
+		// Backoff configures how the refresh period of a periodic check (see WithPeriodicCheck) is extended
+		// while the check keeps failing, so that an already struggling dependency is not checked too often
+		// (see BackoffPolicy). Backoff is disabled by default and ignored for synchronous checks.
+		Backoff BackoffPolicy // Optional
+
+		// CircuitBreaker configures a circuit breaker that stops executing the check function after a number
+		// of contiguous failures for some time, so that a component that is known to be unavailable is not
+		// checked over and over again (see CircuitBreakerPolicy). It is disabled by default.
+		CircuitBreaker CircuitBreakerPolicy // Optional
+
+		// SLO defines an availability target for the check (see SLOPolicy). If the availability of the check
+		// within the configured window falls below the target, the check is reported with status StatusDegraded
+		// instead of StatusUp (see CheckState.SLOBreached), even if the last check execution succeeded.
+		// See WithAvailabilityWindows for how the availability is measured. It is disabled by default.
+		SLO SLOPolicy // Optional
+
+		// IntervalJitter is the maximum random duration that is added to the initial delay and to each refresh
+		// period of a periodic check (see WithPeriodicCheck). This prevents that the checks of many instances
+		// that were started at the same time are executed in lockstep and overload a shared dependency.
+		// A value of 0 means that the global jitter is used (see WithIntervalJitter).
+		// This value is ignored for synchronous checks.
+		IntervalJitter time.Duration // Optional
+
+		// InitialDelay defines how long to wait after the Checker was started (see Checker.Start) before the check
+		// is executed for the first time (e.g., to give a dependency time to warm up). Until then, the check keeps
+		// its initial status (StatusUnknown, or StatusStarting if a startup grace period is configured, see
+		// WithStartupGracePeriod). For periodic checks, the larger value of InitialDelay and the initial delay
+		// that was passed to WithPeriodicCheck is used. Checker.CheckNow ignores this value.
+		InitialDelay time.Duration // Optional
+
+		// Schedule turns the check into a periodic check that is executed according to a cron expression
+		// (e.g., "0 2 * * *" to run it every night at 2 am) rather than a fixed refresh period. Standard cron
+		// expressions with five fields and descriptors (such as "@hourly") are supported. The time zone can be
+		// set by prefixing the expression with "CRON_TZ=<location>" (e.g., "CRON_TZ=Europe/Berlin 0 9 * * MON-FRI").
+		// The local time zone is used by default. The check is executed once when the Checker is started and
+		// then according to its schedule. If set, the refresh period and initial delay (see WithPeriodicCheck)
+		// as well as Check.Backoff are ignored. NewChecker panics if the expression is invalid.
+		Schedule string // Optional
+
+		// SharedCache enables the shared result cache for this check (see WithResultCache). If enabled, the
+		// result of the check is read from the shared cache if it has not expired yet (see Check.CacheDuration),
+		// and stored in the shared cache after the check was executed. This way, multiple instances of a service
+		// can share the results of expensive checks of shared dependencies. This value is ignored for periodic checks.
+		SharedCache bool // Optional
+
+		// NonCritical marks a check as informational. The state of a non-critical check is reported in
+		// the component details, but it never affects the aggregated system status.
+		NonCritical bool // Optional
+
+		// DependsOn holds the names of other checks that this check depends on. If any of these checks is
+		// currently down (or skipped itself), the check function will not be executed and the check will
+		// be reported with status StatusSkipped instead. Checks are executed in the order of their dependencies.
+		// NewChecker panics if a dependency does not exist or if dependencies form a cycle.
+		DependsOn []string // Optional
+
+		// Priority defines the execution order of checks within the same dependency level (see Check.DependsOn).
+		// Checks with a higher priority are executed first, checks with the same priority are ordered by name.
+		// This is most useful with sequential execution (see WithSequentialExecution), where checks with a
+		// low priority are skipped when the deadline of the check run has been exceeded. Default is 0.
+		Priority int // Optional
+
+		// Tags holds a list of tags that can be used to select a subset of all checks
+		// (e.g., to serve separate "liveness" and "readiness" endpoints with the same Checker).
+		// See WithTagFilter for more information.
+		Tags []string // Optional
+
+		// Metadata holds arbitrary key/value pairs that describe the checked component (e.g., the owner team
+		// or a runbook URL). Metadata is included in the check result (see CheckResult.Metadata) and can be
+		// retrieved from the context that is passed to interceptors and status listeners of this check
+		// (see CheckMetadataFromContext). Use WithInfo for metadata that describes the whole service.
+		Metadata map[string]interface{} // Optional
+
+		// Probes classifies the check for use with Kubernetes probes (liveness, readiness, startup).
+		// See WithProbeFilter for more information.
+		Probes []Probe // Optional
+
 		updateInterval time.Duration
 		initialDelay   time.Duration
+		schedule       cron.Schedule
+		// config holds the declarative configuration the check was created from
+		// (see NewCheckerFromConfig). It is nil for checks that were configured in code.
+		config *CheckConfig
+		// dispose releases the resources of a check that was created from a declarative configuration
+		// (see DisposableCheckFactory). It is nil if there is nothing to release.
+		dispose func() error
+		// federation holds the components of the remote service of a federated check
+		// (see NewFederatedCheck). It is nil for all other checks.
+		federation *federation
+	}
+
+	// RetryPolicy configures retries of a check function within a single check execution. Only the result of
+	// the last attempt is recorded in the CheckState, so intermediate failures neither count towards
+	// Check.MaxContiguousFails or Check.MaxTimeInError nor trigger any status listeners. All attempts are
+	// executed within the check timeout (see Check.Timeout and WithTimeout). Retries are aborted as soon as
+	// the check context is cancelled. Checks that return a DegradedError are not retried.
+	RetryPolicy struct {
+		// MaxRetries is the maximum number of retries after a failed attempt. A value of 0 disables retries.
+		MaxRetries uint
+
+		// Interval is the time to wait before the first retry.
+		Interval time.Duration
+
+		// Multiplier is applied to the wait time after each retry to achieve exponential backoff.
+		// Values smaller or equal to 1 result in a constant wait time (see Interval).
+		Multiplier float64
+
+		// MaxInterval limits the wait time between two attempts. A value of 0 means no limit.
+		MaxInterval time.Duration
+	}
+
+	// BackoffPolicy configures exponential backoff for failing periodic checks. As long as a check fails,
+	// the time to wait before the next execution starts at InitialInterval and is multiplied by Multiplier
+	// after each contiguous failure (see CheckState.ContiguousFails). As soon as the check succeeds
+	// again (or returns a DegradedError), the regular refresh period is restored.
+	BackoffPolicy struct {
+		// InitialInterval is the time to wait after the first failure.
+		// A value of 0 means that the refresh period of the check is used.
+		InitialInterval time.Duration
+
+		// Multiplier is applied to the wait time after each contiguous failure.
+		// Values smaller or equal to 1 disable backoff.
+		Multiplier float64
+
+		// MaxInterval limits the wait time between two executions. A value of 0 means no limit.
+		MaxInterval time.Duration
+
+		// Jitter randomizes each wait time by up to the given fraction (e.g., 0.1 for ±10%), so
+		// that multiple instances do not retry a failing dependency at the same time.
+		// Values must be between 0 and 1.
+		Jitter float64
 	}
 
+	// Probe classifies a check for use with a specific kind of Kubernetes probe
+	// (see https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/).
+	Probe string
+
 	// CheckerOption is a configuration option for a Checker.
 	CheckerOption func(config *checkerConfig)
 
@@ -51,15 +225,35 @@ type (
 	HandlerOption func(*HandlerConfig)
 )
 
+const (
+	// Liveness classifies a check for use with liveness probes.
+	Liveness Probe = "liveness"
+	// Readiness classifies a check for use with readiness probes.
+	Readiness Probe = "readiness"
+	// Startup classifies a check for use with startup probes.
+	Startup Probe = "startup"
+)
+
+const (
+	defaultTimeout       = 10 * time.Second
+	defaultCacheDuration = 1 * time.Second
+)
+
 // NewChecker creates a new Checker. The provided options will be
 // used to modify its configuration. If the Checker was not yet started
 // (see Checker.IsStarted), it will be started automatically
 // (see Checker.Start). You can disable this autostart by
 // adding the WithDisabledAutostart configuration option.
+// NewChecker panics if the check configuration is invalid (see Check.DependsOn).
 func NewChecker(options ...CheckerOption) Checker {
+	return newChecker(newCheckerConfig(options))
+}
+
+// newCheckerConfig applies the options to the default configuration of a Checker.
+func newCheckerConfig(options []CheckerOption) checkerConfig {
 	cfg := checkerConfig{
-		cacheTTL:     1 * time.Second,
-		timeout:      10 * time.Second,
+		cacheTTL:     defaultCacheDuration,
+		timeout:      defaultTimeout,
 		checks:       map[string]*Check{},
 		interceptors: []Interceptor{},
 	}
@@ -68,7 +262,7 @@ func NewChecker(options ...CheckerOption) Checker {
 		opt(&cfg)
 	}
 
-	return newChecker(cfg)
+	return cfg
 }
 
 // WithDisabledDetails disables all data in the JSON response body. The AvailabilityStatus will be the only
@@ -88,12 +282,159 @@ func WithTimeout(timeout time.Duration) CheckerOption {
 	}
 }
 
+// WithMaxConcurrentChecks limits how many synchronous checks are executed at the same time when a check run is
+// triggered (e.g., by a request to the health endpoint). Further checks wait until a running check has completed.
+// This avoids resource usage spikes for Checkers with many checks. Periodic checks are not affected.
+// Default value is 0, which means that all checks are executed concurrently.
+func WithMaxConcurrentChecks(n uint) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.maxConcurrentChecks = n
+	}
+}
+
+// WithDeadlineBudget splits the time that remains until the deadline of a check run (see WithTimeout and the
+// context passed to Checker.Check) across the checks that still need to be executed, so that a single slow
+// check cannot starve the checks that depend on it (see Check.DependsOn). Checks are executed level by level
+// in the order of their dependencies. Each check gets an equal share of the remaining time per level it still
+// has to wait for. If the number of concurrently executed checks is limited (see WithMaxConcurrentChecks),
+// every batch of checks within a level counts as a separate level. The resulting deadline is passed to check
+// functions via their context. A check timeout (see Check.Timeout) still applies if it is shorter.
+func WithDeadlineBudget() CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.deadlineBudget = true
+	}
+}
+
+// WithClock sets the Clock that is used by the Checker to schedule periodic checks, to expire cached results
+// (see WithCacheDuration) and to evaluate time based thresholds (such as Check.MaxTimeInError). A fake Clock
+// allows to test time based behaviour deterministically and to simulate clock skew (see healthtest.FakeClock).
+// Check timeouts (see Check.Timeout and WithTimeout) are enforced using context deadlines, so they are
+// always based on the system clock. By default, the system clock is used.
+func WithClock(clock Clock) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.clock = clock
+	}
+}
+
+// WithStaleWhileRevalidate bounds the latency of Checker.Check and Checker.CheckWithFilter. If a check run
+// takes longer than softDeadline (e.g., because a check is slow or the run waits for another one that is
+// in-flight), the last known results are returned immediately and marked as stale (see CheckerResult.Stale),
+// while the check run completes in the background and refreshes the results for subsequent calls. The check
+// run is not cancelled when the caller stops waiting, but is still bound to the timeout (see WithTimeout).
+// Only one check run is executed in the background at a time. Calls that arrive while it is still running do not
+// start another one, but wait for it until the soft deadline has passed (regardless of their filter), so slow
+// checks are not executed over and over again. Checks that were never executed are reported with status StatusUnknown. Forced check runs
+// (see Checker.CheckNow and Checker.CheckAllNow) are not affected. By default, callers wait until all
+// checks have completed.
+func WithStaleWhileRevalidate(softDeadline time.Duration) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.staleAfter = softDeadline
+	}
+}
+
+// WithSingleFlight coalesces concurrent check runs (see Checker.Check and Checker.CheckWithFilter). If a check is
+// already being executed when a check run starts, the check run waits for and shares the result of that execution
+// instead of executing the check again. This is most useful if caching is disabled or short
+// (see WithCacheDuration) and many requests arrive at the same time. Forced check runs
+// (see Checker.CheckNow and Checker.CheckAllNow) always execute the checks.
+func WithSingleFlight() CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.singleFlight = true
+	}
+}
+
+// WithLogger sets a Logger that is used to log internal events, such as check executions, timeouts, panics,
+// publisher errors and delayed periodic checks (see Logger). A *slog.Logger can be passed directly.
+// By default, nothing is logged.
+func WithLogger(logger Logger) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.logger = logger
+	}
+}
+
+// WithListenerTimeout limits how long check processing waits for a listener to return, such as a status
+// listener (see WithStatusListener and Check.StatusListener) or a lifecycle hook (see LifecycleHooks). A listener
+// that did not return in time keeps running in the background, but check processing continues. Listeners that
+// panic are always recovered. Timeouts and panics are logged (see WithLogger). By default, check processing
+// waits until a listener returns.
+func WithListenerTimeout(timeout time.Duration) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.listenerTimeout = timeout
+	}
+}
+
+// WithAsyncListeners calls listeners from a separate goroutine instead of the goroutine that processes
+// the checks, so that slow listeners (e.g., listeners that send notifications over the network) do not
+// delay check processing. This applies to status listeners (see WithStatusListener, WithStatusTransitionListener,
+// Check.StatusListener and Check.StatusTransitionListener), lifecycle hooks (see LifecycleHooks) and config change
+// listeners (see WithConfigChangeListener). Listeners are called one after another in the order of the events.
+// Up to bufferSize listener calls are queued. Further listener calls are dropped until the queue has space
+// again (see WithLogger). Queued listeners are only called while the Checker is started (see Checker.Start).
+// Attention: The context that is passed to a listener may already be cancelled when the listener is called.
+func WithAsyncListeners(bufferSize int) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.listenerQueue = make(chan func(), bufferSize)
+	}
+}
+
+// WithConfigChangeListener registers a listener function that is called after Checker.Reload
+// changed the set of checks (see ConfigChange).
+func WithConfigChangeListener(listener func(ctx context.Context, change ConfigChange)) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.configChangeListener = listener
+	}
+}
+
+// WithSequentialExecution executes synchronous checks one after another instead of concurrently, in the order
+// of their dependencies (see Check.DependsOn) and priorities (see Check.Priority). This makes the execution
+// order deterministic and avoids load spikes on shared dependencies. Checks whose turn comes after the deadline
+// of the check run has been exceeded (see WithTimeout) are not executed but reported with status StatusSkipped
+// and error BudgetExhaustedErr, so that they are not mistaken for timed out checks. Consider combining this
+// option with WithDeadlineBudget, so that a single slow check cannot use up the whole time budget.
+func WithSequentialExecution() CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.sequentialExecution = true
+	}
+}
+
 // WithStatusListener registers a listener function that will be called whenever the overall/aggregated system health
 // status changes (e.g. from "up" to "down"). Attention: Because this listener is also executed for synchronous
-// (i.e, request-based) health checks, it should not block processing.
+// (i.e, request-based) health checks, it should not block processing. This option can be used multiple times
+// to register more than one listener. Listeners are executed in the order they were registered. Listeners may
+// read the state of the Checker (e.g., using Checker.GetCheckState), since they are called after the state has
+// been updated.
 func WithStatusListener(listener func(ctx context.Context, state CheckerState)) CheckerOption {
 	return func(cfg *checkerConfig) {
-		cfg.statusChangeListener = listener
+		previous := cfg.statusChangeListener
+		if previous == nil {
+			cfg.statusChangeListener = listener
+			return
+		}
+
+		cfg.statusChangeListener = func(ctx context.Context, state CheckerState) {
+			previous(ctx, state)
+			listener(ctx, state)
+		}
+	}
+}
+
+// WithStatusTransitionListener works like WithStatusListener, but the listener receives the previous and the
+// current CheckerState. This allows to react to specific transitions (e.g., to send a recovery notification
+// when the status changes from "down" to "up") without keeping track of the previous state. This option can be
+// used multiple times to register more than one listener. Listeners are executed in the order they were
+// registered, after the listeners that were registered using WithStatusListener.
+func WithStatusTransitionListener(listener func(ctx context.Context, oldState, newState CheckerState)) CheckerOption {
+	return func(cfg *checkerConfig) {
+		previous := cfg.statusTransitionListener
+		if previous == nil {
+			cfg.statusTransitionListener = listener
+			return
+		}
+
+		cfg.statusTransitionListener = func(ctx context.Context, oldState, newState CheckerState) {
+			previous(ctx, oldState, newState)
+			listener(ctx, oldState, newState)
+		}
 	}
 }
 
@@ -106,6 +447,17 @@ func WithMiddleware(middleware ...Middleware) HandlerOption {
 	}
 }
 
+// WithHTTPMiddleware configures HTTP middlewares that will be used by the handler to pre- and post-process HTTP
+// requests and health checks. In contrast to WithMiddleware, the middlewares also receive the http.ResponseWriter,
+// e.g., to set response headers or to reject requests. HTTP middlewares are executed before the middlewares that
+// were configured using WithMiddleware. Refer to the documentation of type HTTPMiddleware for more information.
+// This option can be used multiple times.
+func WithHTTPMiddleware(middleware ...HTTPMiddleware) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.httpMiddleware = append(cfg.httpMiddleware, middleware...)
+	}
+}
+
 // WithStatusCodeUp sets an HTTP status code that will be used for responses
 // where the system is considered to be available ("up").
 // Default is HTTP status code 200 (OK).
@@ -124,6 +476,145 @@ func WithStatusCodeDown(httpStatus int) HandlerOption {
 	}
 }
 
+// WithTagFilter configures the handler to only execute, aggregate and report checks that carry at least
+// one of the provided tags (see Check.Tags). Checks without any of these tags do neither contribute to
+// the aggregated status nor appear in the component details. This allows to serve multiple endpoints
+// (e.g., "/live" and "/ready") that are backed by the same Checker instance and share its cache.
+// NewHandler panics if no tags are provided or if no check of the Checker carries any of the provided tags.
+func WithTagFilter(tags ...string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.checkFilters = append(cfg.checkFilters, handlerCheckFilter{
+			description: fmt.Sprintf("tag filter %v", tags),
+			numValues:   len(tags),
+			filter:      TagFilter(tags...),
+		})
+	}
+}
+
+// WithTagExclusionFilter configures the handler to only execute, aggregate and report checks that carry none of
+// the provided tags (see Check.Tags). It can be combined with WithTagFilter, e.g., to serve all checks tagged
+// "critical" except those tagged "slow". NewHandler panics if no tags are provided or if all checks of the
+// Checker carry at least one of the provided tags.
+func WithTagExclusionFilter(tags ...string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.checkFilters = append(cfg.checkFilters, handlerCheckFilter{
+			description: fmt.Sprintf("tag exclusion filter %v", tags),
+			numValues:   len(tags),
+			filter:      ExcludeTagFilter(tags...),
+		})
+	}
+}
+
+// WithProbeFilter configures the handler to only execute, aggregate and report checks that are classified
+// for at least one of the provided probes (see Check.Probes). This allows to serve separate Kubernetes liveness,
+// readiness and startup probe endpoints from the same Checker instance, e.g.:
+//
+//	http.Handle("/ready", health.NewHandler(checker, health.WithProbeFilter(health.Readiness)))
+//
+// NewHandler panics if no probes are provided or if no check of the Checker is classified for any of the provided probes.
+func WithProbeFilter(probes ...Probe) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.checkFilters = append(cfg.checkFilters, handlerCheckFilter{
+			description: fmt.Sprintf("probe filter %v", probes),
+			numValues:   len(probes),
+			filter:      ProbeFilter(probes...),
+		})
+	}
+}
+
+// ProbeFilter creates a CheckFilter that accepts all checks that are classified for at least one
+// of the provided probes (see Check.Probes).
+func ProbeFilter(probes ...Probe) CheckFilter {
+	return func(check Check) bool {
+		for _, checkProbe := range check.Probes {
+			for _, probe := range probes {
+				if checkProbe == probe {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// TagFilter creates a CheckFilter that accepts all checks that carry at least one of the provided tags
+// (see Check.Tags).
+func TagFilter(tags ...string) CheckFilter {
+	return func(check Check) bool {
+		for _, checkTag := range check.Tags {
+			for _, tag := range tags {
+				if checkTag == tag {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// ExcludeTagFilter creates a CheckFilter that accepts all checks that carry none of the provided tags (see Check.Tags).
+func ExcludeTagFilter(tags ...string) CheckFilter {
+	include := TagFilter(tags...)
+	return func(check Check) bool {
+		return !include(check)
+	}
+}
+
+// NameFilter creates a CheckFilter that accepts all checks with one of the provided names.
+func NameFilter(names ...string) CheckFilter {
+	return func(check Check) bool {
+		for _, name := range names {
+			if check.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// WithStatusCode sets the HTTP status code that will be used for responses where the aggregated system status
+// equals the provided status (e.g., to respond with 200 instead of 503 if the system is degraded or starting).
+// It takes precedence over WithStatusCodeUp, WithStatusCodeDown and WithStatusCodeMaintenance.
+// This option can be used multiple times to map different statuses.
+func WithStatusCode(status AvailabilityStatus, httpStatus int) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		if cfg.statusCodes == nil {
+			cfg.statusCodes = map[AvailabilityStatus]int{}
+		}
+		cfg.statusCodes[status] = httpStatus
+	}
+}
+
+// WithStatusCodeResolver sets a function that determines the HTTP status code of a response based on the
+// whole CheckerResult, including the component details (e.g., to respond with 429 if a rate limit check failed).
+// It is called before details are removed for unauthorized requests (see WithDetailsAuthorizer). If the function
+// returns 0, the status code is determined as usual (see WithStatusCode, WithStatusCodeUp and WithStatusCodeDown).
+func WithStatusCodeResolver(resolver func(result CheckerResult) int) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.statusCodeResolver = resolver
+	}
+}
+
+// WithResponseHeaders registers a function that derives HTTP response headers from the CheckerResult
+// (e.g., "Retry-After" if the system is down, or "Cache-Control" directives). Returned headers replace
+// headers of the same name that were set by the handler (such as "Cache-Control"). The function is called
+// before details are removed for unauthorized requests (see WithDetailsAuthorizer). This option can be used
+// multiple times. If multiple functions return the same header, the last one wins.
+func WithResponseHeaders(headers func(result CheckerResult) http.Header) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.responseHeaders = append(cfg.responseHeaders, headers)
+	}
+}
+
+// WithStatusCodeMaintenance sets an HTTP status code that will be used for responses
+// where the system is in maintenance mode (see Checker.Pause).
+// Default is HTTP status code 503 (Service Unavailable).
+func WithStatusCodeMaintenance(httpStatus int) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.statusCodeMaint = httpStatus
+	}
+}
+
 // WithResultWriter is responsible for writing a health check result (see CheckerResult)
 // into an HTTP response. By default, JSONResultWriter will be used.
 func WithResultWriter(writer ResultWriter) HandlerOption {
@@ -132,6 +623,208 @@ func WithResultWriter(writer ResultWriter) HandlerOption {
 	}
 }
 
+// WithResultWriters registers additional result writers by media type (e.g., "application/xml"), so that the
+// handler can select the response format based on the "Accept" header of the request (content negotiation).
+// The default result writer (see WithResultWriter) is used if a request does not contain an "Accept" header or
+// if it accepts any media type ("*/*"). If none of the accepted media types is supported, the handler responds
+// with HTTP status code 406 (Not Acceptable) without executing any checks. This option can be used multiple times.
+func WithResultWriters(writers map[string]ResultWriter) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		if cfg.resultWriters == nil {
+			cfg.resultWriters = make(map[string]ResultWriter, len(writers))
+		}
+		for mediaType, writer := range writers {
+			cfg.resultWriters[strings.ToLower(mediaType)] = writer
+		}
+	}
+}
+
+// WithContextEnricher registers a function that derives the context that is passed to the checker from the
+// HTTP request (e.g., to add trace IDs, authentication claims or a tenant ID from request headers). The enriched
+// context is passed to middleware (see WithMiddleware) and to all synchronous checks that are executed for the
+// request, including their interceptors and status listeners. Note that cached results (see WithCacheDuration)
+// and results of periodic checks are not affected. This option can be used multiple times; enrichers are
+// applied in the order in which they were registered.
+func WithContextEnricher(enricher func(ctx context.Context, r *http.Request) context.Context) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.contextEnrichers = append(cfg.contextEnrichers, enricher)
+	}
+}
+
+// WithETag enables ETag support. The handler sends an ETag header that is derived from the aggregated status and
+// the status, timestamp and error of each component, as well as from the media type and language of the response
+// (see WithResultWriters and WithJSONMessageCatalog). If a request contains a matching "If-None-Match" header,
+// the handler responds with HTTP status code 304 (Not Modified) and an empty body instead of serializing the
+// result again. This saves bandwidth for clients that poll the endpoint frequently, because results are only
+// updated when checks are executed (see WithCacheDuration and WithPeriodicCheck). Only successful responses
+// (HTTP status code 2xx) are answered with 304.
+func WithETag() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.etagEnabled = true
+	}
+}
+
+// WithEarlyHints makes the handler send a 103 (Early Hints) informational response before any check is executed,
+// so that clients and proxies learn that the request is being processed while long-running checks are still
+// being evaluated. The provided values are sent as "Link" headers of the informational response
+// (e.g., "</status.css>; rel=preload; as=style"). Clients that do not support informational responses ignore it.
+// Early hints are only sent if the program was built with Go 1.19 or later, since earlier versions of net/http
+// do not support informational responses. Otherwise, this option has no effect.
+func WithEarlyHints(links ...string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.earlyHints = true
+		cfg.earlyHintLinks = links
+	}
+}
+
+// WithStreaming makes the handler stream results instead of waiting for all checks to complete, so that probes
+// with tight timeouts get the essential status fast. The response is written in JSON Lines format (see
+// StreamingContentType). The HTTP status code and the first line (e.g., {"status":"up"}) are based on the last
+// known aggregated status and are written before any check is executed. Afterwards, a line with the result of
+// each component (e.g., {"component":"database","result":{"status":"up"}}) is written as soon as its check has
+// completed. Components that are not executed (e.g., periodic checks) are written after all checks have completed.
+// The last line contains the new aggregated status (e.g., {"status":"down","final":true}), which is also sent in
+// the HTTP trailer StatusTrailer. Component lines are omitted for requests that are not authorized to see details
+// (see WithDetailsAuthorizer). The check run is wrapped by the configured middleware (see WithMiddleware and
+// WithHTTPMiddleware). Because middleware may change the result after all checks have completed (e.g., to remove
+// details), component lines are only written once the middleware has returned if any Middleware is configured.
+// The result writer (see WithResultWriter) and ETag support (see WithETag) are not used for streamed responses.
+func WithStreaming() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.streaming = true
+	}
+}
+
+// WithResultLimits limits the size of the results that are written by the handler, so that a misbehaving check
+// cannot bloat the response (e.g., by reporting megabytes of data). Limits can be set for the number of components,
+// the length of error messages and the length of data values (see ResultLimits). Components whose error message
+// or data has been truncated are marked as truncated (see CheckResult.Truncated), and the number of omitted
+// components is reported in CheckerResult.OmittedDetails. The limit for the number of components does not apply
+// to streamed responses (see WithStreaming).
+func WithResultLimits(limits ResultLimits) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.resultLimits = &limits
+	}
+}
+
+// WithCompression enables compression of response bodies using gzip or deflate, depending on the
+// "Accept-Encoding" header of the request. This considerably reduces the size of responses of checkers with
+// many components. Response bodies that are smaller than minSize bytes are not compressed, because compressing
+// tiny payloads does not pay off. Streamed responses (see WithStreaming) are not compressed.
+func WithCompression(minSize int) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.compressionEnabled = true
+		cfg.compressionMinSize = minSize
+	}
+}
+
+// WithCORS enables Cross-Origin Resource Sharing (CORS), so that browser-based dashboards that are served from
+// a different origin can query the handler. Requests from one of the allowed origins (e.g.,
+// "https://dashboard.example.com", or "*" to allow all origins) receive an "Access-Control-Allow-Origin" header.
+// Preflight requests (OPTIONS requests with an "Access-Control-Request-Method" header) are answered with HTTP
+// status code 204 (No Content) and the accepted methods (see WithMethods) and request headers (e.g.,
+// "Authorization") without executing any checks. Preflight requests from other origins are answered with
+// HTTP status code 403 (Forbidden).
+func WithCORS(allowedOrigins []string, headers []string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.cors = &corsConfig{allowedOrigins: allowedOrigins, allowedHeaders: headers}
+	}
+}
+
+// WithMethods sets the HTTP methods that the handler accepts (e.g., http.MethodGet, http.MethodHead and
+// http.MethodPost). Requests with any other method are answered with HTTP status code 405 (Method Not Allowed)
+// and an "Allow" header that lists the accepted methods, without executing any checks. POST is always accepted
+// if forced refreshes are enabled (see WithRefreshEnabled). If POST is accepted and check selection is enabled
+// (see WithCheckSelection), the checks can also be selected in the request body. By default, only GET and HEAD
+// are accepted.
+func WithMethods(methods ...string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.methods = methods
+	}
+}
+
+// WithCheckSelection allows clients to select the checks that are executed and reported by a request using the
+// "check" query parameter, e.g.: GET /health?check=database&check=cache. This is useful to debug a single
+// dependency without waiting for all checks to complete. The aggregated status is only based on the selected
+// checks. Requests that select checks that do not exist (or that are excluded by a handler filter, such as
+// WithTagFilter) are answered with HTTP status code 400. Requests without the query parameter are not affected.
+// POST requests (see WithMethods) can select checks in a form body (e.g., "check=database&check=cache") or in
+// a JSON body (e.g., {"checks":["database","cache"]}) instead.
+func WithCheckSelection() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.checkSelectionEnabled = true
+	}
+}
+
+// WithDetailsAuthorizer sets a function that decides for each request whether the caller is authorized to see the
+// component details (such as check names and error messages) and info values (see WithInfo). Requests for which the
+// function returns false will only receive the aggregated status, e.g.: { "status":"down" }. This allows to
+// expose full details to trusted callers only (e.g., from an internal network or with a valid token), while the
+// aggregated status remains visible to everyone. In contrast to WithDisabledDetails, this is decided per request.
+func WithDetailsAuthorizer(authorizer func(r *http.Request) bool) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.detailsAuth = authorizer
+	}
+}
+
+// WithBasicAuth secures the handler with HTTP basic authentication
+// (https://en.wikipedia.org/wiki/Basic_access_authentication). Requests are only processed if they contain
+// the provided username and a password that is accepted by passwordValid. All other requests are answered
+// with HTTP status code 401 (Unauthorized) without executing any checks. If you want to show the aggregated
+// status to unauthenticated clients, consider using WithDetailsAuthorizer instead. If this option is combined
+// with WithBearerToken, a request is processed if it passes either one of them.
+func WithBasicAuth(username string, passwordValid func(password string) bool) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.authenticators = append(cfg.authenticators, basicAuthenticator(username, passwordValid))
+	}
+}
+
+// WithBearerToken secures the handler with bearer token authentication (https://datatracker.ietf.org/doc/html/rfc6750).
+// Requests are only processed if they contain an "Authorization" header with a bearer token that is accepted
+// by tokenValid. All other requests are answered with HTTP status code 401 (Unauthorized) without executing
+// any checks. If this option is combined with WithBasicAuth, a request is processed if it passes either one of them.
+func WithBearerToken(tokenValid func(token string) bool) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.authenticators = append(cfg.authenticators, bearerTokenAuthenticator(tokenValid))
+	}
+}
+
+// WithRefreshEnabled allows clients to force a refresh of all checks by sending a POST request with query
+// parameter "refresh=true" (e.g., "POST /health?refresh=true"). A forced refresh bypasses the cache and executes
+// all checks immediately, including periodic checks (see Checker.CheckAllNow). To prevent abuse, forced refreshes
+// are rate limited (see WithRefreshRateLimit). Requests that exceed the rate limit are answered with HTTP status
+// code 429 (Too Many Requests) and a "Retry-After" header. Forced refreshes are disabled by default.
+func WithRefreshEnabled() HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.refreshEnabled = true
+	}
+}
+
+// WithRefreshRateLimit sets the minimum time that must pass between two forced refreshes of a handler
+// (see WithRefreshEnabled). Default is 10 seconds.
+func WithRefreshRateLimit(minInterval time.Duration) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		cfg.refreshInterval = minInterval
+	}
+}
+
+// WithRateLimit limits the number of requests that a handler processes, so that misconfigured or abusive clients
+// cannot overload the checked dependencies through the health endpoint. Requests are limited using a token bucket
+// per key that allows rps requests per second on average, with bursts of up to burst requests. The key function
+// assigns requests to buckets (e.g., RemoteIPKey to limit requests per client). If keyFunc is nil, all requests
+// share a single bucket. Rate limited requests receive HTTP status code 429 (Too Many Requests) along with the
+// Retry-After header. The response body holds the last result of the handler, if available, so that no checks
+// are executed for rate limited requests. A rps or burst that is not positive disables rate limiting (default).
+func WithRateLimit(rps float64, burst int, keyFunc func(r *http.Request) string) HandlerOption {
+	return func(cfg *HandlerConfig) {
+		if rps <= 0 || burst <= 0 {
+			cfg.rateLimit = nil
+			return
+		}
+		cfg.rateLimit = &rateLimitConfig{rps: rps, burst: burst, keyFunc: keyFunc}
+	}
+}
+
 // WithDisabledAutostart disables automatic startup of a Checker instance.
 func WithDisabledAutostart() CheckerOption {
 	return func(cfg *checkerConfig) {
@@ -180,12 +873,160 @@ func WithPeriodicCheck(refreshPeriod time.Duration, initialDelay time.Duration,
 	}
 }
 
+// WithPanicHandler registers a function that is called whenever a check function panics, e.g., to log the
+// panic or to report it to an error tracking service. The panic is still reported as a check failure
+// (see PanicError). The handler is not called for checks that disabled panic recovery
+// (see Check.DisablePanicRecovery).
+func WithPanicHandler(handler func(checkName string, err *PanicError)) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.panicHandler = handler
+	}
+}
+
 // WithInterceptors adds a list of interceptors that will be applied to every check function. Interceptors
 // may intercept the function call and do some pre- and post-processing, having the check state and check function
-// result at hand. The interceptors will be executed in the order they are passed to this function.
+// result at hand. The interceptors will be executed in the order they are passed to this function, before
+// the interceptors of the individual check (see Check.Interceptors). This option can be used multiple times.
 func WithInterceptors(interceptors ...Interceptor) CheckerOption {
 	return func(cfg *checkerConfig) {
-		cfg.interceptors = interceptors
+		cfg.interceptors = append(cfg.interceptors, interceptors...)
+	}
+}
+
+// WithPublisher registers a Publisher that will be called after every check evaluation (see Publisher).
+// Each Publisher is executed in its own goroutine that is running as long as the Checker is started
+// (see Checker.Start), so a slow or failing Publisher neither stalls check execution nor affects
+// other publishers. Evaluation results are buffered in a queue that holds up to queueSize entries.
+// If the queue is full, new results are dropped and a PublisherQueueFullErr is passed to the
+// error handler (see WithPublisherErrorHandler). This option can be used multiple times.
+func WithPublisher(publisher Publisher, queueSize int) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.publishers = append(cfg.publishers, publisherConfig{publisher: publisher, queueSize: queueSize})
+	}
+}
+
+// WithPublisherErrorHandler sets a function that will be called whenever a Publisher returns an error,
+// panics, or when a result had to be dropped because the queue of a Publisher was full (see WithPublisher).
+// Attention: Because this function may also be called while checks are being processed, it should not block.
+func WithPublisherErrorHandler(handler func(err error)) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.publisherErrHandler = handler
+	}
+}
+
+// WithHistorySize enables a history of the most recent evaluation results (status, duration and error) for
+// each check. The history holds up to size entries per check. Older entries are discarded. It is available
+// via Checker.GetCheckHistory and in the component details of every CheckerResult (see CheckResult.History),
+// which makes flapping components visible from the health endpoint. A size of 0 disables the history (default).
+func WithHistorySize(size uint) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.historySize = size
+	}
+}
+
+// WithFlapDetection enables flap detection for all checks. A check is considered flapping if its status changed
+// at least threshold times within the provided time window (e.g., because a dependency is unstable). While a check
+// is flapping, it is reported with status StatusDegraded (see CheckState.Flapping), so that status listeners
+// (see WithStatusListener and Check.StatusListener) are not called for every single status change.
+// A check stops flapping as soon as the number of status changes within the window drops below the threshold.
+// A threshold or window of 0 disables flap detection (default).
+func WithFlapDetection(window time.Duration, threshold uint) CheckerOption {
+	return func(cfg *checkerConfig) {
+		if window <= 0 || threshold == 0 {
+			cfg.flapDetection = nil
+			return
+		}
+		cfg.flapDetection = &flapDetectionConfig{window: window, threshold: threshold}
+	}
+}
+
+// WithStartupGracePeriod sets a period of time after the Checker was started (see Checker.Start), during which
+// checks that were not executed yet or that fail are reported with status StatusStarting rather than
+// StatusUnknown or StatusDown. The handler responds with the status code for available systems while the aggregated
+// status is StatusStarting (see WithStatusCodeUp). This prevents that freshly started services are considered
+// unavailable (e.g., by a Kubernetes liveness probe) while their dependencies are still warming up. As soon as
+// the grace period has passed, the status of all checks that are still starting is re-evaluated.
+// The startup grace period is disabled by default.
+func WithStartupGracePeriod(gracePeriod time.Duration) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.startupGracePeriod = gracePeriod
+	}
+}
+
+// WithAggregator sets the function that combines the states of individual checks into the aggregated system status
+// (e.g., to only consider the system to be down if a quorum of components is down, see QuorumAggregator).
+// By default, WorstStatusAggregator is used.
+func WithAggregator(aggregator AggregationFunc) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.aggregator = aggregator
+	}
+}
+
+// WorstStatusAggregator is an AggregationFunc that reports the most critical status of all checks
+// (e.g., the system is down as soon as one of the checks is down). This is the default aggregation strategy.
+func WorstStatusAggregator(states map[string]CheckState) AvailabilityStatus {
+	return aggregateStatus(states)
+}
+
+// QuorumAggregator creates an AggregationFunc that only reports the system as down if at least minDown
+// checks are down. If fewer checks are down, the system is reported as degraded. All other statuses
+// are aggregated like in WorstStatusAggregator.
+func QuorumAggregator(minDown uint) AggregationFunc {
+	return func(states map[string]CheckState) AvailabilityStatus {
+		var (
+			numDown   uint
+			remaining = make(map[string]CheckState, len(states))
+		)
+
+		for name, state := range states {
+			if state.Status == StatusDown {
+				numDown++
+				continue
+			}
+			remaining[name] = state
+		}
+
+		if numDown >= minDown && numDown > 0 {
+			return StatusDown
+		}
+
+		status := aggregateStatus(remaining)
+		if numDown > 0 && status.criticality() < StatusDegraded.criticality() {
+			status = StatusDegraded
+		}
+
+		return status
+	}
+}
+
+// WithIntervalJitter sets the maximum random duration that is added to the initial delay and to each refresh
+// period of all periodic checks (see WithPeriodicCheck). It can be overridden for each check individually
+// (see Check.IntervalJitter). By default, no jitter is applied.
+func WithIntervalJitter(jitter time.Duration) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.intervalJitter = jitter
+	}
+}
+
+// WithStateStore configures a StateStore that is used to persist the state of all checks (such as their status,
+// failure counters and timestamps) after every check evaluation, and to restore it when the Checker is started
+// for the first time (see Checker.Start). States are saved asynchronously by a Publisher (see WithPublisher).
+// Errors that occur while loading or saving states are passed to the publisher error handler
+// (see WithPublisherErrorHandler).
+func WithStateStore(store StateStore) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.stateStore = store
+		WithPublisher(&stateStorePublisher{store: store}, 100)(cfg)
+	}
+}
+
+// WithResultCache configures a ResultCache that is shared by multiple Checker instances (e.g., a Redis based cache).
+// It is used for all synchronous checks that have the shared cache enabled (see Check.SharedCache). Errors that
+// occur while accessing the cache are passed to the publisher error handler (see WithPublisherErrorHandler)
+// and the check is executed as if no cached result was available.
+func WithResultCache(cache ResultCache) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.resultCache = cache
 	}
 }
 
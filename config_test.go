@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
@@ -91,7 +92,7 @@ func TestWithMiddlewareConfig(t *testing.T) {
 	cfg := HandlerConfig{}
 	mw := func(MiddlewareFunc) MiddlewareFunc {
 		return func(r *http.Request) CheckerResult {
-			return CheckerResult{nil, StatusUp, nil}
+			return CheckerResult{Status: StatusUp}
 		}
 	}
 
@@ -113,9 +114,10 @@ func TestWithInterceptorConfig(t *testing.T) {
 
 	// Act
 	WithInterceptors(interceptor)(&cfg)
+	WithInterceptors(interceptor, interceptor)(&cfg)
 
 	// Assert
-	assert.Equal(t, 1, len(cfg.interceptors))
+	assert.Equal(t, 3, len(cfg.interceptors))
 }
 
 func TestWithResultWriterConfig(t *testing.T) {
@@ -143,6 +145,20 @@ func TestWithStatusChangeListenerConfig(t *testing.T) {
 	// Not possible in Go to compare functions.
 }
 
+func TestWithMultipleStatusChangeListenersConfig(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+	var calls []string
+
+	// Act
+	WithStatusListener(func(ctx context.Context, state CheckerState) { calls = append(calls, "first") })(&cfg)
+	WithStatusListener(func(ctx context.Context, state CheckerState) { calls = append(calls, "second") })(&cfg)
+	cfg.statusChangeListener(context.Background(), CheckerState{})
+
+	// Assert
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
 func TestNewWithDefaults(t *testing.T) {
 	// Arrange
 	configApplied := false
@@ -188,3 +204,28 @@ func TestCheckerAutostartDisabledConfig(t *testing.T) {
 	// Assert
 	assert.False(t, c.IsStarted())
 }
+
+func TestQuorumAggregator(t *testing.T) {
+	aggregator := QuorumAggregator(2)
+
+	assert.Equal(t, StatusUp, aggregator(map[string]CheckState{"a": {Status: StatusUp}, "b": {Status: StatusUp}}))
+	assert.Equal(t, StatusDegraded, aggregator(map[string]CheckState{"a": {Status: StatusDown}, "b": {Status: StatusUp}}))
+	assert.Equal(t, StatusUnknown, aggregator(map[string]CheckState{"a": {Status: StatusDown}, "b": {Status: StatusUnknown}}))
+	assert.Equal(t, StatusDown, aggregator(map[string]CheckState{"a": {Status: StatusDown}, "b": {Status: StatusDown}}))
+}
+
+func TestWithAggregatorReplacesDefaultAggregation(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithAggregator(QuorumAggregator(2)),
+		WithCheck(Check{Name: "a", Check: func(ctx context.Context) error { return fmt.Errorf("down") }}),
+		WithCheck(Check{Name: "b", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDegraded, res.Status)
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"net/http"
+	"strings"
+)
+
+type corsConfig struct {
+	allowedOrigins []string
+	allowedHeaders []string
+}
+
+// allowsOrigin returns true if the origin is allowed to access the handler (see WithCORS).
+func (c *corsConfig) allowsOrigin(origin string) bool {
+	for _, allowed := range c.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleCORS sets the CORS headers of the response if the request was sent from an allowed origin (see WithCORS).
+// It answers preflight requests and returns true if the request has been handled completely.
+func handleCORS(w http.ResponseWriter, r *http.Request, cors *corsConfig, methods []string) bool {
+	if cors == nil {
+		return false
+	}
+
+	origin := r.Header.Get("Origin")
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+	w.Header().Add("Vary", "Origin")
+
+	if origin == "" {
+		return false
+	}
+
+	if !cors.allowsOrigin(origin) {
+		if preflight {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return true
+		}
+		return false
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+
+	if !preflight {
+		return false
+	}
+
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if len(cors.allowedHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(cors.allowedHeaders, ", "))
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	return true
+}
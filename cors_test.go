@@ -0,0 +1,72 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerWithCORSAllowsConfiguredOrigins(t *testing.T) {
+	// Arrange
+	handler := NewHandler(NewChecker(WithDisabledAutostart()),
+		WithCORS([]string{"https://dashboard.example.com"}, []string{"Authorization"}))
+
+	allowed := httptest.NewRequest(http.MethodGet, "/health", nil)
+	allowed.Header.Set("Origin", "https://dashboard.example.com")
+	denied := httptest.NewRequest(http.MethodGet, "/health", nil)
+	denied.Header.Set("Origin", "https://evil.example.com")
+
+	// Act
+	allowedResp := httptest.NewRecorder()
+	handler.ServeHTTP(allowedResp, allowed)
+	deniedResp := httptest.NewRecorder()
+	handler.ServeHTTP(deniedResp, denied)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, allowedResp.Code)
+	assert.Equal(t, "https://dashboard.example.com", allowedResp.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", allowedResp.Header().Get("Vary"))
+	assert.Equal(t, http.StatusOK, deniedResp.Code)
+	assert.Empty(t, deniedResp.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestHandlerWithCORSAnswersPreflightRequests(t *testing.T) {
+	// Arrange
+	ckr := checkerMock{}
+	handler := NewHandler(&ckr, WithCORS([]string{"*"}, []string{"Authorization"}))
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/health", nil)
+	preflight.Header.Set("Origin", "https://dashboard.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodGet)
+
+	// Act
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, preflight)
+
+	// Assert
+	ckr.AssertNotCalled(t, "Check")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+}
+
+func TestHandlerWithCORSRejectsPreflightRequestsFromOtherOrigins(t *testing.T) {
+	// Arrange
+	handler := NewHandler(NewChecker(WithDisabledAutostart()),
+		WithCORS([]string{"https://dashboard.example.com"}, nil))
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/health", nil)
+	preflight.Header.Set("Origin", "https://evil.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodGet)
+
+	// Act
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, preflight)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,15 @@
+//go:build go1.19
+
+package health
+
+import "net/http"
+
+// writeEarlyHints sends a 103 (Early Hints) informational response with the configured Link headers
+// (see WithEarlyHints).
+func writeEarlyHints(w http.ResponseWriter, links []string) {
+	for _, link := range links {
+		w.Header().Add("Link", link)
+	}
+	w.WriteHeader(http.StatusEarlyHints)
+	w.Header().Del("Link")
+}
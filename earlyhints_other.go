@@ -0,0 +1,10 @@
+//go:build !go1.19
+
+package health
+
+import "net/http"
+
+// writeEarlyHints does nothing, since net/http only supports sending informational responses
+// since Go 1.19. Before, writing a 1xx status code would have been treated as the final status code
+// of the response (see WithEarlyHints).
+func writeEarlyHints(w http.ResponseWriter, links []string) {}
@@ -0,0 +1,53 @@
+//go:build go1.19
+
+package health
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEarlyHintsAreSentBeforeChecksAreExecuted(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	server := httptest.NewServer(NewHandler(ckr, WithEarlyHints("</status.css>; rel=preload; as=style")))
+	defer server.Close()
+
+	var (
+		informationalCode int
+		links             []string
+	)
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			informationalCode = code
+			links = header["Link"]
+			return nil
+		},
+	}
+	req, _ := http.NewRequestWithContext(httptrace.WithClientTrace(context.Background(), trace),
+		http.MethodGet, server.URL, nil)
+
+	// Act
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	//nolint:errcheck
+	io.Copy(io.Discard, resp.Body)
+
+	// Assert
+	assert.Equal(t, http.StatusEarlyHints, informationalCode)
+	assert.Equal(t, []string{"</status.css>; rel=preload; as=style"}, links)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Values("Link"))
+}
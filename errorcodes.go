@@ -0,0 +1,78 @@
+package health
+
+import "errors"
+
+type (
+	// Coder is implemented by errors that carry a machine-readable error code (e.g., "AUTH" or "CONN_REFUSED").
+	// If a check function returns an error that implements Coder (or wraps one, see errors.As), the code is
+	// reported in the check results (see CheckResult.Code). This allows dashboards and alerting rules to group
+	// failures by their class instead of matching error messages. Use NewCodedError to attach a code to an
+	// existing error.
+	Coder interface {
+		// Code returns the error code.
+		Code() string
+	}
+
+	codedError struct {
+		code string
+		err  error
+	}
+)
+
+const (
+	// ErrorCodeTimeout is the error code of checks that did not complete before their timeout (see CheckTimeoutErr).
+	ErrorCodeTimeout = "TIMEOUT"
+	// ErrorCodePanic is the error code of checks whose check function panicked (see PanicError).
+	ErrorCodePanic = "PANIC"
+	// ErrorCodeDependencyUnavailable is the error code of checks that were skipped, because
+	// one of their dependencies is not available (see DependencyUnavailableErr).
+	ErrorCodeDependencyUnavailable = "DEPENDENCY_UNAVAILABLE"
+	// ErrorCodeBudgetExhausted is the error code of checks that were not executed, because the deadline of the
+	// check run was exceeded (see BudgetExhaustedErr).
+	ErrorCodeBudgetExhausted = "BUDGET_EXHAUSTED"
+)
+
+// NewCodedError returns an error that wraps err and implements Coder with the given code.
+func NewCodedError(code string, err error) error {
+	return &codedError{code: code, err: err}
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+func (e *codedError) Code() string {
+	return e.code
+}
+
+// errorCode returns the error code of err (see Coder). Codes of errors returned by check functions take
+// precedence over the codes of errors that are reported by the Checker itself (e.g., ErrorCodeTimeout).
+// It returns an empty string if err is nil or no code is known for it.
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.Code()
+	}
+
+	var panicErr *PanicError
+	switch {
+	case errors.Is(err, CheckTimeoutErr):
+		return ErrorCodeTimeout
+	case errors.As(err, &panicErr):
+		return ErrorCodePanic
+	case errors.Is(err, DependencyUnavailableErr):
+		return ErrorCodeDependencyUnavailable
+	case errors.Is(err, BudgetExhaustedErr):
+		return ErrorCodeBudgetExhausted
+	}
+
+	return ""
+}
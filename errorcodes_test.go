@@ -0,0 +1,68 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResultsContainErrorCodes(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "auth", Check: func(ctx context.Context) error {
+			return fmt.Errorf("login failed: %w", NewCodedError("AUTH", errors.New("invalid credentials")))
+		}}),
+		WithCheck(Check{Name: "slow", Timeout: 10 * time.Millisecond, Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}),
+		WithCheck(Check{Name: "panicking", Check: func(ctx context.Context) error {
+			panic("boom")
+		}}),
+		WithCheck(Check{Name: "plain", Check: func(ctx context.Context) error {
+			return errors.New("something failed")
+		}}),
+		WithCheck(Check{Name: "ok", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, "AUTH", result.Details["auth"].Code)
+	assert.Equal(t, ErrorCodeTimeout, result.Details["slow"].Code)
+	assert.Equal(t, ErrorCodePanic, result.Details["panicking"].Code)
+	assert.Empty(t, result.Details["plain"].Code)
+	assert.Empty(t, result.Details["ok"].Code)
+}
+
+func TestCheckResultErrorCodeJSON(t *testing.T) {
+	// Arrange
+	result := CheckResult{Status: StatusDown, Error: errors.New("connection refused"), Code: "CONN_REFUSED"}
+
+	// Act
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+
+	var unmarshalled CheckResult
+	require.NoError(t, json.Unmarshal(data, &unmarshalled))
+
+	// Assert
+	assert.Contains(t, string(data), `"code":"CONN_REFUSED"`)
+	assert.Equal(t, "CONN_REFUSED", unmarshalled.Code)
+}
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, "", errorCode(nil))
+	assert.Equal(t, ErrorCodeDependencyUnavailable, errorCode(dependencyUnavailableErr("db", CheckState{Status: StatusDown})))
+	assert.Equal(t, ErrorCodeBudgetExhausted, errorCode(BudgetExhaustedErr))
+	assert.Equal(t, "CONN_REFUSED", errorCode(NewCodedError("CONN_REFUSED", CheckTimeoutErr)))
+	assert.Equal(t, "AUTH", errorCode(&sanitizedError{message: "[REDACTED]", err: NewCodedError("AUTH", errors.New("x"))}))
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// writeETag sets the ETag header of the response (see WithETag). If the request contains a matching
+// "If-None-Match" header, it writes a 304 (Not Modified) response and returns true. The ETag depends on the
+// media type and the language of the response, so the response varies with the "Accept" and "Accept-Language"
+// headers of the request.
+func writeETag(w http.ResponseWriter, r *http.Request, result *CheckerResult, statusCode int, mediaType, language string) bool {
+	etag := resultETag(result, mediaType, language)
+	w.Header().Set("ETag", etag)
+	addVary(w.Header(), "Accept", "Accept-Language")
+
+	if statusCode < 200 || statusCode > 299 || !matchesETag(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// resultETag derives a weak ETag from the result and the media type and language of its representation
+// (see WithResultWriters and WithJSONMessageCatalog). It is weak, because only the statuses, timestamps and
+// errors of the checks are hashed, so responses with the same ETag are semantically equivalent, but not
+// necessarily byte-for-byte identical.
+func resultETag(result *CheckerResult, mediaType, language string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s\n", mediaType, language, result.Status)
+
+	for _, name := range sortedKeys(result.Details) {
+		checkResult := result.Details[name]
+		fmt.Fprintf(hash, "%s\n%s\n%d\n%s\n", name, checkResult.Status, checkResult.Timestamp.UnixNano(),
+			errorMessage(checkResult.Error))
+	}
+
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// matchesETag reports whether the value of an "If-None-Match" header matches the ETag (using weak comparison).
+func matchesETag(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addVary adds the header fields to the "Vary" header of the response, unless they are listed already.
+func addVary(header http.Header, fields ...string) {
+	listed := map[string]bool{}
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			listed[strings.ToLower(strings.TrimSpace(field))] = true
+		}
+	}
+
+	for _, field := range fields {
+		if !listed[strings.ToLower(field)] {
+			header.Add("Vary", field)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerETag(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewHandler(ckr, WithETag())
+
+	// Act
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	conditionalReq := httptest.NewRequest(http.MethodGet, "/health", nil)
+	conditionalReq.Header.Set("If-None-Match", first.Header().Get("ETag"))
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, conditionalReq)
+
+	staleReq := httptest.NewRequest(http.MethodGet, "/health", nil)
+	staleReq.Header.Set("If-None-Match", `W/"outdated"`)
+	third := httptest.NewRecorder()
+	handler.ServeHTTP(third, staleReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.NotEmpty(t, first.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Empty(t, second.Body.String())
+	assert.Equal(t, http.StatusOK, third.Code)
+	assert.NotEmpty(t, third.Body.String())
+}
+
+func TestHandlerETagIsNotMatchedForUnavailableSystem(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return context.Canceled }}),
+	)
+	handler := NewHandler(ckr, WithETag())
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/health", nil))
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+
+	// Act
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestHandlerETagDependsOnRepresentation(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewHandler(ckr,
+		WithETag(),
+		WithResultWriter(NewJSONResultWriter(WithJSONMessageCatalog(MessageCatalog{
+			"de": {Statuses: map[AvailabilityStatus]string{StatusUp: "verfügbar"}},
+			"es": {Statuses: map[AvailabilityStatus]string{StatusUp: "saludable"}},
+		}, "de"))),
+		WithResultWriters(map[string]ResultWriter{"application/xml": NewXMLResultWriter()}),
+	)
+
+	serve := func(accept, acceptLanguage, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("Accept", accept)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	german := serve("*/*", "de", "")
+	etag := german.Header().Get("ETag")
+	spanish := serve("*/*", "es", etag)
+	xml := serve("application/xml", "de", etag)
+	cached := serve("*/*", "de-CH, de;q=0.8", etag)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, spanish.Code)
+	assert.NotEqual(t, etag, spanish.Header().Get("ETag"))
+	assert.Equal(t, http.StatusOK, xml.Code)
+	assert.NotEqual(t, etag, xml.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, cached.Code)
+	assert.Equal(t, []string{"Accept", "Accept-Language"}, cached.Header().Values("Vary"))
+}
+
+func TestAddVaryOmitsListedFields(t *testing.T) {
+	// Arrange
+	header := http.Header{}
+	header.Add("Vary", "accept-encoding, Accept")
+
+	// Act
+	addVary(header, "Accept", "Accept-Language")
+
+	// Assert
+	assert.Equal(t, []string{"accept-encoding, Accept", "Accept-Language"}, header.Values("Vary"))
+}
+
+func TestResultETagChangesWithResult(t *testing.T) {
+	// Arrange
+	result := testCheckerResult()
+	etag := resultETag(result, "", "")
+
+	// Act
+	result.Details["search"] = CheckResult{Status: StatusDown}
+
+	// Assert
+	assert.NotEqual(t, etag, resultETag(result, "", ""))
+	assert.True(t, matchesETag(`"abc", `+etag, etag))
+	assert.False(t, matchesETag("", etag))
+}
@@ -0,0 +1,184 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type (
+	// EventStreamOption is a configuration option for an event stream handler (see NewEventStreamHandler).
+	EventStreamOption func(cfg *eventStreamConfig)
+
+	eventStreamConfig struct {
+		heartbeat time.Duration
+		queueSize int
+	}
+
+	eventStreamSnapshot struct {
+		Status     AvailabilityStatus                  `json:"status"`
+		Timestamp  time.Time                           `json:"timestamp"`
+		Components map[string]eventStreamComponentInfo `json:"components,omitempty"`
+	}
+
+	eventStreamComponentInfo struct {
+		Status AvailabilityStatus `json:"status"`
+		Error  string             `json:"error,omitempty"`
+	}
+
+	eventStreamStatusChange struct {
+		Status         AvailabilityStatus `json:"status"`
+		PreviousStatus AvailabilityStatus `json:"previousStatus"`
+		Timestamp      time.Time          `json:"timestamp"`
+	}
+
+	eventStreamComponentChange struct {
+		Name           string             `json:"name"`
+		Status         AvailabilityStatus `json:"status"`
+		PreviousStatus AvailabilityStatus `json:"previousStatus"`
+		Error          string             `json:"error,omitempty"`
+		Timestamp      time.Time          `json:"timestamp"`
+	}
+)
+
+// WithEventStreamHeartbeat sets the interval in which an event stream handler sends a comment line to keep
+// the connection open (e.g., when proxies close idle connections). Default value is 30 seconds.
+// A value of 0 disables heartbeats.
+func WithEventStreamHeartbeat(interval time.Duration) EventStreamOption {
+	return func(cfg *eventStreamConfig) {
+		cfg.heartbeat = interval
+	}
+}
+
+// WithEventStreamQueueSize sets how many checker states can be buffered per client while events are being
+// written (see Checker.Subscribe). If the buffer is full, states are dropped. Default value is 10.
+func WithEventStreamQueueSize(queueSize int) EventStreamOption {
+	return func(cfg *eventStreamConfig) {
+		cfg.queueSize = queueSize
+	}
+}
+
+// NewEventStreamHandler creates a new http.Handler that streams status changes as Server-Sent Events
+// (https://html.spec.whatwg.org/multipage/server-sent-events.html), so that clients such as dashboards
+// can subscribe to status changes instead of polling. The handler sends the following events, each with
+// a JSON payload:
+//   - "snapshot": sent once after the connection was established. Contains the aggregated status
+//     and the status of each component, e.g.: {"status":"up","components":{"database":{"status":"up"}}}.
+//   - "component": sent whenever the status of a component changes,
+//     e.g.: {"name":"database","status":"down","previousStatus":"up","error":"connection refused"}.
+//   - "status": sent whenever the aggregated status changes, e.g.: {"status":"down","previousStatus":"up"}.
+//
+// Events are only sent when checks are executed (e.g., periodically; see WithPeriodicCheck).
+// The handler does not execute any checks itself.
+func NewEventStreamHandler(checker Checker, options ...EventStreamOption) http.HandlerFunc {
+	cfg := eventStreamConfig{heartbeat: 30 * time.Second, queueSize: 10}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		// Disable response buffering of reverse proxies, such as nginx.
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		var heartbeat <-chan time.Time
+		if cfg.heartbeat > 0 {
+			ticker := time.NewTicker(cfg.heartbeat)
+			defer ticker.Stop()
+			heartbeat = ticker.C
+		}
+
+		states := checker.Subscribe(r.Context(), cfg.queueSize)
+		detector := newStatusChangeDetector()
+		isFirst := true
+
+		for {
+			select {
+			case state, ok := <-states:
+				if !ok {
+					return
+				}
+
+				if err := writeStateEvents(w, detector, state, isFirst); err != nil {
+					return
+				}
+				isFirst = false
+			case <-heartbeat:
+				if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+// writeStateEvents writes the events for the state. The first state is written as a snapshot,
+// all following states are written as status change events.
+func writeStateEvents(w io.Writer, detector *statusChangeDetector, state CheckerState, isFirst bool) error {
+	changes, changed := detector.detectChanges(state)
+
+	if isFirst {
+		snapshot := eventStreamSnapshot{Status: state.Status, Timestamp: changes.Timestamp}
+		if len(state.CheckState) > 0 {
+			snapshot.Components = make(map[string]eventStreamComponentInfo, len(state.CheckState))
+			for name, checkState := range state.CheckState {
+				snapshot.Components[name] = eventStreamComponentInfo{
+					Status: checkState.Status,
+					Error:  errorMessage(checkState.Result),
+				}
+			}
+		}
+		return writeEvent(w, "snapshot", snapshot)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	for _, name := range sortedKeys(changes.Components) {
+		component := changes.Components[name]
+		if err := writeEvent(w, "component", eventStreamComponentChange{
+			Name:           name,
+			Status:         component.Status,
+			PreviousStatus: component.PreviousStatus,
+			Error:          component.Error,
+			Timestamp:      changes.Timestamp,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if changes.Status != changes.PreviousStatus {
+		return writeEvent(w, "status", eventStreamStatusChange{
+			Status:         changes.Status,
+			PreviousStatus: changes.PreviousStatus,
+			Timestamp:      changes.Timestamp,
+		})
+	}
+
+	return nil
+}
+
+func writeEvent(w io.Writer, event string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("cannot marshal event: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	var event, data string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestEventStreamHandlerStreamsStatusChanges(t *testing.T) {
+	// Arrange
+	var failing int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			if atomic.LoadInt32(&failing) == 1 {
+				return errors.New("connection refused")
+			}
+			return nil
+		}}),
+	)
+	ckr.Check(context.Background())
+
+	server := httptest.NewServer(NewEventStreamHandler(ckr, WithEventStreamHeartbeat(0)))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	// Act
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+
+	snapshotEvent, snapshotData := readEvent(t, reader)
+	atomic.StoreInt32(&failing, 1)
+	ckr.Check(context.Background())
+	componentEvent, componentData := readEvent(t, reader)
+	statusEvent, statusData := readEvent(t, reader)
+
+	// Assert
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "snapshot", snapshotEvent)
+	assert.Contains(t, snapshotData, `"status":"up","timestamp"`)
+	assert.Contains(t, snapshotData, `"components":{"database":{"status":"up"}}`)
+	assert.Equal(t, "component", componentEvent)
+	assert.Contains(t, componentData, `"name":"database","status":"down","previousStatus":"up","error":"connection refused"`)
+	assert.Equal(t, "status", statusEvent)
+	assert.Contains(t, statusData, `"status":"down","previousStatus":"up"`)
+}
+
+func TestSubscriptionIsClosedWhenContextIsDone(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	states := ckr.Subscribe(ctx, 1)
+
+	// Act
+	initial := <-states
+	cancel()
+
+	// Assert
+	assert.Equal(t, StatusUnknown, initial.Status)
+	assert.Eventually(t, func() bool {
+		_, ok := <-states
+		return !ok
+	}, 1*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// FederationOption is a configuration option for a federated check (see NewFederatedCheck).
+	FederationOption func(cfg *federationConfig)
+
+	federationConfig struct {
+		client  *http.Client
+		headers http.Header
+	}
+
+	// federation holds the components that were reported by the remote service of a federated check
+	// (see NewFederatedCheck). It is shared by all copies of the check.
+	federation struct {
+		mtx        sync.RWMutex
+		url        string
+		cfg        federationConfig
+		components map[string]CheckResult
+	}
+)
+
+// FederatedComponentSeparator separates the name of a federated check from the names of the components
+// of the remote service in the check results (e.g., "orders/database"; see NewFederatedCheck).
+const FederatedComponentSeparator = "/"
+
+// NewFederatedCheck creates a check that fetches the health result of another service from the provided URL
+// (e.g., "http://orders:8080/health"), which must respond with the JSON format of this package (see
+// JSONResultWriter). The status of the check is derived from the aggregated status of the remote service:
+// StatusUp is reported as StatusUp, StatusDegraded, StatusMaintenance and StatusStarting as StatusDegraded,
+// and all other statuses as well as unreachable services as StatusDown. All components of the remote service
+// are merged into the check results of this Checker under the name of the check, followed by
+// FederatedComponentSeparator and the component name (e.g., "orders/database"). This allows a gateway service
+// to present a consolidated view of the health of a whole system. The returned Check can be customized
+// (e.g., by setting Check.Tags) and added using WithCheck or WithPeriodicCheck.
+func NewFederatedCheck(name, url string, options ...FederationOption) Check {
+	cfg := federationConfig{
+		client:  &http.Client{Timeout: 10 * time.Second},
+		headers: http.Header{},
+	}
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	f := &federation{url: url, cfg: cfg}
+
+	return Check{
+		Name:       name,
+		Check:      f.check,
+		federation: f,
+	}
+}
+
+// WithFederationClient sets the http.Client that is used to fetch the health result of the remote service.
+// By default, a client with a timeout of 10 seconds is used.
+func WithFederationClient(client *http.Client) FederationOption {
+	return func(cfg *federationConfig) {
+		cfg.client = client
+	}
+}
+
+// WithFederationHeader adds a custom HTTP header that is sent with every request to the remote service
+// (e.g., credentials to see the component details; see WithDetailsAuthorizer).
+// This option can be used multiple times.
+func WithFederationHeader(key, value string) FederationOption {
+	return func(cfg *federationConfig) {
+		cfg.headers.Add(key, value)
+	}
+}
+
+// check fetches the health result of the remote service and stores its components.
+func (f *federation) check(ctx context.Context) error {
+	result, err := f.fetch(ctx)
+
+	f.mtx.Lock()
+	f.components = result.Details
+	f.mtx.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	switch result.Status {
+	case StatusUp:
+		return nil
+	case StatusDegraded, StatusMaintenance, StatusStarting:
+		return NewDegradedError(fmt.Errorf("service at %s is %s", f.url, result.Status))
+	default:
+		return fmt.Errorf("service at %s is %s", f.url, result.Status)
+	}
+}
+
+func (f *federation) fetch(ctx context.Context) (CheckerResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return CheckerResult{}, fmt.Errorf("cannot create request: %w", err)
+	}
+
+	for key, values := range f.cfg.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.cfg.client.Do(req)
+	if err != nil {
+		return CheckerResult{}, fmt.Errorf("request to %s failed: %w", f.url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return CheckerResult{}, fmt.Errorf("cannot read response from %s: %w", f.url, err)
+	}
+
+	// Unavailable services respond with an error status code, but the body still contains the result.
+	var result CheckerResult
+	if err := json.Unmarshal(body, &result); err != nil || result.Status == "" {
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			return CheckerResult{}, fmt.Errorf("unexpected status code from %s: %d", f.url, resp.StatusCode)
+		}
+		return CheckerResult{}, fmt.Errorf("cannot decode response from %s", f.url)
+	}
+
+	return result, nil
+}
+
+// mergeInto adds the components of the remote service to the results under namespaced keys.
+func (f *federation) mergeInto(name string, results map[string]CheckResult) {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+
+	for component, result := range f.components {
+		results[name+FederatedComponentSeparator+component] = result
+	}
+}
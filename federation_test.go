@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFederatedCheckMergesRemoteComponents(t *testing.T) {
+	// Arrange
+	remote := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+	)
+	srv := httptest.NewServer(NewHandler(remote))
+	defer srv.Close()
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(NewFederatedCheck("orders", srv.URL)),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDown, result.Status)
+	assert.Equal(t, StatusDown, result.Details["orders"].Status)
+	assert.Equal(t, StatusUp, result.Details["orders/database"].Status)
+	assert.Equal(t, StatusDown, result.Details["orders/search"].Status)
+	assert.EqualError(t, result.Details["orders/search"].Error, "connection refused")
+}
+
+func TestFederatedCheckReportsDegradedRemoteService(t *testing.T) {
+	// Arrange
+	remote := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return NewDegradedError(errors.New("replication lag"))
+		}}),
+	)
+	srv := httptest.NewServer(NewHandler(remote))
+	defer srv.Close()
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(NewFederatedCheck("orders", srv.URL)),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDegraded, result.Details["orders"].Status)
+	assert.Equal(t, StatusDegraded, result.Details["orders/database"].Status)
+}
+
+func TestFederatedCheckFailsForUnexpectedResponse(t *testing.T) {
+	// Arrange
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(NewFederatedCheck("orders", srv.URL, WithFederationHeader("X-Api-Key", "secret"))),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDown, result.Details["orders"].Status)
+	assert.Contains(t, result.Details["orders"].Error.Error(), "unexpected status code")
+	assert.Len(t, result.Details, 1)
+}
@@ -0,0 +1,44 @@
+package health
+
+import "time"
+
+type flapDetectionConfig struct {
+	window    time.Duration
+	threshold uint
+}
+
+// detectFlapping records status changes of a check and reports the check as flapping (see CheckState.Flapping)
+// if its status changed at least threshold times within the configured window (see WithFlapDetection).
+// While a check is flapping, its status is reported as StatusDegraded. newState.Status is expected to
+// hold the status that was evaluated from the current check result.
+func (cfg *flapDetectionConfig) detectFlapping(now time.Time, oldState, newState CheckState) CheckState {
+	var (
+		previousStatus = oldState.evaluatedStatus
+		statusChanges  = make([]time.Time, 0, len(oldState.statusChanges)+1)
+	)
+
+	if previousStatus == "" {
+		previousStatus = oldState.Status
+	}
+
+	// We always create a new slice, because the old state may still be referenced elsewhere (e.g., by a Publisher).
+	for _, changedAt := range oldState.statusChanges {
+		if now.Sub(changedAt) < cfg.window {
+			statusChanges = append(statusChanges, changedAt)
+		}
+	}
+
+	if previousStatus != StatusUnknown && previousStatus != newState.Status {
+		statusChanges = append(statusChanges, now)
+	}
+
+	newState.evaluatedStatus = newState.Status
+	newState.statusChanges = statusChanges
+	newState.Flapping = uint(len(statusChanges)) >= cfg.threshold
+
+	if newState.Flapping {
+		newState.Status = StatusDegraded
+	}
+
+	return newState
+}
@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlappingCheckIsReportedAsDegraded(t *testing.T) {
+	// Arrange
+	var (
+		calls          int
+		statusChanges  []AvailabilityStatus
+		checkerResults []AvailabilityStatus
+	)
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithFlapDetection(1*time.Hour, 2),
+		WithStatusListener(func(ctx context.Context, state CheckerState) {
+			statusChanges = append(statusChanges, state.Status)
+		}),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				calls++
+				if calls%2 == 0 {
+					return fmt.Errorf("failed")
+				}
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	for i := 0; i < 5; i++ {
+		checkerResults = append(checkerResults, ckr.Check(context.Background()).Status)
+	}
+	state, _ := ckr.GetCheckState("database")
+
+	// Assert
+	assert.Equal(t, []AvailabilityStatus{StatusUp, StatusDown, StatusDegraded, StatusDegraded, StatusDegraded}, checkerResults)
+	assert.Equal(t, []AvailabilityStatus{StatusUp, StatusDown, StatusDegraded}, statusChanges)
+	assert.True(t, state.Flapping)
+}
+
+func TestFlappingCheckStabilizesAfterWindow(t *testing.T) {
+	// Arrange
+	cfg := flapDetectionConfig{window: 1 * time.Hour, threshold: 2}
+	state := CheckState{
+		Status:          StatusDegraded,
+		Flapping:        true,
+		evaluatedStatus: StatusUp,
+		statusChanges:   []time.Time{time.Now().Add(-2 * time.Hour), time.Now().Add(-90 * time.Minute)},
+	}
+
+	// Act
+	newState := cfg.detectFlapping(time.Now(), state, CheckState{Status: StatusUp})
+
+	// Assert
+	assert.False(t, newState.Flapping)
+	assert.Equal(t, StatusUp, newState.Status)
+	assert.Empty(t, newState.statusChanges)
+}
+
+func TestFlapDetectionIsDisabledWithZeroThreshold(t *testing.T) {
+	// Arrange
+	cfg := checkerConfig{}
+
+	// Act
+	WithFlapDetection(1*time.Minute, 0)(&cfg)
+
+	// Assert
+	assert.Nil(t, cfg.flapDetection)
+}
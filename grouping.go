@@ -0,0 +1,87 @@
+package health
+
+import "strings"
+
+// componentGroup is a node in the tree of components that is created from
+// hierarchical check names (see WithGroupedComponents).
+type componentGroup struct {
+	status     AvailabilityStatus
+	fields     map[string]interface{}
+	components map[string]*componentGroup
+}
+
+// WithGroupedComponents groups components with hierarchical names (e.g., "storage/postgres/primary" with
+// separator "/") into nested JSON objects in the output of a JSONResultWriter. Each group is written as an
+// object with a "status" field that holds the most critical status of all components in the group, and a
+// "components" field that holds the components and subgroups of the group by their name relative to the group
+// (e.g., "primary" in group "postgres" in group "storage"). Components whose name does not contain the separator
+// are written as before. If a check has the same name as a group, its fields are written into the group object.
+// Both field names can be renamed using WithJSONFieldNames. By default, components are not grouped.
+func WithGroupedComponents(separator string) JSONResultWriterOption {
+	return func(rw *JSONResultWriter) {
+		rw.groupSeparator = separator
+	}
+}
+
+// groupComponents arranges the generic JSON representation of the components in a tree of groups
+// according to their names (see WithGroupedComponents). The statuses of the groups are derived
+// from the component statuses of the result.
+func (rw *JSONResultWriter) groupComponents(result *CheckerResult, details map[string]interface{}) map[string]interface{} {
+	root := &componentGroup{components: map[string]*componentGroup{}}
+
+	for name, checkResult := range result.Details {
+		group := root
+		for _, segment := range strings.Split(name, rw.groupSeparator) {
+			child, ok := group.components[segment]
+			if !ok {
+				child = &componentGroup{components: map[string]*componentGroup{}}
+				group.components[segment] = child
+			}
+
+			if child.status == "" || checkResult.Status.criticality() > child.status.criticality() {
+				child.status = checkResult.Status
+			}
+
+			group = child
+		}
+
+		group.fields, _ = details[name].(map[string]interface{})
+	}
+
+	return rw.renderComponents(root)
+}
+
+func (rw *JSONResultWriter) renderComponents(group *componentGroup) map[string]interface{} {
+	rendered := make(map[string]interface{}, len(group.components))
+
+	for name, child := range group.components {
+		if len(child.components) == 0 {
+			rendered[name] = child.fields
+			continue
+		}
+
+		fields := make(map[string]interface{}, len(child.fields)+2)
+		for key, value := range child.fields {
+			fields[key] = value
+		}
+
+		status := string(child.status)
+		if rw.statusMapper != nil {
+			status = rw.statusMapper(child.status)
+		}
+
+		fields[rw.fieldName("status")] = status
+		fields[rw.fieldName("components")] = rw.renderComponents(child)
+		rendered[name] = fields
+	}
+
+	return rendered
+}
+
+// fieldName returns the name of a field in the JSON output (see WithJSONFieldNames).
+func (rw *JSONResultWriter) fieldName(name string) string {
+	if newName, ok := rw.fieldNames[name]; ok {
+		return newName
+	}
+	return name
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONResultWriterWithGroupedComponents(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	result := &CheckerResult{
+		Status: StatusDown,
+		Details: map[string]CheckResult{
+			"storage":                  {Status: StatusUp},
+			"storage/postgres/primary": {Status: StatusDown},
+			"storage/postgres/replica": {Status: StatusDegraded},
+			"storage/s3":               {Status: StatusUp},
+			"search":                   {Status: StatusUp},
+		},
+	}
+
+	// Act
+	err := NewJSONResultWriter(WithGroupedComponents("/")).
+		Write(result, http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": "down",
+		"details": {
+			"search": {"status": "up", "timestamp": "0001-01-01T00:00:00Z"},
+			"storage": {
+				"status": "down",
+				"timestamp": "0001-01-01T00:00:00Z",
+				"components": {
+					"postgres": {
+						"status": "down",
+						"components": {
+							"primary": {"status": "down", "timestamp": "0001-01-01T00:00:00Z"},
+							"replica": {"status": "degraded", "timestamp": "0001-01-01T00:00:00Z"}
+						}
+					},
+					"s3": {"status": "up", "timestamp": "0001-01-01T00:00:00Z"}
+				}
+			}
+		}
+	}`, w.Body.String())
+}
+
+func TestJSONResultWriterWithGroupedComponentsAndFieldNames(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	result := &CheckerResult{
+		Status: StatusUp,
+		Details: map[string]CheckResult{
+			"storage.postgres": {Status: StatusUp},
+		},
+	}
+	writer := NewJSONResultWriter(
+		WithGroupedComponents("."),
+		WithJSONFieldNames(map[string]string{"status": "state", "components": "children"}),
+	)
+
+	// Act
+	err := writer.Write(result, http.StatusOK, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"state": "up",
+		"details": {
+			"storage": {
+				"state": "up",
+				"children": {
+					"postgres": {"state": "up", "timestamp": "0001-01-01T00:00:00Z"}
+				}
+			}
+		}
+	}`, w.Body.String())
+}
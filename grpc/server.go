@@ -0,0 +1,198 @@
+// Package grpc provides an implementation of the gRPC Health Checking Protocol (grpc.health.v1)
+// that is backed by a health.Checker. This allows Kubernetes gRPC probes and service meshes
+// to consume the same checks that are used by the HTTP handler (see health.NewHandler).
+package grpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/alexliesenfeld/health"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the grpc.health.v1.Health service. The empty service name ("") refers to the
+// aggregated system status, all other service names refer to the check with the same name.
+//
+// The Watch RPC is driven by status listeners. Register the listeners of the Server with the
+// health.Checker to receive status updates:
+//
+//	server := grpc.NewServer()
+//	checker := health.NewChecker(
+//		health.WithStatusListener(server.StatusListener()),
+//		health.WithCheck(health.Check{
+//			Name:           "database",
+//			Check:          db.PingContext,
+//			StatusListener: server.CheckStatusListener(),
+//		}),
+//	)
+//	server.SetChecker(checker)
+//	healthpb.RegisterHealthServer(grpcServer, server)
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	mtx      sync.Mutex
+	checker  health.Checker
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+	watchers map[string]map[chan healthpb.HealthCheckResponse_ServingStatus]struct{}
+}
+
+// NewServer creates a new Server. A health.Checker must be set using Server.SetChecker
+// before the Server can answer requests.
+func NewServer() *Server {
+	return &Server{
+		statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{},
+		watchers: map[string]map[chan healthpb.HealthCheckResponse_ServingStatus]struct{}{},
+	}
+}
+
+// SetChecker sets the health.Checker that is used to answer requests.
+func (s *Server) SetChecker(checker health.Checker) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.checker = checker
+}
+
+// StatusListener returns a listener that forwards changes of the aggregated system status to
+// all watchers. Use it with health.WithStatusListener.
+func (s *Server) StatusListener() func(ctx context.Context, state health.CheckerState) {
+	return func(_ context.Context, state health.CheckerState) {
+		s.update("", servingStatusOf(state.Status))
+		for name, checkState := range state.CheckState {
+			s.update(name, servingStatusOf(checkState.Status))
+		}
+	}
+}
+
+// CheckStatusListener returns a listener that forwards status changes of a check to all watchers
+// of that check. Use it with health.Check.StatusListener.
+func (s *Server) CheckStatusListener() func(ctx context.Context, name string, state health.CheckState) {
+	return func(_ context.Context, name string, state health.CheckState) {
+		s.update(name, servingStatusOf(state.Status))
+	}
+}
+
+// Check implements grpc_health_v1.HealthServer.Check. It executes all synchronous checks
+// that are required to answer the request (see health.Checker.Check).
+func (s *Server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	checker := s.getChecker()
+	if checker == nil {
+		return nil, status.Error(codes.Unavailable, "health checker not available")
+	}
+
+	if req.Service == "" {
+		result := checker.Check(ctx)
+		return &healthpb.HealthCheckResponse{Status: servingStatusOf(result.Status)}, nil
+	}
+
+	if _, ok := checker.GetCheckState(req.Service); !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+
+	result := checker.CheckWithFilter(ctx, func(check health.Check) bool {
+		return check.Name == req.Service
+	})
+
+	return &healthpb.HealthCheckResponse{Status: servingStatusOf(result.Status)}, nil
+}
+
+// Watch implements grpc_health_v1.HealthServer.Watch. It sends the current status immediately and
+// then sends a new message whenever the status changes.
+func (s *Server) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	updates := make(chan healthpb.HealthCheckResponse_ServingStatus, 1)
+
+	s.mtx.Lock()
+	if s.watchers[req.Service] == nil {
+		s.watchers[req.Service] = map[chan healthpb.HealthCheckResponse_ServingStatus]struct{}{}
+	}
+	s.watchers[req.Service][updates] = struct{}{}
+	s.mtx.Unlock()
+
+	defer func() {
+		s.mtx.Lock()
+		delete(s.watchers[req.Service], updates)
+		s.mtx.Unlock()
+	}()
+
+	lastSentStatus := healthpb.HealthCheckResponse_ServingStatus(-1)
+	currentStatus := s.currentStatus(stream.Context(), req.Service)
+
+	for {
+		if currentStatus != lastSentStatus {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: currentStatus}); err != nil {
+				return status.Error(codes.Canceled, "stream has ended")
+			}
+			lastSentStatus = currentStatus
+		}
+
+		select {
+		case currentStatus = <-updates:
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		}
+	}
+}
+
+func (s *Server) getChecker() health.Checker {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.checker
+}
+
+func (s *Server) currentStatus(ctx context.Context, service string) healthpb.HealthCheckResponse_ServingStatus {
+	s.mtx.Lock()
+	servingStatus, ok := s.statuses[service]
+	checker := s.checker
+	s.mtx.Unlock()
+
+	// The lock must not be held while the checker is being called, since
+	// the checker may call the status listeners of this Server.
+	switch {
+	case ok:
+		return servingStatus
+	case checker == nil:
+		return healthpb.HealthCheckResponse_UNKNOWN
+	case service == "":
+		return servingStatusOf(checker.Check(ctx).Status)
+	}
+
+	state, ok := checker.GetCheckState(service)
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	return servingStatusOf(state.Status)
+}
+
+func (s *Server) update(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.statuses[service] == servingStatus {
+		return
+	}
+	s.statuses[service] = servingStatus
+
+	for watcher := range s.watchers[service] {
+		// Only the latest status is relevant for a watcher, so an outdated update that
+		// was not yet consumed is replaced by the new one.
+		select {
+		case <-watcher:
+		default:
+		}
+		watcher <- servingStatus
+	}
+}
+
+func servingStatusOf(availabilityStatus health.AvailabilityStatus) healthpb.HealthCheckResponse_ServingStatus {
+	switch availabilityStatus {
+	case health.StatusUp, health.StatusDegraded, health.StatusStarting:
+		return healthpb.HealthCheckResponse_SERVING
+	case health.StatusDown, health.StatusSkipped, health.StatusMaintenance:
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	default:
+		return healthpb.HealthCheckResponse_UNKNOWN
+	}
+}
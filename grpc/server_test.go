@@ -0,0 +1,159 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves the Server using an in-memory connection and returns a client for it.
+func startServer(t *testing.T, server *Server) healthpb.HealthClient {
+	listener := bufconn.Listen(1 << 20)
+
+	grpcServer := ggrpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, server)
+	go grpcServer.Serve(listener) //nolint:errcheck
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := ggrpc.DialContext(context.Background(), "bufnet",
+		ggrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		ggrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// newTestChecker creates a Checker with a check "database" that fails while failing is set.
+func newTestChecker(server *Server, failing *atomic.Bool) health.Checker {
+	return health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithStatusListener(server.StatusListener()),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if failing.Load() {
+					return errors.New("connection refused")
+				}
+				return nil
+			},
+			StatusListener: server.CheckStatusListener(),
+		}),
+	)
+}
+
+func TestCheck(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	server := NewServer()
+	server.SetChecker(newTestChecker(server, &failing))
+	client := startServer(t, server)
+
+	// Act
+	up, upErr := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	failing.Store(true)
+	down, downErr := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "database"})
+
+	// Assert
+	require.NoError(t, upErr)
+	require.NoError(t, downErr)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, up.Status)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, down.Status)
+}
+
+func TestCheckFailsForUnknownService(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	server := NewServer()
+	server.SetChecker(newTestChecker(server, &failing))
+	client := startServer(t, server)
+
+	// Act
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "cache"})
+
+	// Assert
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCheckFailsWithoutChecker(t *testing.T) {
+	// Arrange
+	client := startServer(t, NewServer())
+
+	// Act
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	// Assert
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestWatchSendsCurrentStatusAndUpdates(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	server := NewServer()
+	checker := newTestChecker(server, &failing)
+	server.SetChecker(checker)
+	client := startServer(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// Act
+	initial, initialErr := stream.Recv()
+	failing.Store(true)
+	checker.Check(context.Background())
+	update, updateErr := stream.Recv()
+
+	// Assert
+	require.NoError(t, initialErr)
+	require.NoError(t, updateErr)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, initial.Status)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, update.Status)
+}
+
+func TestWatchReportsUnknownService(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	server := NewServer()
+	server.SetChecker(newTestChecker(server, &failing))
+	client := startServer(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "cache"})
+	require.NoError(t, err)
+
+	// Act
+	resp, err := stream.Recv()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, resp.Status)
+}
+
+func TestServingStatusOf(t *testing.T) {
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatusOf(health.StatusDegraded))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatusOf(health.StatusStarting))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(health.StatusMaintenance))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(health.StatusSkipped))
+	assert.Equal(t, healthpb.HealthCheckResponse_UNKNOWN, servingStatusOf(health.StatusUnknown))
+}
@@ -1,19 +1,53 @@
 package health
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 type (
 	HandlerConfig struct {
-		statusCodeUp   int
-		statusCodeDown int
-		middleware     []Middleware
-		resultWriter   ResultWriter
+		statusCodeUp          int
+		statusCodeDown        int
+		statusCodeMaint       int
+		middleware            []Middleware
+		resultWriter          ResultWriter
+		resultWriters         map[string]ResultWriter
+		checkFilters          []handlerCheckFilter
+		refreshEnabled        bool
+		refreshInterval       time.Duration
+		detailsAuth           func(r *http.Request) bool
+		authenticators        []handlerAuthenticator
+		checkSelectionEnabled bool
+		contextEnrichers      []func(ctx context.Context, r *http.Request) context.Context
+		etagEnabled           bool
+		statusCodes           map[AvailabilityStatus]int
+		statusCodeResolver    func(result CheckerResult) int
+		responseHeaders       []func(result CheckerResult) http.Header
+		httpMiddleware        []HTTPMiddleware
+		rateLimit             *rateLimitConfig
+		earlyHints            bool
+		earlyHintLinks        []string
+		streaming             bool
+		methods               []string
+		cors                  *corsConfig
+		compressionEnabled    bool
+		compressionMinSize    int
+		resultLimits          *ResultLimits
+	}
+
+	handlerCheckFilter struct {
+		description string
+		numValues   int
+		filter      CheckFilter
 	}
 
 	// Middleware is factory function that allows creating new instances of
@@ -45,40 +79,262 @@ type (
 
 	// JSONResultWriter writes a CheckerResult in JSON format into an
 	// http.ResponseWriter. This ResultWriter is set by default.
-	JSONResultWriter struct{}
+	JSONResultWriter struct {
+		fieldNames        map[string]string
+		statusMapper      func(status AvailabilityStatus) string
+		transformer       func(result *CheckerResult) interface{}
+		timestampFormat   TimestampFormat
+		timestampLocation *time.Location
+		groupSeparator    string
+		messageCatalog    MessageCatalog
+		defaultLanguage   string
+	}
+
+	// JSONResultWriterOption is a configuration option for a JSONResultWriter (see NewJSONResultWriter).
+	JSONResultWriterOption func(rw *JSONResultWriter)
 )
 
+// WithJSONFieldNames renames fields in the JSON output of a JSONResultWriter. The map keys are the default field
+// names (e.g., "status", "details", "error", "timestamp") and the values are the field names that are written
+// instead (e.g., "status" -> "state"). Renaming applies to the fields of the aggregated result as well as to the
+// fields of each component, but not to component names or the keys of info and data maps.
+func WithJSONFieldNames(fieldNames map[string]string) JSONResultWriterOption {
+	return func(rw *JSONResultWriter) {
+		rw.fieldNames = fieldNames
+	}
+}
+
+// WithJSONStatusMapper configures a function that maps availability statuses to the values that are written
+// into the JSON output of a JSONResultWriter (e.g., to write "UP" instead of "up"). The function is applied
+// to the aggregated status as well as to the status of each component.
+func WithJSONStatusMapper(mapper func(status AvailabilityStatus) string) JSONResultWriterOption {
+	return func(rw *JSONResultWriter) {
+		rw.statusMapper = mapper
+	}
+}
+
+// WithResultTransformer configures a function that transforms a CheckerResult into an arbitrary value, which is
+// marshalled into the JSON output of a JSONResultWriter instead of the CheckerResult. This allows to produce
+// any response schema without writing a custom ResultWriter. If a transformer is configured,
+// WithJSONFieldNames and WithJSONStatusMapper are ignored.
+func WithResultTransformer(transformer func(result *CheckerResult) interface{}) JSONResultWriterOption {
+	return func(rw *JSONResultWriter) {
+		rw.transformer = transformer
+	}
+}
+
 // Write implements ResultWriter.Write.
 func (rw *JSONResultWriter) Write(result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
-	jsonResp, err := json.Marshal(result)
+	language, translation, translated := rw.selectTranslation(r)
+	jsonResp, err := rw.marshal(result, translation)
 	if err != nil {
 		return fmt.Errorf("cannot marshal response: %w", err)
 	}
+	if len(rw.messageCatalog) > 0 {
+		w.Header().Add("Vary", "Accept-Language")
+	}
+	if translated {
+		w.Header().Set("Content-Language", language)
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(statusCode)
 	_, err = w.Write(jsonResp)
 	return err
 }
 
+func (rw *JSONResultWriter) marshal(result *CheckerResult, translation *Translation) ([]byte, error) {
+	if rw.transformer != nil {
+		return json.Marshal(rw.transformer(result))
+	}
+
+	if rw.fieldNames == nil && rw.statusMapper == nil && !rw.formatsTimestamps() && rw.groupSeparator == "" && translation == nil {
+		return json.Marshal(result)
+	}
+
+	// Field names and statuses are mapped on the generic JSON representation,
+	// so that custom marshalling of the result types is respected.
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if details, ok := doc["details"].(map[string]interface{}); ok {
+		for name, component := range details {
+			if component, ok := component.(map[string]interface{}); ok {
+				details[name] = rw.mapJSONFields(component, translation)
+			}
+		}
+
+		if rw.groupSeparator != "" {
+			doc["details"] = rw.groupComponents(result, details)
+		}
+	}
+
+	return json.Marshal(rw.mapJSONFields(doc, translation))
+}
+
+func (rw *JSONResultWriter) mapJSONFields(fields map[string]interface{}, translation *Translation) map[string]interface{} {
+	if translation != nil {
+		translation.addMessage(fields)
+	}
+
+	if status, ok := fields["status"].(string); ok {
+		if localized, ok := translation.localizedStatus(AvailabilityStatus(status)); ok {
+			fields["status"] = localized
+		} else if rw.statusMapper != nil {
+			fields["status"] = rw.statusMapper(AvailabilityStatus(status))
+		}
+	}
+
+	if rw.formatsTimestamps() {
+		rw.formatTimestampField(fields)
+		if history, ok := fields["history"].([]interface{}); ok {
+			for _, entry := range history {
+				if entry, ok := entry.(map[string]interface{}); ok {
+					rw.formatTimestampField(entry)
+				}
+			}
+		}
+	}
+
+	mapped := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		mapped[rw.fieldName(name)] = value
+	}
+
+	return mapped
+}
+
 // NewJSONResultWriter creates a new instance of a JSONResultWriter.
-func NewJSONResultWriter() *JSONResultWriter {
-	return &JSONResultWriter{}
+func NewJSONResultWriter(options ...JSONResultWriterOption) *JSONResultWriter {
+	rw := &JSONResultWriter{}
+	for _, opt := range options {
+		opt(rw)
+	}
+	return rw
 }
 
 // NewHandler creates a new health check http.Handler.
+// NewHandler panics if the configuration is invalid (e.g., if a tag filter does not match any check; see WithTagFilter).
 func NewHandler(checker Checker, options ...HandlerOption) http.HandlerFunc {
 	cfg := createConfig(options)
+	filter := createCheckFilter(checker, &cfg)
+	limiter := &refreshLimiter{minInterval: cfg.refreshInterval}
+	rateLimiter := newRateLimiter(cfg.rateLimit)
+	methods := allowedMethods(&cfg)
 	return func(w http.ResponseWriter, r *http.Request) {
+		if handleCORS(w, r, cfg.cors, methods) {
+			return
+		}
+
+		if !containsMethod(methods, r.Method) {
+			writeMethodNotAllowed(w, methods)
+			return
+		}
+
+		// Streamed responses are not compressed, because the compressed body can only be written at once.
+		if cfg.compressionEnabled && !(cfg.streaming && r.Method != http.MethodHead) {
+			if cw := newCompressingWriter(w, r, cfg.compressionMinSize); cw != nil {
+				//nolint:errcheck
+				defer cw.finish()
+				w = cw
+			}
+		}
+
+		if !isAuthenticated(r, cfg.authenticators) {
+			writeUnauthorized(w, cfg.authenticators)
+			return
+		}
+
+		// Select the result writer before checking, so that no checks are executed for unacceptable requests
+		if len(cfg.resultWriters) > 0 {
+			w.Header().Add("Vary", "Accept")
+		}
+
+		resultWriter, mediaType, ok := selectResultWriter(r, &cfg)
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+			return
+		}
+
+		// Rate limited requests get the last result along with status code 429 if possible, so that no checks
+		// are executed for them, but clients still learn that they have been rate limited.
+		// Results of requests that selected specific checks are not reused (see WithCheckSelection).
+		if rateLimiter != nil {
+			if wait, allowed := rateLimiter.allow(r); !allowed {
+				writeRetryAfter(w, wait)
+				if cached, ok := rateLimiter.loadResult(); ok && !cfg.checkSelectionEnabled {
+					removeUnauthorizedDetails(r, &cached, &cfg)
+					applyResultLimits(&cached, cfg.resultLimits)
+					disableResponseCache(w)
+					writeResult(resultWriter, &cached, http.StatusTooManyRequests, w, r)
+					return
+				}
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		filter := filter
+		if cfg.checkSelectionEnabled {
+			var err error
+			if filter, err = selectRequestedChecks(r, checker, filter); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		forceRefresh := cfg.refreshEnabled && isRefreshRequest(r)
+		if forceRefresh {
+			if wait, allowed := limiter.allow(); !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+		}
+
+		if cfg.earlyHints {
+			writeEarlyHints(w, cfg.earlyHintLinks)
+		}
+
+		if cfg.streaming && r.Method != http.MethodHead {
+			streamResults(w, enrichContext(r, cfg.contextEnrichers), checker, filter, &cfg)
+			return
+		}
+
 		// Do the check (with configured middleware)
-		result := withMiddleware(cfg.middleware, func(r *http.Request) CheckerResult {
-			return checker.Check(r.Context())
-		})(r)
+		r = enrichContext(r, cfg.contextEnrichers)
+		result, ok := withHTTPMiddleware(w, r, &cfg, func(r *http.Request) CheckerResult {
+			if forceRefresh {
+				return refresh(r.Context(), checker, filter)
+			}
+			return check(r.Context(), checker, filter)
+		})
+		if !ok {
+			return
+		}
+		statusCode := resolveHTTPStatusCode(&result, &cfg)
+		headers := resolveResponseHeaders(&result, &cfg)
+		if rateLimiter != nil {
+			rateLimiter.storeResult(result)
+		}
+		removeUnauthorizedDetails(r, &result, &cfg)
+		applyResultLimits(&result, cfg.resultLimits)
 
 		// Write HTTP response
 		disableResponseCache(w)
-		statusCode := mapHTTPStatusCode(result.Status, cfg.statusCodeUp, cfg.statusCodeDown)
-		//nolint:errcheck
-		cfg.resultWriter.Write(&result, statusCode, w, r)
+		setHeaders(w, headers)
+		if cfg.etagEnabled && writeETag(w, r, &result, statusCode, mediaType, contentLanguage(resultWriter, r)) {
+			return
+		}
+		writeResult(resultWriter, &result, statusCode, w, r)
 	}
 }
 
@@ -86,20 +342,57 @@ func NewHandler(checker Checker, options ...HandlerOption) http.HandlerFunc {
 // the Echo framework, version 4.x.
 func NewHandlerEcho(ctx echo.Context, checker Checker, options ...HandlerOption) error {
 	cfg := createConfig(options)
+	filter := createCheckFilter(checker, &cfg)
+
+	if !isAuthenticated(ctx.Request(), cfg.authenticators) {
+		writeUnauthorized(ctx.Response().Writer, cfg.authenticators)
+		return nil
+	}
 
 	// Do the check (with configured middleware)
-	result := withMiddleware(cfg.middleware, func(r *http.Request) CheckerResult {
-		return checker.Check(r.Context())
-	})(ctx.Request())
+	result, ok := withHTTPMiddleware(ctx.Response().Writer, enrichContext(ctx.Request(), cfg.contextEnrichers), &cfg,
+		func(r *http.Request) CheckerResult {
+			return check(r.Context(), checker, filter)
+		})
+	if !ok {
+		return nil
+	}
+	statusCode := resolveHTTPStatusCode(&result, &cfg)
+	headers := resolveResponseHeaders(&result, &cfg)
+	removeUnauthorizedDetails(ctx.Request(), &result, &cfg)
+	applyResultLimits(&result, cfg.resultLimits)
 
 	// Write HTTP response
 	disableResponseCache(ctx.Response().Writer)
-	statusCode := mapHTTPStatusCode(result.Status, cfg.statusCodeUp, cfg.statusCodeDown)
+	setHeaders(ctx.Response().Writer, headers)
+	if ctx.Request().Method == http.MethodHead {
+		return ctx.NoContent(statusCode)
+	}
 	//nolint:errcheck
 	return ctx.JSON(statusCode, &result)
 
 }
 
+// writeResult writes the result into the response using the result writer. Responses to HEAD requests only
+// contain the HTTP status code and headers, so the result is not serialized at all.
+func writeResult(resultWriter ResultWriter, result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodHead {
+		w.WriteHeader(statusCode)
+		return
+	}
+	//nolint:errcheck
+	resultWriter.Write(result, statusCode, w, r)
+}
+
+// removeUnauthorizedDetails removes all data except the aggregated status from the result
+// if the request is not authorized to see details (see WithDetailsAuthorizer).
+func removeUnauthorizedDetails(r *http.Request, result *CheckerResult, cfg *HandlerConfig) {
+	if cfg.detailsAuth != nil && !cfg.detailsAuth(r) {
+		result.Details = nil
+		result.Info = nil
+	}
+}
+
 func disableResponseCache(w http.ResponseWriter) {
 	// Avoid caching: https://www.ibm.com/garage/method/practices/manage/health-check-apis/
 	w.Header().Set("Cache-Control", "no-cache")
@@ -107,18 +400,59 @@ func disableResponseCache(w http.ResponseWriter) {
 	w.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
 }
 
-func mapHTTPStatusCode(status AvailabilityStatus, statusCodeUp int, statusCodeDown int) int {
-	if status == StatusDown || status == StatusUnknown {
-		return statusCodeDown
+// resolveHTTPStatusCode determines the HTTP status code of the response. The status code resolver
+// (see WithStatusCodeResolver) takes precedence over status specific codes (see WithStatusCode),
+// which take precedence over the default mapping (see mapHTTPStatusCode).
+func resolveHTTPStatusCode(result *CheckerResult, cfg *HandlerConfig) int {
+	if cfg.statusCodeResolver != nil {
+		if statusCode := cfg.statusCodeResolver(*result); statusCode != 0 {
+			return statusCode
+		}
+	}
+
+	if statusCode, ok := cfg.statusCodes[result.Status]; ok {
+		return statusCode
+	}
+
+	return mapHTTPStatusCode(result.Status, cfg)
+}
+
+// resolveResponseHeaders collects the headers of all response header functions (see WithResponseHeaders).
+func resolveResponseHeaders(result *CheckerResult, cfg *HandlerConfig) http.Header {
+	headers := http.Header{}
+	for _, headerFunc := range cfg.responseHeaders {
+		for key, values := range headerFunc(*result) {
+			headers[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	return headers
+}
+
+// setHeaders sets the headers in the response, replacing existing values of the same keys.
+func setHeaders(w http.ResponseWriter, headers http.Header) {
+	for key, values := range headers {
+		w.Header()[key] = values
+	}
+}
+
+func mapHTTPStatusCode(status AvailabilityStatus, cfg *HandlerConfig) int {
+	switch status {
+	case StatusMaintenance:
+		return cfg.statusCodeMaint
+	case StatusDown, StatusUnknown, StatusSkipped:
+		return cfg.statusCodeDown
+	default:
+		return cfg.statusCodeUp
 	}
-	return statusCodeUp
 }
 
 func createConfig(options []HandlerOption) HandlerConfig {
 	cfg := HandlerConfig{
-		statusCodeDown: 503,
-		statusCodeUp:   200,
-		middleware:     []Middleware{},
+		statusCodeDown:  503,
+		statusCodeMaint: 503,
+		statusCodeUp:    200,
+		middleware:      []Middleware{},
+		refreshInterval: 10 * time.Second,
 	}
 
 	for _, opt := range options {
@@ -132,6 +466,54 @@ func createConfig(options []HandlerOption) HandlerConfig {
 	return cfg
 }
 
+func check(ctx context.Context, checker Checker, filter CheckFilter) CheckerResult {
+	if filter == nil {
+		return checker.Check(ctx)
+	}
+	return checker.CheckWithFilter(ctx, filter)
+}
+
+// createCheckFilter combines all configured check filters into one. A check is only accepted if all filters accept it.
+func createCheckFilter(checker Checker, cfg *HandlerConfig) CheckFilter {
+	if len(cfg.checkFilters) == 0 {
+		return nil
+	}
+
+	for _, f := range cfg.checkFilters {
+		if f.numValues == 0 {
+			panic(fmt.Sprintf("health: %s must contain at least one value", f.description))
+		}
+
+		if len(checker.GetCheckNames(f.filter)) == 0 {
+			panic(fmt.Sprintf("health: no check matches the %s", f.description))
+		}
+	}
+
+	filters := cfg.checkFilters
+	return func(check Check) bool {
+		for _, f := range filters {
+			if !f.filter(check) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// enrichContext applies all context enrichers to the request context (see WithContextEnricher).
+func enrichContext(r *http.Request, enrichers []func(ctx context.Context, r *http.Request) context.Context) *http.Request {
+	if len(enrichers) == 0 {
+		return r
+	}
+
+	ctx := r.Context()
+	for _, enrich := range enrichers {
+		ctx = enrich(ctx, r)
+	}
+
+	return r.WithContext(ctx)
+}
+
 func withMiddleware(interceptors []Middleware, target MiddlewareFunc) MiddlewareFunc {
 	chain := target
 	for idx := len(interceptors) - 1; idx >= 0; idx-- {
@@ -27,14 +27,133 @@ func (ck *checkerMock) Stop() {
 	ck.Called()
 }
 
+func (ck *checkerMock) Pause() {
+	ck.Called()
+}
+
+func (ck *checkerMock) Resume() {
+	ck.Called()
+}
+
+func (ck *checkerMock) IsPaused() bool {
+	return ck.Called().Get(0).(bool)
+}
+
 func (ck *checkerMock) Check(ctx context.Context) CheckerResult {
 	return ck.Called(ctx).Get(0).(CheckerResult)
 }
 
+func (ck *checkerMock) CheckWithFilter(ctx context.Context, filter CheckFilter) CheckerResult {
+	return ck.Called(ctx, filter).Get(0).(CheckerResult)
+}
+
+func (ck *checkerMock) CheckNow(ctx context.Context, name string) (CheckResult, error) {
+	args := ck.Called(ctx, name)
+	return args.Get(0).(CheckResult), args.Error(1)
+}
+
+func (ck *checkerMock) CheckAllNow(ctx context.Context) CheckerResult {
+	args := ck.Called(ctx)
+	return args.Get(0).(CheckerResult)
+}
+
+func (ck *checkerMock) GetCheckNames(filter CheckFilter) []string {
+	return ck.Called(filter).Get(0).([]string)
+}
+
 func (ck *checkerMock) GetRunningPeriodicCheckCount() int {
 	return ck.Called().Get(0).(int)
 }
 
+func (ck *checkerMock) GetRunningPeriodicCheckNames() []string {
+	return ck.Called().Get(0).([]string)
+}
+
+func (ck *checkerMock) GetCheckState(name string) (CheckState, bool) {
+	args := ck.Called(name)
+	return args.Get(0).(CheckState), args.Bool(1)
+}
+
+func (ck *checkerMock) GetCheckHistory(name string) ([]CheckHistoryEntry, bool) {
+	args := ck.Called(name)
+	return args.Get(0).([]CheckHistoryEntry), args.Bool(1)
+}
+
+func (ck *checkerMock) AddCheck(check Check) error {
+	return ck.Called(check).Error(0)
+}
+
+func (ck *checkerMock) AddPeriodicCheck(refreshPeriod time.Duration, initialDelay time.Duration, check Check) error {
+	return ck.Called(refreshPeriod, initialDelay, check).Error(0)
+}
+
+func (ck *checkerMock) RemoveCheck(name string) error {
+	return ck.Called(name).Error(0)
+}
+
+func (ck *checkerMock) SetUnhealthy(reason string) {
+	ck.Called(reason)
+}
+
+func (ck *checkerMock) Shutdown(ctx context.Context) error {
+	args := ck.Called(ctx)
+	return args.Error(0)
+}
+
+func (ck *checkerMock) Subscribe(ctx context.Context, queueSize int) <-chan CheckerState {
+	return ck.Called(ctx, queueSize).Get(0).(<-chan CheckerState)
+}
+
+func (ck *checkerMock) GetState() CheckerState {
+	return ck.Called().Get(0).(CheckerState)
+}
+
+func (ck *checkerMock) ListChecks() []CheckInfo {
+	return ck.Called().Get(0).([]CheckInfo)
+}
+
+func (ck *checkerMock) Reload(cfg CheckerConfig) error {
+	return ck.Called(cfg).Error(0)
+}
+
+func (ck *checkerMock) SetMaintenance(name string, until time.Time, reason string) error {
+	return ck.Called(name, until, reason).Error(0)
+}
+
+func (ck *checkerMock) ClearMaintenance(name string) error {
+	return ck.Called(name).Error(0)
+}
+
+func (ck *checkerMock) AddSilence(silence Silence) string {
+	return ck.Called(silence).String(0)
+}
+
+func (ck *checkerMock) RemoveSilence(id string) bool {
+	return ck.Called(id).Bool(0)
+}
+
+func (ck *checkerMock) GetAvailability(name string, window time.Duration) (float64, bool) {
+	args := ck.Called(name, window)
+	return args.Get(0).(float64), args.Bool(1)
+}
+
+func (ck *checkerMock) GetIncidentStats(name string) (IncidentStats, bool) {
+	args := ck.Called(name)
+	return args.Get(0).(IncidentStats), args.Bool(1)
+}
+
+func (ck *checkerMock) GetSystemIncidentStats() IncidentStats {
+	return ck.Called().Get(0).(IncidentStats)
+}
+
+func (ck *checkerMock) DisableCheck(name string) error {
+	return ck.Called(name).Error(0)
+}
+
+func (ck *checkerMock) EnableCheck(name string) error {
+	return ck.Called(name).Error(0)
+}
+
 func (ck *checkerMock) IsStarted() bool {
 	return ck.Called().Get(0).(bool)
 }
@@ -121,3 +240,280 @@ func TestWhenChecksEmptyThenHandlerResultContainNoChecksMap(t *testing.T) {
 	}
 
 }
+
+func TestHandlerWithTagFilterOnlyReportsTaggedChecks(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithCheck(Check{
+			Name:  "liveness-check",
+			Tags:  []string{"liveness"},
+			Check: func(ctx context.Context) error { return nil },
+		}),
+		WithCheck(Check{
+			Name:  "readiness-check",
+			Tags:  []string{"readiness"},
+			Check: func(ctx context.Context) error { return fmt.Errorf("not ready") },
+		}),
+	)
+	liveHandler := NewHandler(ckr, WithTagFilter("liveness"))
+	readyHandler := NewHandler(ckr, WithTagFilter("readiness"))
+
+	// Act
+	liveResponse := httptest.NewRecorder()
+	liveHandler.ServeHTTP(liveResponse, httptest.NewRequest(http.MethodGet, "/live", nil))
+	readyResponse := httptest.NewRecorder()
+	readyHandler.ServeHTTP(readyResponse, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	// Assert
+	liveResult := CheckerResult{}
+	_ = json.Unmarshal(liveResponse.Body.Bytes(), &liveResult)
+	assert.Equal(t, http.StatusOK, liveResponse.Code)
+	assert.Equal(t, StatusUp, liveResult.Status)
+	assert.Len(t, liveResult.Details, 1)
+	assert.Contains(t, liveResult.Details, "liveness-check")
+
+	readyResult := CheckerResult{}
+	_ = json.Unmarshal(readyResponse.Body.Bytes(), &readyResult)
+	assert.Equal(t, http.StatusServiceUnavailable, readyResponse.Code)
+	assert.Equal(t, StatusDown, readyResult.Status)
+	assert.Len(t, readyResult.Details, 1)
+	assert.Contains(t, readyResult.Details, "readiness-check")
+}
+
+func TestHandlerWithUnmatchedTagFilterPanics(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(WithCheck(Check{
+		Name:  "check",
+		Tags:  []string{"liveness"},
+		Check: func(ctx context.Context) error { return nil },
+	}))
+
+	// Act + Assert
+	assert.Panics(t, func() { NewHandler(ckr, WithTagFilter("readiness")) })
+	assert.Panics(t, func() { NewHandler(ckr, WithTagFilter()) })
+}
+
+func TestHandlerWithProbeFilterOnlyReportsClassifiedChecks(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithCheck(Check{
+			Name:   "deadlock",
+			Probes: []Probe{Liveness, Readiness},
+			Check:  func(ctx context.Context) error { return nil },
+		}),
+		WithCheck(Check{
+			Name:   "database",
+			Probes: []Probe{Readiness},
+			Check:  func(ctx context.Context) error { return fmt.Errorf("not ready") },
+		}),
+	)
+	response := httptest.NewRecorder()
+
+	// Act
+	NewHandler(ckr, WithProbeFilter(Liveness)).ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/live", nil))
+
+	// Assert
+	result := CheckerResult{}
+	_ = json.Unmarshal(response.Body.Bytes(), &result)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Len(t, result.Details, 1)
+	assert.Contains(t, result.Details, "deadlock")
+	assert.Panics(t, func() { NewHandler(ckr, WithProbeFilter(Startup)) })
+}
+
+func TestHandlerRespondsWithMaintenanceStatusCodeWhenPaused(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(WithCheck(Check{Name: "check", Check: func(ctx context.Context) error { return nil }}))
+	handler := NewHandler(ckr, WithStatusCodeMaintenance(http.StatusTeapot))
+	response := httptest.NewRecorder()
+
+	// Act
+	ckr.Pause()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	result := CheckerResult{}
+	_ = json.Unmarshal(response.Body.Bytes(), &result)
+	assert.Equal(t, http.StatusTeapot, response.Code)
+	assert.Equal(t, StatusMaintenance, result.Status)
+}
+
+func TestHandlerWithDetailsAuthorizerOnlyRevealsDetailsToAuthorizedRequests(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithInfo(map[string]interface{}{"version": "1.0.0"}),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return fmt.Errorf("secret error") }}),
+	)
+	handler := NewHandler(ckr, WithDetailsAuthorizer(func(r *http.Request) bool {
+		return r.Header.Get("Authorization") == "Bearer token"
+	}))
+
+	authorizedRequest := httptest.NewRequest(http.MethodGet, "/health", nil)
+	authorizedRequest.Header.Set("Authorization", "Bearer token")
+	authorizedResponse := httptest.NewRecorder()
+	unauthorizedResponse := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(authorizedResponse, authorizedRequest)
+	handler.ServeHTTP(unauthorizedResponse, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, unauthorizedResponse.Code)
+	assert.Equal(t, `{"status":"down"}`, unauthorizedResponse.Body.String())
+	assert.Equal(t, http.StatusServiceUnavailable, authorizedResponse.Code)
+	assert.Contains(t, authorizedResponse.Body.String(), "secret error")
+	assert.Contains(t, authorizedResponse.Body.String(), "1.0.0")
+}
+
+func TestHandlerWithTagExclusionFilter(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Tags: []string{"critical"}, Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "search", Tags: []string{"critical", "slow"}, Check: func(ctx context.Context) error {
+			return fmt.Errorf("timeout")
+		}}),
+		WithCheck(Check{Name: "cache", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewHandler(ckr, WithTagFilter("critical"), WithTagExclusionFilter("slow"))
+
+	// Act
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	result := CheckerResult{}
+	_ = json.Unmarshal(response.Body.Bytes(), &result)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Len(t, result.Details, 1)
+	assert.Contains(t, result.Details, "database")
+	assert.Panics(t, func() { NewHandler(ckr, WithTagExclusionFilter()) })
+}
+
+func TestHandlerWithContextEnricher(t *testing.T) {
+	// Arrange
+	type tenantKey struct{}
+	var tenant interface{}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			tenant = ctx.Value(tenantKey{})
+			return nil
+		}}),
+	)
+	handler := NewHandler(ckr, WithContextEnricher(func(ctx context.Context, r *http.Request) context.Context {
+		return context.WithValue(ctx, tenantKey{}, r.Header.Get("X-Tenant"))
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("X-Tenant", "acme")
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	assert.Equal(t, "acme", tenant)
+}
+
+func TestHandlerWithStatusCodeMapping(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return NewDegradedError(fmt.Errorf("replica lag"))
+		}}),
+	)
+	handler := NewHandler(ckr, WithStatusCode(StatusDegraded, http.StatusMultiStatus))
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusMultiStatus, response.Code)
+}
+
+func TestHandlerWithStatusCodeResolver(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "rate-limit", Check: func(ctx context.Context) error {
+			return fmt.Errorf("quota exceeded")
+		}}),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return nil
+		}}),
+	)
+	resolver := func(result CheckerResult) int {
+		if result.Details["rate-limit"].Status == StatusDown {
+			return http.StatusTooManyRequests
+		}
+		return 0
+	}
+	handler := NewHandler(ckr, WithStatusCodeResolver(resolver), WithDetailsAuthorizer(func(r *http.Request) bool {
+		return false
+	}))
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, response.Code)
+	assert.NotContains(t, response.Body.String(), "rate-limit")
+}
+
+func TestHandlerWithResponseHeaders(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return fmt.Errorf("connection refused")
+		}}),
+	)
+	handler := NewHandler(ckr,
+		WithResponseHeaders(func(result CheckerResult) http.Header {
+			return http.Header{"x-health-status": []string{string(result.Status)}}
+		}),
+		WithResponseHeaders(func(result CheckerResult) http.Header {
+			headers := http.Header{}
+			if result.Status == StatusDown {
+				headers.Set("Retry-After", "30")
+				headers.Set("Cache-Control", "max-age=5")
+			}
+			return headers
+		}),
+	)
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.Equal(t, "down", response.Header().Get("X-Health-Status"))
+	assert.Equal(t, "30", response.Header().Get("Retry-After"))
+	assert.Equal(t, "max-age=5", response.Header().Get("Cache-Control"))
+	assert.Equal(t, "no-cache", response.Header().Get("Pragma"))
+}
+
+func TestHandlerAnswersHeadRequestsWithoutBody(t *testing.T) {
+	// Arrange
+	ckr := checkerMock{}
+	ckr.On("Check", mock.Anything).Return(CheckerResult{Status: StatusDown})
+	writer := resultWriterMock{}
+	handler := NewHandler(&ckr, WithResultWriter(&writer), WithResponseHeaders(func(result CheckerResult) http.Header {
+		return http.Header{"X-Health-Status": []string{string(result.Status)}}
+	}))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/health", nil))
+
+	// Assert
+	ckr.Mock.AssertNumberOfCalls(t, "Check", 1)
+	writer.AssertNotCalled(t, "Write", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "down", w.Header().Get("X-Health-Status"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Empty(t, w.Body.String())
+}
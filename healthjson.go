@@ -0,0 +1,139 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// HealthJSONResultWriter writes a CheckerResult in the "application/health+json" format
+	// as defined by the IETF draft "Health Check Response Format for HTTP APIs"
+	// (https://datatracker.ietf.org/doc/html/draft-inadarei-api-health-check).
+	// Availability statuses are mapped as follows: StatusUp is reported as "pass", StatusDegraded,
+	// StatusMaintenance, StatusStarting and StatusDisabled as "warn", and all other statuses as "fail".
+	// Each component is reported in the "checks" object using the check name as key. Check names that
+	// should follow the "componentName:measurementName" convention of the draft need to be named accordingly
+	// (e.g., "database:responseTime").
+	HealthJSONResultWriter struct {
+		version     string
+		releaseID   string
+		serviceID   string
+		description string
+		links       map[string]string
+	}
+
+	// HealthJSONOption is a configuration option for a HealthJSONResultWriter (see NewHealthJSONResultWriter).
+	HealthJSONOption func(rw *HealthJSONResultWriter)
+
+	healthJSONResult struct {
+		Status      string                       `json:"status"`
+		Version     string                       `json:"version,omitempty"`
+		ReleaseID   string                       `json:"releaseId,omitempty"`
+		ServiceID   string                       `json:"serviceId,omitempty"`
+		Description string                       `json:"description,omitempty"`
+		Checks      map[string][]healthJSONCheck `json:"checks,omitempty"`
+		Links       map[string]string            `json:"links,omitempty"`
+	}
+
+	healthJSONCheck struct {
+		ComponentID string     `json:"componentId,omitempty"`
+		Status      string     `json:"status"`
+		Time        *time.Time `json:"time,omitempty"`
+		Output      string     `json:"output,omitempty"`
+	}
+)
+
+// HealthJSONContentType is the media type of the "application/health+json" format (see HealthJSONResultWriter).
+const HealthJSONContentType = "application/health+json"
+
+// NewHealthJSONResultWriter creates a new instance of a HealthJSONResultWriter.
+func NewHealthJSONResultWriter(options ...HealthJSONOption) *HealthJSONResultWriter {
+	rw := &HealthJSONResultWriter{}
+	for _, opt := range options {
+		opt(rw)
+	}
+	return rw
+}
+
+// WithHealthJSONVersion sets the public version of the service ("version" field).
+func WithHealthJSONVersion(version string) HealthJSONOption {
+	return func(rw *HealthJSONResultWriter) {
+		rw.version = version
+	}
+}
+
+// WithHealthJSONReleaseID sets the release version of the service ("releaseId" field).
+func WithHealthJSONReleaseID(releaseID string) HealthJSONOption {
+	return func(rw *HealthJSONResultWriter) {
+		rw.releaseID = releaseID
+	}
+}
+
+// WithHealthJSONServiceID sets the unique identifier of the service ("serviceId" field).
+func WithHealthJSONServiceID(serviceID string) HealthJSONOption {
+	return func(rw *HealthJSONResultWriter) {
+		rw.serviceID = serviceID
+	}
+}
+
+// WithHealthJSONDescription sets a human-friendly description of the service ("description" field).
+func WithHealthJSONDescription(description string) HealthJSONOption {
+	return func(rw *HealthJSONResultWriter) {
+		rw.description = description
+	}
+}
+
+// WithHealthJSONLinks sets links to related resources by relation type ("links" field),
+// e.g., {"about": "https://example.com/docs"}.
+func WithHealthJSONLinks(links map[string]string) HealthJSONOption {
+	return func(rw *HealthJSONResultWriter) {
+		rw.links = links
+	}
+}
+
+// Write implements ResultWriter.Write.
+func (rw *HealthJSONResultWriter) Write(result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	healthResult := healthJSONResult{
+		Status:      toHealthJSONStatus(result.Status),
+		Version:     rw.version,
+		ReleaseID:   rw.releaseID,
+		ServiceID:   rw.serviceID,
+		Description: rw.description,
+		Links:       rw.links,
+	}
+
+	if len(result.Details) > 0 {
+		healthResult.Checks = make(map[string][]healthJSONCheck, len(result.Details))
+		for name, checkResult := range result.Details {
+			healthResult.Checks[name] = []healthJSONCheck{{
+				ComponentID: strings.SplitN(name, ":", 2)[0],
+				Status:      toHealthJSONStatus(checkResult.Status),
+				Time:        timeOrNil(checkResult.Timestamp),
+				Output:      errorMessage(checkResult.Error),
+			}}
+		}
+	}
+
+	jsonResp, err := json.Marshal(healthResult)
+	if err != nil {
+		return fmt.Errorf("cannot marshal response: %w", err)
+	}
+	w.Header().Set("Content-Type", HealthJSONContentType)
+	w.WriteHeader(statusCode)
+	_, err = w.Write(jsonResp)
+	return err
+}
+
+func toHealthJSONStatus(status AvailabilityStatus) string {
+	switch status {
+	case StatusUp:
+		return "pass"
+	case StatusDegraded, StatusMaintenance, StatusStarting, StatusDisabled:
+		return "warn"
+	default:
+		return "fail"
+	}
+}
@@ -0,0 +1,49 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthJSONResultWriter(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	writer := NewHealthJSONResultWriter(
+		WithHealthJSONVersion("1"),
+		WithHealthJSONReleaseID("1.2.3"),
+		WithHealthJSONLinks(map[string]string{"about": "https://example.com/docs"}),
+	)
+	result := testCheckerResult()
+	result.Details["search:responseTime"] = CheckResult{Status: StatusDegraded}
+
+	// Act
+	err := writer.Write(result, http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "application/health+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"status": "fail",
+		"version": "1",
+		"releaseId": "1.2.3",
+		"checks": {
+			"database": [{"componentId": "database", "status": "fail", "time": "2021-07-01T08:05:14Z", "output": "connection refused"}],
+			"search": [{"componentId": "search", "status": "pass"}],
+			"search:responseTime": [{"componentId": "search", "status": "warn"}]
+		},
+		"links": {"about": "https://example.com/docs"}
+	}`, w.Body.String())
+}
+
+func TestHealthJSONStatusMapping(t *testing.T) {
+	assert.Equal(t, "pass", toHealthJSONStatus(StatusUp))
+	assert.Equal(t, "warn", toHealthJSONStatus(StatusDegraded))
+	assert.Equal(t, "warn", toHealthJSONStatus(StatusMaintenance))
+	assert.Equal(t, "fail", toHealthJSONStatus(StatusUnknown))
+	assert.Equal(t, "fail", toHealthJSONStatus(StatusDown))
+}
@@ -0,0 +1,161 @@
+package healthtest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+var _ health.Clock = (*FakeClock)(nil)
+
+// FakeClock is a clock whose time only changes when it is advanced explicitly (see FakeClock.Advance).
+// This allows to test time based behaviour deterministically without real sleeps (see health.WithClock).
+// It is safe for concurrent use.
+type FakeClock struct {
+	mtx     sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	tickers []*FakeTicker
+}
+
+type fakeWaiter struct {
+	until time.Time
+	ch    chan time.Time
+}
+
+// NewFakeClock creates a new FakeClock that is set to the provided time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.now
+}
+
+// Since returns the time elapsed since t according to the clock.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After returns a channel that receives the current time of the clock as soon as the
+// clock was advanced by at least d (see FakeClock.Advance). The channel is buffered,
+// so the clock never blocks if nobody receives from it.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+
+	c.waiters = append(c.waiters, &fakeWaiter{until: c.now.Add(d), ch: ch})
+
+	return ch
+}
+
+// Advance moves the clock forward by d and fires all channels and tickers that became due
+// (see FakeClock.After and FakeClock.NewTicker), in the order of their due time.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set sets the clock to the provided time and fires all channels and tickers that became due (see FakeClock.Advance).
+// Setting the clock to an earlier time can be used to simulate clock skew.
+func (c *FakeClock) Set(now time.Time) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.now = now
+
+	sort.SliceStable(c.waiters, func(i, j int) bool { return c.waiters[i].until.Before(c.waiters[j].until) })
+
+	remaining := c.waiters[:0]
+	for _, waiter := range c.waiters {
+		if waiter.until.After(now) {
+			remaining = append(remaining, waiter)
+			continue
+		}
+		waiter.ch <- now
+	}
+	c.waiters = remaining
+
+	for _, ticker := range c.tickers {
+		ticker.tick(now)
+	}
+}
+
+// Waiters returns the number of pending channels (see FakeClock.After). This is useful to wait
+// until a goroutine under test is waiting for the clock before advancing it.
+func (c *FakeClock) Waiters() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return len(c.waiters)
+}
+
+// FakeTicker is a health.Ticker that delivers ticks whenever its FakeClock is advanced past
+// the next tick (see FakeClock.NewTicker).
+type FakeTicker struct {
+	clock  *FakeClock
+	period time.Duration
+	next   time.Time
+	ch     chan time.Time
+}
+
+// NewTicker returns a FakeTicker that delivers a tick whenever the clock is advanced past the next tick.
+// Like time.Ticker, it drops ticks for slow receivers. It panics if d is not positive.
+func (c *FakeClock) NewTicker(d time.Duration) health.Ticker {
+	if d <= 0 {
+		panic("healthtest: non-positive interval for NewTicker")
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ticker := &FakeTicker{clock: c, period: d, next: c.now.Add(d), ch: make(chan time.Time, 1)}
+	c.tickers = append(c.tickers, ticker)
+
+	return ticker
+}
+
+// C implements health.Ticker.C.
+func (t *FakeTicker) C() <-chan time.Time {
+	return t.ch
+}
+
+// Stop implements health.Ticker.Stop.
+func (t *FakeTicker) Stop() {
+	t.clock.mtx.Lock()
+	defer t.clock.mtx.Unlock()
+
+	for idx, ticker := range t.clock.tickers {
+		if ticker == t {
+			t.clock.tickers = append(t.clock.tickers[:idx], t.clock.tickers[idx+1:]...)
+			return
+		}
+	}
+}
+
+// tick delivers a tick if the ticker became due. Must be called while holding the clock lock.
+func (t *FakeTicker) tick(now time.Time) {
+	if t.next.After(now) {
+		return
+	}
+
+	for !t.next.After(now) {
+		t.next = t.next.Add(t.period)
+	}
+
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
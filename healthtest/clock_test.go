@@ -0,0 +1,59 @@
+package healthtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFakeClockFiresChannelsWhenAdvanced(t *testing.T) {
+	// Arrange
+	clock := NewFakeClock(testTime)
+	early := clock.After(time.Second)
+	late := clock.After(time.Minute)
+	immediate := clock.After(0)
+
+	// Act
+	clock.Advance(2 * time.Second)
+
+	// Assert
+	assert.Equal(t, testTime, <-immediate)
+	assert.Equal(t, testTime.Add(2*time.Second), <-early)
+	assert.Len(t, late, 0)
+	assert.Equal(t, 1, clock.Waiters())
+	assert.Equal(t, 2*time.Second, clock.Since(testTime))
+}
+
+func TestFakeTickerDropsTicksForSlowReceivers(t *testing.T) {
+	// Arrange
+	clock := NewFakeClock(testTime)
+	ticker := clock.NewTicker(time.Second)
+
+	// Act
+	clock.Advance(500 * time.Millisecond)
+	notDue := len(ticker.C())
+	clock.Advance(time.Second)
+	clock.Advance(time.Second)
+
+	// Assert
+	assert.Equal(t, 0, notDue)
+	assert.Equal(t, testTime.Add(1500*time.Millisecond), <-ticker.C())
+	assert.Len(t, ticker.C(), 0)
+}
+
+func TestFakeTickerStop(t *testing.T) {
+	// Arrange
+	clock := NewFakeClock(testTime)
+	ticker := clock.NewTicker(time.Second)
+
+	// Act
+	ticker.Stop()
+	clock.Advance(time.Minute)
+
+	// Assert
+	assert.Len(t, ticker.C(), 0)
+	assert.Panics(t, func() { clock.NewTicker(0) })
+}
@@ -0,0 +1,101 @@
+package healthtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// MockCheck is a check function whose results can be scripted (e.g., to succeed twice, then fail).
+// Use MockCheck.Check as the check function of a health.Check:
+//
+//	mock := healthtest.NewMockCheck().Succeed().Fail(errors.New("connection refused"))
+//	checker := health.NewChecker(health.WithCheck(health.Check{Name: "database", Check: mock.Check}))
+//
+// Every execution consumes the next scripted step. The last step is repeated forever. A MockCheck
+// without any steps succeeds. It is safe for concurrent use.
+type MockCheck struct {
+	mtx   sync.Mutex
+	steps []func(ctx context.Context) error
+	calls int
+}
+
+// ErrMockCheckFailed is the error returned by MockCheck.Fail if no error is provided.
+var ErrMockCheckFailed = errors.New("mock check failed")
+
+// NewMockCheck creates a new MockCheck without any steps.
+func NewMockCheck() *MockCheck {
+	return &MockCheck{}
+}
+
+// Succeed adds a step that succeeds.
+func (m *MockCheck) Succeed() *MockCheck {
+	return m.Then(func(ctx context.Context) error { return nil })
+}
+
+// Fail adds a step that fails with the provided error. If err is nil, ErrMockCheckFailed is used.
+func (m *MockCheck) Fail(err error) *MockCheck {
+	if err == nil {
+		err = ErrMockCheckFailed
+	}
+
+	return m.Then(func(ctx context.Context) error { return err })
+}
+
+// Degrade adds a step that reports the component as degraded (see health.NewDegradedError).
+func (m *MockCheck) Degrade(err error) *MockCheck {
+	if err == nil {
+		err = ErrMockCheckFailed
+	}
+
+	return m.Then(func(ctx context.Context) error { return health.NewDegradedError(err) })
+}
+
+// Hang adds a step that blocks until the check context is done (e.g., because of a check timeout)
+// and returns the context error.
+func (m *MockCheck) Hang() *MockCheck {
+	return m.Then(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+}
+
+// Then adds a custom step.
+func (m *MockCheck) Then(step func(ctx context.Context) error) *MockCheck {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.steps = append(m.steps, step)
+
+	return m
+}
+
+// Check executes the next step. It is a check function that can be used as health.Check.Check.
+func (m *MockCheck) Check(ctx context.Context) error {
+	m.mtx.Lock()
+	m.calls++
+	var step func(ctx context.Context) error
+	if len(m.steps) > 0 {
+		step = m.steps[0]
+		if len(m.steps) > 1 {
+			m.steps = m.steps[1:]
+		}
+	}
+	m.mtx.Unlock()
+
+	if step == nil {
+		return nil
+	}
+
+	return step(ctx)
+}
+
+// Calls returns how often the check was executed.
+func (m *MockCheck) Calls() int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.calls
+}
@@ -0,0 +1,56 @@
+package healthtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockCheckExecutesStepsInOrderAndRepeatsTheLastStep(t *testing.T) {
+	// Arrange
+	connErr := errors.New("connection refused")
+	mock := NewMockCheck().Succeed().Fail(connErr).Degrade(nil)
+
+	// Act
+	results := make([]error, 4)
+	for i := range results {
+		results[i] = mock.Check(context.Background())
+	}
+
+	// Assert
+	var degradedErr *health.DegradedError
+	assert.NoError(t, results[0])
+	assert.Equal(t, connErr, results[1])
+	assert.ErrorAs(t, results[2], &degradedErr)
+	assert.ErrorIs(t, results[3], ErrMockCheckFailed)
+	assert.Equal(t, 4, mock.Calls())
+}
+
+func TestMockCheckWithoutStepsSucceeds(t *testing.T) {
+	// Arrange
+	mock := NewMockCheck()
+
+	// Act
+	err := mock.Check(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mock.Calls())
+}
+
+func TestMockCheckHangsUntilContextIsDone(t *testing.T) {
+	// Arrange
+	mock := NewMockCheck().Hang()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := mock.Check(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
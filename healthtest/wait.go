@@ -0,0 +1,76 @@
+// Package healthtest provides utilities for testing code that uses the health package, such as
+// scriptable check functions (see MockCheck), a clock that can be advanced manually (see FakeClock)
+// and assertions that wait for a checker to reach an expected status (see WaitForStatus).
+package healthtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+)
+
+// TestingT is the subset of testing.TB that is used by this package.
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// pollInterval is the time between two status evaluations while waiting for a status.
+const pollInterval = 10 * time.Millisecond
+
+// WaitForStatus waits until the aggregated status of the checker equals the expected status. The status
+// is evaluated using health.Checker.Check, so that synchronous checks are executed as well (cached results
+// are respected, see health.WithCacheDuration). The test fails if the status is not reached within timeout.
+func WaitForStatus(t TestingT, checker health.Checker, status health.AvailabilityStatus, timeout time.Duration) {
+	t.Helper()
+
+	var actual health.AvailabilityStatus
+	ok := waitFor(timeout, func() bool {
+		actual = checker.Check(context.Background()).Status
+		return actual == status
+	})
+
+	if !ok {
+		t.Fatalf("checker did not reach status %q within %v (last status: %q)", status, timeout, actual)
+	}
+}
+
+// WaitForCheckStatus waits until the status of the check with the provided name equals the expected
+// status (see WaitForStatus). The test fails if the status is not reached within timeout.
+func WaitForCheckStatus(
+	t TestingT,
+	checker health.Checker,
+	name string,
+	status health.AvailabilityStatus,
+	timeout time.Duration,
+) {
+	t.Helper()
+
+	var actual health.AvailabilityStatus
+	ok := waitFor(timeout, func() bool {
+		checker.Check(context.Background())
+		state, _ := checker.GetCheckState(name)
+		actual = state.Status
+		return actual == status
+	})
+
+	if !ok {
+		t.Fatalf("check %q did not reach status %q within %v (last status: %q)", name, status, timeout, actual)
+	}
+}
+
+func waitFor(timeout time.Duration, condition func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if condition() {
+			return true
+		}
+
+		if time.Now().After(deadline) {
+			return false
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,72 @@
+package healthtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// testingTMock records failures instead of failing the test.
+type testingTMock struct {
+	failure string
+}
+
+func (m *testingTMock) Helper() {}
+
+func (m *testingTMock) Fatalf(format string, args ...interface{}) {
+	m.failure = fmt.Sprintf(format, args...)
+}
+
+func TestWaitForStatus(t *testing.T) {
+	// Arrange
+	mock := NewMockCheck().Fail(nil).Fail(nil).Succeed()
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{Name: "database", Check: mock.Check}),
+	)
+	tMock := &testingTMock{}
+
+	// Act
+	WaitForStatus(tMock, checker, health.StatusUp, time.Second)
+
+	// Assert
+	assert.Empty(t, tMock.failure)
+	assert.Equal(t, 3, mock.Calls())
+}
+
+func TestWaitForStatusFailsAfterTimeout(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{Name: "database", Check: NewMockCheck().Fail(nil).Check}),
+	)
+	tMock := &testingTMock{}
+
+	// Act
+	WaitForStatus(tMock, checker, health.StatusUp, 30*time.Millisecond)
+
+	// Assert
+	assert.Equal(t, `checker did not reach status "up" within 30ms (last status: "down")`, tMock.failure)
+}
+
+func TestWaitForCheckStatus(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{Name: "database", Check: NewMockCheck().Succeed().Degrade(nil).Check}),
+	)
+	tMock := &testingTMock{}
+
+	// Act
+	WaitForCheckStatus(tMock, checker, "database", health.StatusDegraded, time.Second)
+	WaitForCheckStatus(tMock, checker, "cache", health.StatusUp, 30*time.Millisecond)
+
+	// Assert
+	assert.Equal(t, `check "cache" did not reach status "up" within 30ms (last status: "")`, tMock.failure)
+}
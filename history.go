@@ -0,0 +1,121 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type (
+	// CheckHistoryEntry holds the outcome of a single evaluation of a component check (see WithHistorySize).
+	// Attention: This type is converted from/to JSON using a custom marshalling/unmarshalling function
+	// (see type jsonCheckHistoryEntry), because the error interface is not converted automatically.
+	CheckHistoryEntry struct {
+		// Status is the availability status of the component after the evaluation.
+		Status AvailabilityStatus `json:"status"`
+		// Timestamp holds the time when the evaluation took place.
+		Timestamp time.Time `json:"timestamp"`
+		// Duration holds how long the check execution took (including retries).
+		// It is 0 if the check function was not executed (see StatusSkipped).
+		Duration time.Duration `json:"duration"`
+		// Error contains the check error, if the check failed.
+		Error error `json:"error,omitempty"`
+	}
+
+	jsonCheckHistoryEntry struct {
+		Status    string    `json:"status"`
+		Timestamp time.Time `json:"timestamp"`
+		Duration  string    `json:"duration"`
+		Error     string    `json:"error,omitempty"`
+	}
+
+	// checkHistory is a fixed size ring buffer that holds the most recent evaluations of a check.
+	checkHistory struct {
+		entries []CheckHistoryEntry
+		next    int
+		full    bool
+	}
+)
+
+// MarshalJSON provides a custom marshaller for the CheckHistoryEntry type.
+func (e CheckHistoryEntry) MarshalJSON() ([]byte, error) {
+	errorMsg := ""
+	if e.Error != nil {
+		errorMsg = e.Error.Error()
+	}
+
+	return json.Marshal(&jsonCheckHistoryEntry{
+		Status:    string(e.Status),
+		Timestamp: e.Timestamp,
+		Duration:  e.Duration.String(),
+		Error:     errorMsg,
+	})
+}
+
+func (e *CheckHistoryEntry) UnmarshalJSON(data []byte) error {
+	var entry jsonCheckHistoryEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+
+	e.Status = AvailabilityStatus(entry.Status)
+	e.Timestamp = entry.Timestamp
+
+	if entry.Duration != "" {
+		duration, err := time.ParseDuration(entry.Duration)
+		if err != nil {
+			return err
+		}
+		e.Duration = duration
+	}
+
+	if entry.Error != "" {
+		e.Error = errors.New(entry.Error)
+	}
+
+	return nil
+}
+
+func newCheckHistory(size uint) *checkHistory {
+	return &checkHistory{entries: make([]CheckHistoryEntry, size)}
+}
+
+// add appends an entry to the history. If the history is full, the oldest entry is overwritten.
+func (h *checkHistory) add(entry CheckHistoryEntry) {
+	h.entries[h.next] = entry
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// list returns a copy of all entries, ordered from oldest to newest.
+func (h *checkHistory) list() []CheckHistoryEntry {
+	if !h.full {
+		return append([]CheckHistoryEntry(nil), h.entries[:h.next]...)
+	}
+
+	result := make([]CheckHistoryEntry, 0, len(h.entries))
+	result = append(result, h.entries[h.next:]...)
+	result = append(result, h.entries[:h.next]...)
+
+	return result
+}
+
+// newCheckHistoryEntry creates a history entry from the state of a check after an evaluation.
+func newCheckHistoryEntry(now time.Time, state CheckState) CheckHistoryEntry {
+	entry := CheckHistoryEntry{
+		Status:    state.Status,
+		Timestamp: state.LastCheckedAt,
+		Duration:  state.LastCheckDuration,
+		Error:     state.Result,
+	}
+
+	// Skipped checks are not executed, so neither the timestamp nor the duration is updated (see skipCheck).
+	if state.Status == StatusSkipped {
+		entry.Timestamp = now.UTC()
+		entry.Duration = 0
+	}
+
+	return entry
+}
@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHistoryDiscardsOldestEntries(t *testing.T) {
+	// Arrange
+	history := newCheckHistory(3)
+
+	// Act
+	for i := 0; i < 5; i++ {
+		history.add(CheckHistoryEntry{Duration: time.Duration(i)})
+	}
+
+	// Assert
+	entries := history.list()
+	require.Len(t, entries, 3)
+	assert.Equal(t, time.Duration(2), entries[0].Duration)
+	assert.Equal(t, time.Duration(3), entries[1].Duration)
+	assert.Equal(t, time.Duration(4), entries[2].Duration)
+}
+
+func TestGetCheckHistoryReturnsRecentResults(t *testing.T) {
+	// Arrange
+	var calls int
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithHistorySize(2),
+		WithCheck(Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				calls++
+				if calls%2 == 0 {
+					return fmt.Errorf("call %d failed", calls)
+				}
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	emptyHistory, existsBefore := ckr.GetCheckHistory("database")
+	for i := 0; i < 3; i++ {
+		ckr.Check(context.Background())
+	}
+	history, exists := ckr.GetCheckHistory("database")
+	_, unknownExists := ckr.GetCheckHistory("unknown")
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.True(t, existsBefore)
+	assert.Empty(t, emptyHistory)
+	assert.True(t, exists)
+	assert.False(t, unknownExists)
+	require.Len(t, history, 2)
+	assert.Equal(t, StatusDown, history[0].Status)
+	assert.EqualError(t, history[0].Error, "call 2 failed")
+	assert.Equal(t, StatusUp, history[1].Status)
+	assert.NoError(t, history[1].Error)
+	assert.Len(t, result.Details["database"].History, 2)
+}
+
+func TestCheckHistoryIsDisabledByDefault(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+	history, exists := ckr.GetCheckHistory("database")
+
+	// Assert
+	assert.True(t, exists)
+	assert.Empty(t, history)
+	assert.Nil(t, result.Details["database"].History)
+}
+
+func TestCheckHistoryEntryJSONRoundTrip(t *testing.T) {
+	// Arrange
+	entry := CheckHistoryEntry{
+		Status:    StatusDown,
+		Timestamp: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		Duration:  1500 * time.Millisecond,
+		Error:     fmt.Errorf("connection refused"),
+	}
+
+	// Act
+	data, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	var decoded CheckHistoryEntry
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// Assert
+	assert.JSONEq(t, `{"status":"down","timestamp":"2022-01-02T03:04:05Z","duration":"1.5s","error":"connection refused"}`, string(data))
+	assert.Equal(t, entry.Status, decoded.Status)
+	assert.Equal(t, entry.Timestamp, decoded.Timestamp)
+	assert.Equal(t, entry.Duration, decoded.Duration)
+	assert.EqualError(t, decoded.Error, "connection refused")
+}
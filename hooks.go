@@ -0,0 +1,69 @@
+package health
+
+import "context"
+
+// LifecycleHooks are called when the result of a check changes between success and failure
+// (see Check.Hooks and WithLifecycleHooks). In contrast to status listeners, hooks are based on
+// the results of check executions instead of the availability status, so they are not affected by
+// failure thresholds (see Check.MaxContiguousFails). A DegradedError counts as a success. Hooks are
+// not called for skipped checks (see StatusSkipped). All hooks are optional.
+type LifecycleHooks struct {
+	// OnFirstFailure is called when a check fails after it succeeded before (or after its first execution).
+	OnFirstFailure func(ctx context.Context, name string, state CheckState)
+	// OnStillFailing is called for every failure that follows another failure. The number of
+	// contiguous failures (see CheckState.ContiguousFails) is at least 2.
+	OnStillFailing func(ctx context.Context, name string, state CheckState, contiguousFails uint)
+	// OnRecovered is called when a check succeeds after it failed before.
+	OnRecovered func(ctx context.Context, name string, state CheckState)
+}
+
+// WithLifecycleHooks registers hooks that are called for all checks (see LifecycleHooks).
+// This option can be used multiple times. Hooks are executed in the order they were
+// registered, before the hooks of the check itself (see Check.Hooks).
+func WithLifecycleHooks(hooks LifecycleHooks) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.lifecycleHooks = append(cfg.lifecycleHooks, hooks)
+	}
+}
+
+// callLifecycleHooks calls the lifecycle hooks that are due after a check execution.
+func callLifecycleHooks(ctx context.Context, cfg *checkerConfig, check *Check, oldState, newState CheckState) {
+	// The check function was not executed (e.g., because an interceptor skipped it).
+	if newState.CheckCount == oldState.CheckCount {
+		return
+	}
+
+	if cfg.silences.isSilenced(cfg.clock.Now(), check) {
+		return
+	}
+
+	for _, hooks := range cfg.lifecycleHooks {
+		hooks := hooks
+		callListener(cfg, "lifecycle hooks", func() {
+			hooks.call(ctx, check.Name, oldState, newState)
+		})
+	}
+
+	if check.Hooks.OnFirstFailure != nil || check.Hooks.OnStillFailing != nil || check.Hooks.OnRecovered != nil {
+		callListener(cfg, "lifecycle hooks of check "+check.Name, func() {
+			check.Hooks.call(ctx, check.Name, oldState, newState)
+		})
+	}
+}
+
+func (h *LifecycleHooks) call(ctx context.Context, name string, oldState, newState CheckState) {
+	switch {
+	case newState.ContiguousFails == 1:
+		if h.OnFirstFailure != nil {
+			h.OnFirstFailure(ctx, name, newState)
+		}
+	case newState.ContiguousFails > 1:
+		if h.OnStillFailing != nil {
+			h.OnStillFailing(ctx, name, newState, newState.ContiguousFails)
+		}
+	case oldState.ContiguousFails > 0:
+		if h.OnRecovered != nil {
+			h.OnRecovered(ctx, name, newState)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleHooksAreCalledOnResultTransitions(t *testing.T) {
+	// Arrange
+	var (
+		results      = []error{nil, errors.New("failed"), errors.New("failed"), errors.New("failed"), nil, nil}
+		executions   int
+		checkEvents  []string
+		globalEvents []string
+	)
+
+	hooks := func(events *[]string) LifecycleHooks {
+		return LifecycleHooks{
+			OnFirstFailure: func(ctx context.Context, name string, state CheckState) {
+				*events = append(*events, "failed")
+			},
+			OnStillFailing: func(ctx context.Context, name string, state CheckState, contiguousFails uint) {
+				*events = append(*events, fmt.Sprintf("still failing %d", contiguousFails))
+			},
+			OnRecovered: func(ctx context.Context, name string, state CheckState) {
+				*events = append(*events, "recovered")
+			},
+		}
+	}
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithLifecycleHooks(hooks(&globalEvents)),
+		WithCheck(Check{
+			Name:               "database",
+			MaxContiguousFails: 5,
+			Hooks:              hooks(&checkEvents),
+			Check: func(ctx context.Context) error {
+				executions++
+				return results[executions-1]
+			},
+		}),
+	)
+
+	// Act
+	for range results {
+		ckr.Check(context.Background())
+	}
+
+	// Assert
+	expected := []string{"failed", "still failing 2", "still failing 3", "recovered"}
+	assert.Equal(t, expected, checkEvents)
+	assert.Equal(t, expected, globalEvents)
+}
@@ -0,0 +1,60 @@
+package health
+
+import "net/http"
+
+type (
+	// HTTPMiddleware works like Middleware, but the middleware function also receives the http.ResponseWriter
+	// (see WithHTTPMiddleware). This allows middlewares to set response headers or to respond on their own
+	// (e.g., to reject unauthorized requests) without wrapping the handler externally.
+	HTTPMiddleware func(next HTTPMiddlewareFunc) HTTPMiddlewareFunc
+
+	// HTTPMiddlewareFunc is an HTTP middleware for a health Handler (see NewHandler). It is invoked each time
+	// an HTTP request is processed. If it writes a response (e.g., by calling http.ResponseWriter.WriteHeader)
+	// instead of invoking the next HTTPMiddlewareFunc, the handler does not write the CheckerResult.
+	HTTPMiddlewareFunc func(w http.ResponseWriter, r *http.Request) CheckerResult
+
+	// responseTracker records whether a response was written by an HTTP middleware.
+	responseTracker struct {
+		http.ResponseWriter
+		written bool
+	}
+)
+
+// WriteHeader implements http.ResponseWriter.WriteHeader.
+func (t *responseTracker) WriteHeader(statusCode int) {
+	t.written = true
+	t.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write implements http.ResponseWriter.Write.
+func (t *responseTracker) Write(data []byte) (int, error) {
+	t.written = true
+	return t.ResponseWriter.Write(data)
+}
+
+// Unwrap returns the underlying http.ResponseWriter (see http.ResponseController).
+func (t *responseTracker) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+// withHTTPMiddleware executes the HTTP middleware chain (see WithHTTPMiddleware), followed by the middleware
+// chain (see WithMiddleware). The second return value is false if a middleware has written a response already.
+func withHTTPMiddleware(
+	w http.ResponseWriter,
+	r *http.Request,
+	cfg *HandlerConfig,
+	target MiddlewareFunc,
+) (CheckerResult, bool) {
+	chain := func(w http.ResponseWriter, r *http.Request) CheckerResult {
+		return withMiddleware(cfg.middleware, target)(r)
+	}
+
+	for idx := len(cfg.httpMiddleware) - 1; idx >= 0; idx-- {
+		chain = cfg.httpMiddleware[idx](chain)
+	}
+
+	tracker := &responseTracker{ResponseWriter: w}
+	result := chain(tracker, r)
+
+	return result, !tracker.written
+}
@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPMiddlewareCanSetHeadersAndSeeTheResult(t *testing.T) {
+	// Arrange
+	var order []string
+	ckr := NewChecker(WithDisabledAutostart(), WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+		return nil
+	}}))
+	handler := NewHandler(ckr,
+		WithMiddleware(func(next MiddlewareFunc) MiddlewareFunc {
+			return func(r *http.Request) CheckerResult {
+				order = append(order, "middleware")
+				return next(r)
+			}
+		}),
+		WithHTTPMiddleware(func(next HTTPMiddlewareFunc) HTTPMiddlewareFunc {
+			return func(w http.ResponseWriter, r *http.Request) CheckerResult {
+				order = append(order, "http middleware "+r.RemoteAddr)
+				result := next(w, r)
+				w.Header().Set("X-Health-Status", string(result.Status))
+				return result
+			}
+		}),
+	)
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+	request.RemoteAddr = "10.0.0.1:1234"
+
+	// Act
+	handler.ServeHTTP(response, request)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "up", response.Header().Get("X-Health-Status"))
+	assert.Equal(t, []string{"http middleware 10.0.0.1:1234", "middleware"}, order)
+}
+
+func TestHTTPMiddlewareCanRespondOnItsOwn(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(WithDisabledAutostart(), WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}}))
+	handler := NewHandler(ckr, WithHTTPMiddleware(func(next HTTPMiddlewareFunc) HTTPMiddlewareFunc {
+		return func(w http.ResponseWriter, r *http.Request) CheckerResult {
+			if r.Header.Get("X-Api-Key") != "secret" {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return CheckerResult{}
+			}
+			return next(w, r)
+		}
+	}))
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	assert.Equal(t, "Forbidden\n", response.Body.String())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,112 @@
+package health
+
+import (
+	"net/http"
+	"strings"
+)
+
+type (
+	// Translation holds the localized wording of a single language (see MessageCatalog).
+	Translation struct {
+		// Statuses maps availability statuses to the localized values that are written instead
+		// (e.g., StatusUp -> "saludable"). Statuses without an entry are written unchanged.
+		Statuses map[AvailabilityStatus]string
+		// Messages holds localized human-readable messages that are written into the field "message" of the
+		// aggregated result and of each component. Messages are looked up by the error code of a component first
+		// (see Coder, e.g., "TIMEOUT"), and by its availability status second (e.g., "down").
+		Messages map[string]string
+	}
+
+	// MessageCatalog maps language tags (e.g., "es" or "de-AT") to translations (see WithJSONMessageCatalog).
+	MessageCatalog map[string]Translation
+)
+
+// WithJSONMessageCatalog localizes the JSON output of a JSONResultWriter. The language is selected for each
+// request based on its "Accept-Language" header. A language tag that is not part of the catalog also matches
+// the translation of its primary language (e.g., "es-MX" matches "es"). If none of the accepted languages is
+// part of the catalog, the translation of defaultLanguage is used (no translation is applied if
+// defaultLanguage is empty or not part of the catalog). The selected language is reported in the
+// "Content-Language" header of the response. Localized statuses take precedence over WithJSONStatusMapper.
+func WithJSONMessageCatalog(catalog MessageCatalog, defaultLanguage string) JSONResultWriterOption {
+	normalized := make(MessageCatalog, len(catalog))
+	for tag, translation := range catalog {
+		normalized[strings.ToLower(tag)] = translation
+	}
+
+	return func(rw *JSONResultWriter) {
+		rw.messageCatalog = normalized
+		rw.defaultLanguage = strings.ToLower(defaultLanguage)
+	}
+}
+
+// contentLanguage returns the language of the response that the result writer writes for the request
+// (see WithJSONMessageCatalog), or an empty string if the response is not localized.
+func contentLanguage(writer ResultWriter, r *http.Request) string {
+	if rw, ok := writer.(*JSONResultWriter); ok {
+		language, _, _ := rw.selectTranslation(r)
+		return language
+	}
+	return ""
+}
+
+// selectTranslation selects the translation for the request (see WithJSONMessageCatalog). It returns
+// the selected language tag along with the translation, or false if no translation is applied.
+func (rw *JSONResultWriter) selectTranslation(r *http.Request) (string, *Translation, bool) {
+	if len(rw.messageCatalog) == 0 {
+		return "", nil, false
+	}
+
+	for _, language := range parseAcceptLanguage(r.Header.Get("Accept-Language")) {
+		candidates := []string{language.value}
+		if i := strings.Index(language.value, "-"); i > 0 {
+			candidates = append(candidates, language.value[:i])
+		}
+
+		for _, tag := range candidates {
+			if translation, ok := rw.messageCatalog[tag]; ok {
+				return tag, &translation, true
+			}
+		}
+	}
+
+	if translation, ok := rw.messageCatalog[rw.defaultLanguage]; ok {
+		return rw.defaultLanguage, &translation, true
+	}
+
+	return "", nil, false
+}
+
+// addMessage adds the localized message to the fields of the aggregated result or a component (see Translation).
+func (t *Translation) addMessage(fields map[string]interface{}) {
+	status, _ := fields["status"].(string)
+	code, _ := fields["code"].(string)
+
+	if message, ok := t.Messages[code]; ok && code != "" {
+		fields["message"] = message
+	} else if message, ok := t.Messages[status]; ok {
+		fields["message"] = message
+	}
+}
+
+// localizedStatus returns the localized value of the status (see Translation). It returns false
+// if the translation is nil or holds no value for the status.
+func (t *Translation) localizedStatus(status AvailabilityStatus) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+
+	localized, ok := t.Statuses[status]
+	return localized, ok
+}
+
+// parseAcceptLanguage parses the value of an "Accept-Language" header and returns all acceptable
+// language tags in lower case, ordered by their quality (highest first). The wildcard "*" is ignored.
+func parseAcceptLanguage(acceptLanguage string) []qualityValue {
+	var languages []qualityValue
+	for _, language := range parseQualityValues(acceptLanguage) {
+		if language.value != "*" && language.quality > 0 {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONResultWriterWithMessageCatalog(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return NewCodedError("AUTH", errors.New("invalid credentials"))
+		}}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error { return nil }}),
+	)
+	writer := NewJSONResultWriter(WithJSONMessageCatalog(MessageCatalog{
+		"es": {
+			Statuses: map[AvailabilityStatus]string{StatusUp: "saludable", StatusDown: "caído"},
+			Messages: map[string]string{"AUTH": "Credenciales no válidas", "down": "El servicio no está disponible"},
+		},
+		"de": {
+			Statuses: map[AvailabilityStatus]string{StatusDown: "nicht verfügbar"},
+		},
+	}, "de"))
+	handler := NewHandler(ckr, WithResultWriter(writer))
+
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+	request.Header.Set("Accept-Language", "fr;q=0.9, es-MX")
+
+	// Act
+	spanish := httptest.NewRecorder()
+	handler.ServeHTTP(spanish, request)
+	fallback := httptest.NewRecorder()
+	handler.ServeHTTP(fallback, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(spanish.Body.Bytes(), &result))
+	assert.Equal(t, "es", spanish.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", spanish.Header().Get("Vary"))
+	assert.Equal(t, "caído", result["status"])
+	assert.Equal(t, "El servicio no está disponible", result["message"])
+
+	details := result["details"].(map[string]interface{})
+	database := details["database"].(map[string]interface{})
+	assert.Equal(t, "caído", database["status"])
+	assert.Equal(t, "Credenciales no válidas", database["message"])
+	assert.Equal(t, "invalid credentials", database["error"])
+	assert.Equal(t, "saludable", details["search"].(map[string]interface{})["status"])
+
+	var fallbackResult map[string]interface{}
+	require.NoError(t, json.Unmarshal(fallback.Body.Bytes(), &fallbackResult))
+	assert.Equal(t, "de", fallback.Header().Get("Content-Language"))
+	assert.Equal(t, "nicht verfügbar", fallbackResult["status"])
+	assert.NotContains(t, fallbackResult, "message")
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	languages := parseAcceptLanguage("de-AT;q=0.5, *, EN;q=0.8, fr;q=0, it;q=invalid, es")
+
+	assert.Equal(t, []qualityValue{
+		{value: "es", quality: 1},
+		{value: "en", quality: 0.8},
+		{value: "de-at", quality: 0.5},
+	}, languages)
+}
@@ -0,0 +1,189 @@
+package health
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type (
+	// IncidentStats holds downtime statistics of a component or of the aggregated system status
+	// (see Checker.GetIncidentStats). An incident starts when the status changes to StatusDown and ends
+	// when the status changes to StatusUp or StatusDegraded. Other statuses (e.g., StatusSkipped) neither
+	// start nor end an incident. Statistics are recorded since the Checker was created.
+	// Attention: This type is converted from/to JSON using a custom marshalling/unmarshalling function
+	// (see type jsonIncidentStats), because durations are formatted as strings.
+	IncidentStats struct {
+		// Incidents holds the number of incidents, including an ongoing incident.
+		Incidents uint `json:"incidents"`
+		// Downtime holds the cumulative duration of all incidents, including an ongoing incident.
+		Downtime time.Duration `json:"downtime"`
+		// LastIncidentDuration holds the duration of the most recent incident. If an incident is ongoing,
+		// it holds for how long it has been going on so far.
+		LastIncidentDuration time.Duration `json:"lastIncidentDuration,omitempty"`
+		// MeanTimeToRecovery holds the average duration of all incidents that have ended.
+		MeanTimeToRecovery time.Duration `json:"meanTimeToRecovery,omitempty"`
+		// OngoingSince holds the time when the ongoing incident started. It is zero if there is no ongoing incident.
+		OngoingSince time.Time `json:"ongoingSince,omitempty"`
+	}
+
+	jsonIncidentStats struct {
+		Incidents            uint       `json:"incidents"`
+		Downtime             string     `json:"downtime"`
+		LastIncidentDuration string     `json:"lastIncidentDuration,omitempty"`
+		MeanTimeToRecovery   string     `json:"meanTimeToRecovery,omitempty"`
+		OngoingSince         *time.Time `json:"ongoingSince,omitempty"`
+	}
+
+	incidentTracker struct {
+		incidents            uint
+		resolvedIncidents    uint
+		resolvedDowntime     time.Duration
+		lastIncidentDuration time.Duration
+		ongoingSince         time.Time
+	}
+)
+
+// WithIncidentStats adds the downtime statistics of the aggregated system status and of each component to
+// every CheckerResult (see CheckerResult.Incidents and CheckResult.Incidents). Statistics are always available
+// via Checker.GetIncidentStats and Checker.GetSystemIncidentStats, regardless of this option.
+func WithIncidentStats() CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.incidentStatsInResults = true
+	}
+}
+
+// GetIncidentStats implements Checker.GetIncidentStats. Please refer to Checker.GetIncidentStats for more information.
+func (ck *defaultChecker) GetIncidentStats(name string) (IncidentStats, bool) {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return IncidentStats{}, false
+	}
+
+	return ck.incidents[name].stats(ck.cfg.clock.Now()), true
+}
+
+// GetSystemIncidentStats implements Checker.GetSystemIncidentStats.
+// Please refer to Checker.GetSystemIncidentStats for more information.
+func (ck *defaultChecker) GetSystemIncidentStats() IncidentStats {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	return ck.systemIncidents.stats(ck.cfg.clock.Now())
+}
+
+// trackIncident records the status of the check for its downtime statistics. Must be called while holding the state lock.
+func (ck *defaultChecker) trackIncident(update checkResult) {
+	tracker, ok := ck.incidents[update.checkName]
+	if !ok {
+		tracker = &incidentTracker{}
+		ck.incidents[update.checkName] = tracker
+	}
+
+	tracker.record(ck.cfg.clock.Now(), update.newState.Status)
+}
+
+// incidentStatsResult returns the downtime statistics of a check for a CheckerResult (see WithIncidentStats),
+// or nil if they are not enabled. An empty name returns the statistics of the aggregated system status.
+// Must be called while holding ck.mtx or the state lock.
+func (ck *defaultChecker) incidentStatsResult(name string) *IncidentStats {
+	if !ck.cfg.incidentStatsInResults {
+		return nil
+	}
+
+	tracker := &ck.systemIncidents
+	if name != "" {
+		tracker = ck.incidents[name]
+	}
+
+	stats := tracker.stats(ck.cfg.clock.Now())
+	return &stats
+}
+
+func (t *incidentTracker) record(now time.Time, status AvailabilityStatus) {
+	switch {
+	case status == StatusDown && t.ongoingSince.IsZero():
+		t.incidents++
+		t.ongoingSince = now
+	case (status == StatusUp || status == StatusDegraded) && !t.ongoingSince.IsZero():
+		t.lastIncidentDuration = now.Sub(t.ongoingSince)
+		t.resolvedIncidents++
+		t.resolvedDowntime += t.lastIncidentDuration
+		t.ongoingSince = time.Time{}
+	}
+}
+
+// stats returns the statistics at the given time. It is safe to call this function on a nil tracker.
+func (t *incidentTracker) stats(now time.Time) IncidentStats {
+	if t == nil {
+		return IncidentStats{}
+	}
+
+	stats := IncidentStats{
+		Incidents:            t.incidents,
+		Downtime:             t.resolvedDowntime,
+		LastIncidentDuration: t.lastIncidentDuration,
+		OngoingSince:         t.ongoingSince,
+	}
+
+	if !t.ongoingSince.IsZero() {
+		stats.LastIncidentDuration = now.Sub(t.ongoingSince)
+		stats.Downtime += stats.LastIncidentDuration
+	}
+
+	if t.resolvedIncidents > 0 {
+		stats.MeanTimeToRecovery = t.resolvedDowntime / time.Duration(t.resolvedIncidents)
+	}
+
+	return stats
+}
+
+// MarshalJSON provides a custom marshaller for the IncidentStats type.
+func (s IncidentStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonIncidentStats{
+		Incidents:            s.Incidents,
+		Downtime:             s.Downtime.String(),
+		LastIncidentDuration: durationOrEmpty(s.LastIncidentDuration),
+		MeanTimeToRecovery:   durationOrEmpty(s.MeanTimeToRecovery),
+		OngoingSince:         timeOrNil(s.OngoingSince),
+	})
+}
+
+func (s *IncidentStats) UnmarshalJSON(data []byte) error {
+	var stats jsonIncidentStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return err
+	}
+
+	*s = IncidentStats{Incidents: stats.Incidents}
+
+	for target, value := range map[*time.Duration]string{
+		&s.Downtime:             stats.Downtime,
+		&s.LastIncidentDuration: stats.LastIncidentDuration,
+		&s.MeanTimeToRecovery:   stats.MeanTimeToRecovery,
+	} {
+		if value == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*target = duration
+	}
+
+	if stats.OngoingSince != nil {
+		s.OngoingSince = *stats.OngoingSince
+	}
+
+	return nil
+}
+
+func durationOrEmpty(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
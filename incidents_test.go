@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncidentStatsAreRecorded(t *testing.T) {
+	// Arrange
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	failing := true
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			if failing {
+				return errors.New("connection refused")
+			}
+			return nil
+		}}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	clock.advance(2 * time.Minute)
+	failing = false
+	ckr.Check(context.Background())
+	clock.advance(10 * time.Minute)
+	failing = true
+	ckr.Check(context.Background())
+	clock.advance(4 * time.Minute)
+	failing = false
+	ckr.Check(context.Background())
+	clock.advance(1 * time.Minute)
+	failing = true
+	ckr.Check(context.Background())
+	clock.advance(30 * time.Second)
+
+	// Assert
+	stats, ok := ckr.GetIncidentStats("database")
+	require.True(t, ok)
+	assert.Equal(t, IncidentStats{
+		Incidents:            3,
+		Downtime:             6*time.Minute + 30*time.Second,
+		LastIncidentDuration: 30 * time.Second,
+		MeanTimeToRecovery:   3 * time.Minute,
+		OngoingSince:         clock.Now().Add(-30 * time.Second),
+	}, stats)
+	assert.Equal(t, stats, ckr.GetSystemIncidentStats())
+
+	_, ok = ckr.GetIncidentStats("unknown")
+	assert.False(t, ok)
+}
+
+func TestIncidentStatsAreAddedToResults(t *testing.T) {
+	// Arrange
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithClock(clock),
+		WithIncidentStats(),
+		WithCheck(Check{Name: "database", NonCritical: true, Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	clock.advance(1 * time.Minute)
+	res := ckr.Check(context.Background())
+
+	// Assert
+	require.NotNil(t, res.Incidents)
+	assert.Equal(t, IncidentStats{}, *res.Incidents)
+	require.NotNil(t, res.Details["database"].Incidents)
+	assert.Equal(t, uint(1), res.Details["database"].Incidents.Incidents)
+	assert.Equal(t, 1*time.Minute, res.Details["database"].Incidents.Downtime)
+}
+
+func TestIncidentStatsJSONRoundTrip(t *testing.T) {
+	// Arrange
+	stats := IncidentStats{
+		Incidents:            2,
+		Downtime:             90 * time.Second,
+		LastIncidentDuration: 30 * time.Second,
+		MeanTimeToRecovery:   1 * time.Minute,
+	}
+
+	// Act
+	data, err := json.Marshal(stats)
+	require.NoError(t, err)
+
+	var decoded IncidentStats
+	err = json.Unmarshal(data, &decoded)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"incidents":2,"downtime":"1m30s","lastIncidentDuration":"30s","meanTimeToRecovery":"1m0s"}`,
+		string(data))
+	assert.Equal(t, stats, decoded)
+}
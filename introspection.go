@@ -0,0 +1,119 @@
+package health
+
+import (
+	"sort"
+	"time"
+)
+
+// CheckInfo summarizes the configuration of a check (see Checker.ListChecks).
+type CheckInfo struct {
+	// Name is the name of the check (see Check.Name).
+	Name string
+	// Periodic is true if the check is executed periodically in the background (see WithPeriodicCheck).
+	Periodic bool
+	// RefreshPeriod is the time between two executions of a periodic check (see WithPeriodicCheck).
+	// It is 0 for synchronous checks and for checks that are executed according to a schedule.
+	RefreshPeriod time.Duration
+	// InitialDelay is the time to wait before the first execution of a periodic check (see WithPeriodicCheck).
+	InitialDelay time.Duration
+	// Schedule is the cron expression of the check (see Check.Schedule).
+	Schedule string
+	// Timeout is the check timeout (see Check.Timeout). It is 0 if no check specific timeout is configured.
+	Timeout time.Duration
+	// CacheDuration is the effective cache duration of a synchronous check (see Check.CacheDuration
+	// and WithCacheDuration).
+	CacheDuration time.Duration
+	// NonCritical is true if the check does not affect the aggregated status (see Check.NonCritical).
+	NonCritical bool
+	// Priority is the execution priority of the check (see Check.Priority).
+	Priority int
+	// DependsOn holds the names of the checks this check depends on (see Check.DependsOn).
+	DependsOn []string
+	// Tags holds the tags of the check (see Check.Tags).
+	Tags []string
+	// Probes holds the probe classification of the check (see Check.Probes).
+	Probes []Probe
+	// NextRunAt holds the time of the next scheduled execution of a periodic check. It is zero
+	// for synchronous checks and for periodic checks that are not running (see Checker.Start).
+	NextRunAt time.Time
+}
+
+// GetState implements Checker.GetState. Please refer to Checker.GetState for more information.
+func (ck *defaultChecker) GetState() CheckerState {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	state := copyCheckerState(ck.state)
+	for name, checkState := range state.CheckState {
+		checkState.Data = copyData(checkState.Data)
+		checkState.statusChanges = append([]time.Time(nil), checkState.statusChanges...)
+		checkState.recentFailures = append([]bool(nil), checkState.recentFailures...)
+		state.CheckState[name] = checkState
+	}
+
+	return state
+}
+
+// ListChecks implements Checker.ListChecks. Please refer to Checker.ListChecks for more information.
+func (ck *defaultChecker) ListChecks() []CheckInfo {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	checks := make([]CheckInfo, 0, len(ck.cfg.checks))
+	for _, check := range ck.cfg.checks {
+		info := CheckInfo{
+			Name:         check.Name,
+			Periodic:     isPeriodicCheck(check),
+			InitialDelay: check.initialDelay,
+			Schedule:     check.Schedule,
+			Timeout:      check.Timeout,
+			NonCritical:  check.NonCritical,
+			Priority:     check.Priority,
+			DependsOn:    append([]string(nil), check.DependsOn...),
+			Tags:         append([]string(nil), check.Tags...),
+			Probes:       append([]Probe(nil), check.Probes...),
+			NextRunAt:    ck.nextRuns[check],
+		}
+
+		if check.Schedule == "" {
+			info.RefreshPeriod = check.updateInterval
+		}
+
+		if !info.Periodic {
+			info.CacheDuration = ck.cacheTTL(check)
+		}
+
+		checks = append(checks, info)
+	}
+
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+
+	return checks
+}
+
+// setNextRun records the time of the next execution of a periodic check (see CheckInfo.NextRunAt).
+// A delay of 0 removes the recorded time.
+func (ck *defaultChecker) setNextRun(check *Check, delay time.Duration) {
+	ck.stateMtx.Lock()
+	defer ck.stateMtx.Unlock()
+
+	if delay == 0 {
+		delete(ck.nextRuns, check)
+		return
+	}
+
+	ck.nextRuns[check] = ck.cfg.clock.Now().Add(delay)
+}
+
+func copyData(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		result[key] = value
+	}
+
+	return result
+}
@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStateReturnsDeepCopy(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", CheckWithData: func(ctx context.Context) (map[string]interface{}, error) {
+			return map[string]interface{}{"connections": 5}, nil
+		}}),
+	)
+	ckr.Check(context.Background())
+
+	// Act
+	state := ckr.GetState()
+	state.CheckState["database"].Data["connections"] = 10
+	delete(state.CheckState, "database")
+
+	// Assert
+	assert.Equal(t, StatusUp, state.Status)
+	actual := ckr.GetState()
+	assert.Equal(t, 5, actual.CheckState["database"].Data["connections"])
+	assert.Equal(t, StatusUp, actual.CheckState["database"].Status)
+}
+
+func TestListChecksSummarizesConfiguration(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithCacheDuration(5*time.Second),
+		WithCheck(Check{
+			Name:      "search",
+			Timeout:   1 * time.Second,
+			Priority:  2,
+			DependsOn: []string{"database"},
+			Tags:      []string{"ready"},
+			Check:     func(ctx context.Context) error { return nil },
+		}),
+		WithPeriodicCheck(1*time.Hour, 0, Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	defer ckr.Stop()
+
+	// Act
+	var checks []CheckInfo
+	assert.Eventually(t, func() bool {
+		checks = ckr.ListChecks()
+		return !checks[0].NextRunAt.IsZero()
+	}, 1*time.Second, 10*time.Millisecond)
+
+	// Assert
+	assert.Len(t, checks, 2)
+
+	assert.Equal(t, "database", checks[0].Name)
+	assert.True(t, checks[0].Periodic)
+	assert.Equal(t, 1*time.Hour, checks[0].RefreshPeriod)
+	assert.Zero(t, checks[0].CacheDuration)
+	assert.WithinDuration(t, time.Now().Add(1*time.Hour), checks[0].NextRunAt, 1*time.Second)
+
+	assert.Equal(t, CheckInfo{
+		Name:          "search",
+		Timeout:       1 * time.Second,
+		CacheDuration: 5 * time.Second,
+		Priority:      2,
+		DependsOn:     []string{"database"},
+		Tags:          []string{"ready"},
+	}, checks[1])
+}
@@ -0,0 +1,222 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	// JSONLinesOption is a configuration option for a JSON Lines export (see WithJSONLinesExport).
+	JSONLinesOption func(cfg *jsonLinesConfig)
+
+	jsonLinesConfig struct {
+		maxSize    int64
+		maxAge     time.Duration
+		maxBackups int
+		retention  time.Duration
+		queueSize  int
+	}
+
+	jsonLinesRecord struct {
+		Timestamp time.Time             `json:"timestamp"`
+		Status    AvailabilityStatus    `json:"status"`
+		Checks    map[string]CheckState `json:"checks,omitempty"`
+	}
+
+	// jsonLinesPublisher is only called from a single goroutine (see publisherWorker),
+	// so no synchronization is required.
+	jsonLinesPublisher struct {
+		path     string
+		cfg      jsonLinesConfig
+		now      func() time.Time
+		openedAt time.Time
+	}
+)
+
+// jsonLinesBackupTimeFormat is the format of the timestamp that is added to the names of rotated files.
+const jsonLinesBackupTimeFormat = "2006-01-02T15-04-05.000"
+
+// WithJSONLinesExport appends the state of the Checker to a file in JSON Lines format after every check
+// evaluation, so that a sidecar or log shipper can pick up the history of check results. Each line holds
+// a JSON object with the fields "timestamp", "status" (the aggregated system status) and "checks" (the
+// state of each check, see CheckState). The file is rotated when it exceeds a size limit
+// (see WithJSONLinesMaxSize) or age limit (see WithJSONLinesMaxAge). Rotated files are renamed
+// by adding the time of rotation to the file name (e.g., "health-2024-01-01T00-00-00.000.jsonl").
+// Lines are written asynchronously by a Publisher (see WithPublisher). Errors are passed to the publisher
+// error handler (see WithPublisherErrorHandler).
+func WithJSONLinesExport(path string, options ...JSONLinesOption) CheckerOption {
+	cfg := jsonLinesConfig{queueSize: 100}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return WithPublisher(&jsonLinesPublisher{path: path, cfg: cfg, now: time.Now}, cfg.queueSize)
+}
+
+// WithJSONLinesMaxSize rotates the file when writing the next line would make it exceed the
+// provided size in bytes. A value of 0 disables size-based rotation (default).
+func WithJSONLinesMaxSize(bytes int64) JSONLinesOption {
+	return func(cfg *jsonLinesConfig) {
+		cfg.maxSize = bytes
+	}
+}
+
+// WithJSONLinesMaxAge rotates the file when it has been written to for longer than the provided duration
+// (e.g., 24 hours for daily files). A value of 0 disables time-based rotation (default).
+func WithJSONLinesMaxAge(maxAge time.Duration) JSONLinesOption {
+	return func(cfg *jsonLinesConfig) {
+		cfg.maxAge = maxAge
+	}
+}
+
+// WithJSONLinesMaxBackups sets how many rotated files are retained. Older files are deleted.
+// A value of 0 retains all rotated files (default), unless a retention period is set
+// (see WithJSONLinesRetention).
+func WithJSONLinesMaxBackups(maxBackups int) JSONLinesOption {
+	return func(cfg *jsonLinesConfig) {
+		cfg.maxBackups = maxBackups
+	}
+}
+
+// WithJSONLinesRetention sets for how long rotated files are retained. Older files are deleted.
+// A value of 0 retains rotated files regardless of their age (default).
+func WithJSONLinesRetention(retention time.Duration) JSONLinesOption {
+	return func(cfg *jsonLinesConfig) {
+		cfg.retention = retention
+	}
+}
+
+// WithJSONLinesQueueSize sets how many check evaluations can be buffered while lines
+// are being written (see WithPublisher). Default is 100.
+func WithJSONLinesQueueSize(queueSize int) JSONLinesOption {
+	return func(cfg *jsonLinesConfig) {
+		cfg.queueSize = queueSize
+	}
+}
+
+// Publish implements Publisher.Publish.
+func (p *jsonLinesPublisher) Publish(_ context.Context, state CheckerState) error {
+	now := p.now()
+
+	line, err := json.Marshal(jsonLinesRecord{Timestamp: now.UTC(), Status: state.Status, Checks: state.CheckState})
+	if err != nil {
+		return fmt.Errorf("cannot marshal check results: %w", err)
+	}
+	line = append(line, '\n')
+
+	if err := p.rotateIfRequired(now, int64(len(line))); err != nil {
+		return err
+	}
+
+	// The file is opened for each line, so that no file handle is left open when the Checker is stopped.
+	file, err := os.OpenFile(p.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot open JSON lines file: %w", err)
+	}
+
+	if _, err := file.Write(line); err != nil {
+		file.Close()
+		return fmt.Errorf("cannot write JSON lines file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("cannot write JSON lines file: %w", err)
+	}
+
+	if p.openedAt.IsZero() {
+		p.openedAt = now
+	}
+
+	return nil
+}
+
+// rotateIfRequired renames the current file if writing the next line would exceed a size or age limit,
+// and deletes rotated files that are not retained anymore.
+func (p *jsonLinesPublisher) rotateIfRequired(now time.Time, lineSize int64) error {
+	info, err := os.Stat(p.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("cannot read JSON lines file: %w", err)
+	}
+
+	exceedsSize := p.cfg.maxSize > 0 && info.Size() > 0 && info.Size()+lineSize > p.cfg.maxSize
+	exceedsAge := p.cfg.maxAge > 0 && !p.openedAt.IsZero() && now.Sub(p.openedAt) >= p.cfg.maxAge
+	if !exceedsSize && !exceedsAge {
+		return nil
+	}
+
+	if err := os.Rename(p.path, p.backupPath(now)); err != nil {
+		return fmt.Errorf("cannot rotate JSON lines file: %w", err)
+	}
+	p.openedAt = time.Time{}
+
+	return p.removeExpiredBackups(now)
+}
+
+func (p *jsonLinesPublisher) backupPath(rotatedAt time.Time) string {
+	ext := filepath.Ext(p.path)
+	return strings.TrimSuffix(p.path, ext) + "-" + rotatedAt.UTC().Format(jsonLinesBackupTimeFormat) + ext
+}
+
+// removeExpiredBackups deletes rotated files that exceed the maximum number of backups
+// (see WithJSONLinesMaxBackups) or the retention period (see WithJSONLinesRetention).
+func (p *jsonLinesPublisher) removeExpiredBackups(now time.Time) error {
+	if p.cfg.maxBackups <= 0 && p.cfg.retention <= 0 {
+		return nil
+	}
+
+	var (
+		ext     = filepath.Ext(p.path)
+		prefix  = strings.TrimSuffix(filepath.Base(p.path), ext) + "-"
+		backups []string
+	)
+
+	entries, err := os.ReadDir(filepath.Dir(p.path))
+	if err != nil {
+		return fmt.Errorf("cannot read rotated JSON lines files: %w", err)
+	}
+
+	rotatedAt := map[string]time.Time{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		t, err := time.Parse(jsonLinesBackupTimeFormat, timestamp)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, name)
+		rotatedAt[name] = t
+	}
+
+	// Newest files first.
+	sort.Slice(backups, func(i, j int) bool {
+		return rotatedAt[backups[i]].After(rotatedAt[backups[j]])
+	})
+
+	for i, name := range backups {
+		tooMany := p.cfg.maxBackups > 0 && i >= p.cfg.maxBackups
+		tooOld := p.cfg.retention > 0 && now.Sub(rotatedAt[name]) > p.cfg.retention
+		if !tooMany && !tooOld {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(filepath.Dir(p.path), name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot remove rotated JSON lines file: %w", err)
+		}
+	}
+
+	return nil
+}
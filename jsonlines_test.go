@@ -0,0 +1,133 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJSONLinesPublisher(path string, now *time.Time, options ...JSONLinesOption) *jsonLinesPublisher {
+	cfg := jsonLinesConfig{}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	return &jsonLinesPublisher{path: path, cfg: cfg, now: func() time.Time { return *now }}
+}
+
+func readJSONLines(t *testing.T, path string) []map[string]interface{} {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func TestJSONLinesExportAppendsEvaluations(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "health.jsonl")
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+		WithJSONLinesExport(path),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	ckr.Check(context.Background())
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil && len(readJSONLines(t, path)) >= 2
+	}, 1*time.Second, 10*time.Millisecond)
+
+	lines := readJSONLines(t, path)
+	assert.Equal(t, "down", lines[1]["status"])
+	database := lines[1]["checks"].(map[string]interface{})["database"].(map[string]interface{})
+	assert.Equal(t, "down", database["status"])
+	assert.Equal(t, "connection refused", database["error"])
+	assert.Equal(t, float64(2), database["checkCount"])
+}
+
+func TestJSONLinesExportRotatesBySize(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	path := filepath.Join(dir, "health.jsonl")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	publisher := newTestJSONLinesPublisher(path, &now, WithJSONLinesMaxSize(50), WithJSONLinesMaxBackups(2))
+	state := CheckerState{Status: StatusUp}
+
+	// Act
+	for i := 0; i < 4; i++ {
+		require.NoError(t, publisher.Publish(context.Background(), state))
+		now = now.Add(1 * time.Second)
+	}
+
+	// Assert
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	assert.Equal(t, []string{
+		"health-2024-01-01T00-00-02.000.jsonl",
+		"health-2024-01-01T00-00-03.000.jsonl",
+		"health.jsonl",
+	}, names)
+	assert.Len(t, readJSONLines(t, path), 1)
+}
+
+func TestJSONLinesExportRotatesByAgeAndAppliesRetention(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	path := filepath.Join(dir, "health.jsonl")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	publisher := newTestJSONLinesPublisher(path, &now, WithJSONLinesMaxAge(1*time.Hour),
+		WithJSONLinesRetention(90*time.Minute))
+	state := CheckerState{Status: StatusUp}
+
+	// Act
+	require.NoError(t, publisher.Publish(context.Background(), state))
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, publisher.Publish(context.Background(), state))
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, publisher.Publish(context.Background(), state))
+	now = now.Add(1 * time.Hour)
+	require.NoError(t, publisher.Publish(context.Background(), state))
+	now = now.Add(1 * time.Hour)
+	require.NoError(t, publisher.Publish(context.Background(), state))
+
+	// Assert
+	_, err := os.Stat(filepath.Join(dir, "health-2024-01-01T01-00-00.000.jsonl"))
+	assert.True(t, os.IsNotExist(err))
+
+	rotated := readJSONLines(t, filepath.Join(dir, "health-2024-01-01T02-00-00.000.jsonl"))
+	assert.Len(t, rotated, 1)
+	assert.Len(t, readJSONLines(t, path), 1)
+}
@@ -0,0 +1,89 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// ResultLimits limits the size of the results that are written by a handler (see WithResultLimits).
+// A value of 0 disables the corresponding limit.
+type ResultLimits struct {
+	// MaxDetails is the maximum number of components that are included in a result. Components whose
+	// status is not StatusUp are included first, followed by the others. Both are ordered alphabetically.
+	// The number of omitted components is reported in CheckerResult.OmittedDetails.
+	MaxDetails int
+	// MaxErrorLength is the maximum length of the error message of a component in bytes. Truncated
+	// messages end with "..." (which counts towards the limit) and are cut on a UTF-8 rune boundary.
+	MaxErrorLength int
+	// MaxDataValueLength is the maximum length of each value in the data of a component (see CheckResult.Data)
+	// in bytes. Values are truncated like error messages (see MaxErrorLength). Values that are not strings are
+	// limited by the length of their JSON representation and replaced by a string that holds the truncated JSON
+	// representation.
+	MaxDataValueLength int
+}
+
+// applyResultLimits truncates the result according to the limits (see WithResultLimits). Components and
+// their data are copied before they are modified, because they may be shared with the Checker.
+func applyResultLimits(result *CheckerResult, limits *ResultLimits) {
+	if limits == nil || len(result.Details) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(result.Details))
+	for name := range result.Details {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		iUp, jUp := result.Details[names[i]].Status == StatusUp, result.Details[names[j]].Status == StatusUp
+		if iUp != jUp {
+			return jUp
+		}
+		return names[i] < names[j]
+	})
+
+	if limits.MaxDetails > 0 && len(names) > limits.MaxDetails {
+		result.OmittedDetails = len(names) - limits.MaxDetails
+		names = names[:limits.MaxDetails]
+	}
+
+	details := make(map[string]CheckResult, len(names))
+	for _, name := range names {
+		details[name] = limitCheckResult(result.Details[name], limits)
+	}
+	result.Details = details
+}
+
+// limitCheckResult truncates the error message and data of a component (see ResultLimits).
+func limitCheckResult(result CheckResult, limits *ResultLimits) CheckResult {
+	if limits.MaxErrorLength > 0 && result.Error != nil && len(result.Error.Error()) > limits.MaxErrorLength {
+		result.Error = errors.New(truncate(result.Error.Error(), limits.MaxErrorLength))
+		result.Truncated = true
+	}
+
+	if limits.MaxDataValueLength <= 0 || len(result.Data) == 0 {
+		return result
+	}
+
+	data := make(map[string]interface{}, len(result.Data))
+	for key, value := range result.Data {
+		data[key] = value
+
+		if s, ok := value.(string); ok {
+			if len(s) > limits.MaxDataValueLength {
+				data[key] = truncate(s, limits.MaxDataValueLength)
+				result.Truncated = true
+			}
+			continue
+		}
+
+		if encoded, err := json.Marshal(value); err == nil && len(encoded) > limits.MaxDataValueLength {
+			data[key] = truncate(string(encoded), limits.MaxDataValueLength)
+			result.Truncated = true
+		}
+	}
+	result.Data = data
+
+	return result
+}
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerWithResultLimits(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "a", Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "b", Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "c", NonCritical: true, Check: func(ctx context.Context) error {
+			return errors.New(strings.Repeat("x", 100))
+		}}),
+		WithCheck(Check{Name: "d", CheckWithData: func(ctx context.Context) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"log":     strings.Repeat("y", 100),
+				"items":   []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				"version": "1.0",
+			}, nil
+		}}),
+	)
+	handler := NewHandler(ckr, WithResultLimits(ResultLimits{MaxDetails: 3, MaxErrorLength: 10, MaxDataValueLength: 10}))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	var result CheckerResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, 1, result.OmittedDetails)
+	require.Len(t, result.Details, 3)
+	assert.NotContains(t, result.Details, "d")
+
+	c := result.Details["c"]
+	assert.True(t, c.Truncated)
+	assert.Equal(t, strings.Repeat("x", 7)+"...", c.Error.Error())
+	assert.False(t, result.Details["a"].Truncated)
+
+	state, _ := ckr.GetCheckState("c")
+	assert.Len(t, state.Result.Error(), 100)
+}
+
+func TestLimitCheckResultTruncatesDataValues(t *testing.T) {
+	// Arrange
+	data := map[string]interface{}{
+		"log":     strings.Repeat("y", 100),
+		"items":   []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		"version": "1.0",
+	}
+
+	// Act
+	result := limitCheckResult(CheckResult{Status: StatusUp, Data: data}, &ResultLimits{MaxDataValueLength: 10})
+
+	// Assert
+	assert.True(t, result.Truncated)
+	assert.Equal(t, strings.Repeat("y", 7)+"...", result.Data["log"])
+	assert.Equal(t, "[1,2,3,...", result.Data["items"])
+	assert.Equal(t, "1.0", result.Data["version"])
+	assert.Len(t, data["log"], 100)
+}
+
+func TestTruncateCutsOnRuneBoundary(t *testing.T) {
+	const s = "grüße aus köln"
+
+	assert.Equal(t, "gr...", truncate(s, 6))
+	assert.Equal(t, "grü...", truncate(s, 7))
+	assert.Equal(t, "gr", truncate(s, 3))
+	assert.Equal(t, s, truncate(s, len(s)))
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+)
+
+// callListener calls a user-provided listener, such as a status listener or a lifecycle hook. A panicking
+// listener is recovered, so that it neither crashes the application nor affects check processing. If a
+// listener timeout is configured (see WithListenerTimeout), the caller stops waiting for the listener after
+// the timeout has passed. If asynchronous listeners are enabled (see WithAsyncListeners), the listener is
+// only queued. Panics, timeouts and dropped listener calls are logged (see WithLogger).
+func callListener(cfg *checkerConfig, name string, listener func()) {
+	if cfg.listenerQueue != nil {
+		select {
+		case cfg.listenerQueue <- func() { callListenerWithTimeout(cfg, name, listener) }:
+		default:
+			cfg.logger.Warn("health listener queue full", "listener", name)
+		}
+		return
+	}
+
+	callListenerWithTimeout(cfg, name, listener)
+}
+
+func callListenerWithTimeout(cfg *checkerConfig, name string, listener func()) {
+	if cfg.listenerTimeout <= 0 {
+		runListener(cfg.logger, name, listener)
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runListener(cfg.logger, name, listener)
+	}()
+
+	// Like check timeouts, listener timeouts are always based on the system clock (see WithClock).
+	timer := time.NewTimer(cfg.listenerTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		cfg.logger.Warn("health listener timed out", "listener", name, "timeout", cfg.listenerTimeout)
+	}
+}
+
+func runListener(logger Logger, name string, listener func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("health listener panicked", "listener", name,
+				"error", &PanicError{Value: r, Stack: debug.Stack()})
+		}
+	}()
+
+	listener()
+}
+
+// startListenerWorker starts a goroutine that calls queued listeners one after another
+// (see WithAsyncListeners). Must be called while holding ck.mtx.
+func (ck *defaultChecker) startListenerWorker(ctx context.Context) {
+	if ck.cfg.listenerQueue == nil {
+		return
+	}
+
+	ck.wg.Add(1)
+
+	go func() {
+		defer ck.wg.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case listener := <-ck.cfg.listenerQueue:
+				listener()
+			}
+		}
+	}()
+}
@@ -0,0 +1,101 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanickingListenerIsRecovered(t *testing.T) {
+	// Arrange
+	logger := &recordingLogger{}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithLogger(logger),
+		WithStatusListener(func(ctx context.Context, state CheckerState) { panic("boom") }),
+		WithCheck(Check{
+			Name:           "database",
+			Check:          func(ctx context.Context) error { return nil },
+			StatusListener: func(ctx context.Context, name string, state CheckState) { panic("boom") },
+		}),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, result.Status)
+	assert.Contains(t, logger.entries, "ERROR health listener panicked [listener status listener]")
+	assert.Contains(t, logger.entries, "ERROR health listener panicked [listener status listener of check database]")
+}
+
+func TestBlockingListenerTimesOut(t *testing.T) {
+	// Arrange
+	logger := &recordingLogger{}
+	release := make(chan struct{})
+	defer close(release)
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithLogger(logger),
+		WithListenerTimeout(10*time.Millisecond),
+		WithStatusListener(func(ctx context.Context, state CheckerState) { <-release }),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, result.Status)
+	assert.Contains(t, logger.entries, "WARN health listener timed out [listener status listener]")
+}
+
+func TestAsyncListenersDoNotBlockCheckProcessing(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	notified := make(chan AvailabilityStatus, 1)
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithAsyncListeners(10),
+		WithStatusListener(func(ctx context.Context, state CheckerState) {
+			<-release
+			notified <- state.Status
+		}),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Act
+	result := ckr.Check(context.Background())
+	close(release)
+
+	// Assert
+	assert.Equal(t, StatusUp, result.Status)
+	assert.Equal(t, StatusUp, <-notified)
+}
+
+func TestAsyncListenersDropCallsWhenQueueIsFull(t *testing.T) {
+	// Arrange
+	logger := &recordingLogger{}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithLogger(logger),
+		WithAsyncListeners(1),
+		WithStatusListener(func(ctx context.Context, state CheckerState) {}),
+		WithCheck(Check{
+			Name:           "database",
+			Check:          func(ctx context.Context) error { return nil },
+			StatusListener: func(ctx context.Context, name string, state CheckState) {},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Contains(t, logger.entries, "WARN health listener queue full [listener status listener]")
+}
@@ -0,0 +1,64 @@
+package health
+
+import "time"
+
+// Logger is used by a Checker to log internal events (see WithLogger). Messages are logged with alternating
+// keys and values as additional arguments (e.g., "check", "database", "duration", 150*time.Millisecond).
+// The method set is compatible with *slog.Logger from the standard library (Go 1.21+), so it can be
+// passed to WithLogger directly.
+//
+// The following events are logged:
+//   - Debug: a check was started or finished (keys "check", "status" and "duration").
+//   - Warn: a check timed out (keys "check" and "duration").
+//   - Warn: a periodic check was executed later than planned, e.g., because the scheduler
+//     or the system was overloaded (keys "check" and "drift").
+//   - Error: a check function panicked (keys "check" and "error").
+//   - Error: a publisher, state store or result cache failed (key "error").
+//   - Error: the resources of a replaced or removed check could not be released (keys "check" and "error").
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// noopLogger is the Logger that is used if no Logger was configured (see WithLogger).
+type noopLogger struct{}
+
+// schedulerDriftThreshold is the delay of a periodic check execution above which it is logged (see Logger).
+const schedulerDriftThreshold = 1 * time.Second
+
+func (noopLogger) Debug(string, ...interface{}) {}
+
+func (noopLogger) Info(string, ...interface{}) {}
+
+func (noopLogger) Warn(string, ...interface{}) {}
+
+func (noopLogger) Error(string, ...interface{}) {}
+
+// handleErr logs errors of publishers, state stores and result caches and passes them
+// to the publisher error handler (see WithPublisherErrorHandler).
+func (ck *defaultChecker) handleErr(err error) {
+	ck.cfg.logger.Error("health publisher failed", "error", err)
+
+	if ck.cfg.publisherErrHandler != nil {
+		ck.cfg.publisherErrHandler(err)
+	}
+}
+
+// logCheckResult logs the result of a check execution (see Logger).
+func logCheckResult(logger Logger, check *Check, state *CheckState) {
+	if state.TimedOut {
+		logger.Warn("health check timed out", "check", check.Name, "duration", state.LastCheckDuration)
+	}
+
+	logger.Debug("health check finished", "check", check.Name, "status", state.Status,
+		"duration", state.LastCheckDuration)
+}
+
+// logSchedulerDrift logs if a periodic check is executed later than planned (see Logger).
+func logSchedulerDrift(logger Logger, check *Check, plannedAt, now time.Time) {
+	if drift := now.Sub(plannedAt); drift > schedulerDriftThreshold {
+		logger.Warn("health check execution delayed", "check", check.Name, "drift", drift)
+	}
+}
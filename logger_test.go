@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	mtx     sync.Mutex
+	entries []string
+}
+
+func (l *recordingLogger) record(level, msg string, args []interface{}) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.entries = append(l.entries, fmt.Sprintf("%s %s %v", level, msg, args[:2]))
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.record("DEBUG", msg, args) }
+
+func (l *recordingLogger) Info(msg string, args ...interface{}) { l.record("INFO", msg, args) }
+
+func (l *recordingLogger) Warn(msg string, args ...interface{}) { l.record("WARN", msg, args) }
+
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.record("ERROR", msg, args) }
+
+func TestLoggerLogsCheckExecutions(t *testing.T) {
+	// Arrange
+	logger := &recordingLogger{}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithLogger(logger),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "search", Timeout: 1 * time.Millisecond, Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}),
+		WithCheck(Check{Name: "cache", Check: func(ctx context.Context) error { panic("boom") }}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Contains(t, logger.entries, "DEBUG health check started [check database]")
+	assert.Contains(t, logger.entries, "DEBUG health check finished [check database]")
+	assert.Contains(t, logger.entries, "WARN health check timed out [check search]")
+	assert.Contains(t, logger.entries, "ERROR health check panicked [check cache]")
+}
+
+func TestLoggerLogsPublisherErrors(t *testing.T) {
+	// Arrange
+	logger := &recordingLogger{}
+	published := make(chan struct{}, 1)
+	ckr := NewChecker(
+		WithLogger(logger),
+		WithPublisher(PublisherFunc(func(ctx context.Context, state CheckerState) error {
+			defer func() { published <- struct{}{} }()
+			return errors.New("connection refused")
+		}), 1),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	defer ckr.Stop()
+
+	// Act
+	ckr.Check(context.Background())
+	<-published
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		logger.mtx.Lock()
+		defer logger.mtx.Unlock()
+		for _, entry := range logger.entries {
+			if entry == "ERROR health publisher failed [error connection refused]" {
+				return true
+			}
+		}
+		return false
+	}, 1*time.Second, 5*time.Millisecond)
+}
+
+func TestLogSchedulerDrift(t *testing.T) {
+	// Arrange
+	logger := &recordingLogger{}
+	check := &Check{Name: "database"}
+	plannedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	logSchedulerDrift(logger, check, plannedAt, plannedAt.Add(100*time.Millisecond))
+	logSchedulerDrift(logger, check, plannedAt, plannedAt.Add(5*time.Second))
+
+	// Assert
+	assert.Equal(t, []string{"WARN health check execution delayed [check database]"}, logger.entries)
+}
@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Maintenance describes a planned maintenance of a component (see Checker.SetMaintenance).
+type Maintenance struct {
+	// Reason describes why the component is in maintenance (e.g., "database migration").
+	Reason string `json:"reason,omitempty"`
+	// Until holds the time when the maintenance ends. It is zero if the maintenance
+	// does not end automatically (see Checker.ClearMaintenance).
+	Until time.Time `json:"until,omitempty"`
+}
+
+// MarshalJSON provides a custom marshaller for the Maintenance type, which omits Until if it is zero.
+func (m Maintenance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Reason string     `json:"reason,omitempty"`
+		Until  *time.Time `json:"until,omitempty"`
+	}{Reason: m.Reason, Until: timeOrNil(m.Until)})
+}
+
+// SetMaintenance implements Checker.SetMaintenance. Please refer to Checker.SetMaintenance for more information.
+func (ck *defaultChecker) SetMaintenance(name string, until time.Time, reason string) error {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return fmt.Errorf("check %q does not exist", name)
+	}
+
+	ck.stateMtx.Lock()
+	ck.maintenance[name] = Maintenance{Reason: reason, Until: until}
+	ck.stateMtx.Unlock()
+
+	// The aggregated status is updated right away, because the check does not contribute to it anymore.
+	ck.updateState(context.Background())
+
+	return nil
+}
+
+// ClearMaintenance implements Checker.ClearMaintenance. Please refer to Checker.ClearMaintenance for more information.
+func (ck *defaultChecker) ClearMaintenance(name string) error {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return fmt.Errorf("check %q does not exist", name)
+	}
+
+	ck.stateMtx.Lock()
+	delete(ck.maintenance, name)
+	ck.stateMtx.Unlock()
+
+	ck.updateState(context.Background())
+
+	return nil
+}
+
+// activeMaintenance returns the maintenance of the check, if it has not ended yet
+// (see Checker.SetMaintenance). Must be called while holding ck.mtx or the state lock.
+func (ck *defaultChecker) activeMaintenance(name string) (Maintenance, bool) {
+	maintenance, ok := ck.maintenance[name]
+	if !ok || (!maintenance.Until.IsZero() && !ck.cfg.clock.Now().Before(maintenance.Until)) {
+		return Maintenance{}, false
+	}
+	return maintenance, true
+}
@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMaintenanceSkipsCheckAndExcludesItFromAggregation(t *testing.T) {
+	// Arrange
+	var calls int32
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	until := clock.Now().Add(1 * time.Hour)
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.New("connection refused")
+		}}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error {
+			return nil
+		}}),
+	)
+
+	// Act
+	err := ckr.SetMaintenance("database", until, "database migration")
+	res := ckr.Check(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Equal(t, StatusMaintenance, res.Details["database"].Status)
+	assert.Equal(t, &Maintenance{Reason: "database migration", Until: until}, res.Details["database"].Maintenance)
+	assert.Nil(t, res.Details["search"].Maintenance)
+}
+
+func TestMaintenanceEndsAutomatically(t *testing.T) {
+	// Arrange
+	var calls int32
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.New("connection refused")
+		}}),
+	)
+	require.NoError(t, ckr.SetMaintenance("database", clock.Now().Add(1*time.Minute), "database migration"))
+
+	// Act
+	first := ckr.Check(context.Background())
+	clock.advance(2 * time.Minute)
+	second := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusMaintenance, first.Details["database"].Status)
+	assert.Equal(t, StatusDown, second.Status)
+	assert.Equal(t, StatusDown, second.Details["database"].Status)
+	assert.Nil(t, second.Details["database"].Maintenance)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClearMaintenance(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return nil
+		}}),
+	)
+	require.NoError(t, ckr.SetMaintenance("database", time.Time{}, "database migration"))
+
+	// Act
+	during := ckr.Check(context.Background())
+	err := ckr.ClearMaintenance("database")
+	after := ckr.Check(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, StatusMaintenance, during.Details["database"].Status)
+	assert.Equal(t, StatusUp, after.Details["database"].Status)
+	assert.Error(t, ckr.SetMaintenance("unknown", time.Time{}, ""))
+	assert.Error(t, ckr.ClearMaintenance("unknown"))
+}
+
+func TestMaintenanceMarshalJSONOmitsZeroUntil(t *testing.T) {
+	// Act
+	data, err := json.Marshal(Maintenance{Reason: "database migration"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"reason":"database migration"}`, string(data))
+}
+
+func TestReloadRemovesMaintenanceOfRemovedChecks(t *testing.T) {
+	// Arrange
+	cfg := CheckerConfig{Checks: []CheckConfig{{Name: "database", Type: "static"}}}
+	ckr, err := NewCheckerFromConfig(cfg, WithDisabledAutostart(), WithDisabledCache())
+	require.NoError(t, err)
+	require.NoError(t, ckr.SetMaintenance("database", time.Time{}, "database migration"))
+
+	// Act
+	require.NoError(t, ckr.Reload(CheckerConfig{}))
+	require.NoError(t, ckr.Reload(cfg))
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, res.Details["database"].Status)
+	assert.Nil(t, res.Details["database"].Maintenance)
+}
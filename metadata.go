@@ -0,0 +1,20 @@
+package health
+
+import "context"
+
+type checkMetadataKey struct{}
+
+// CheckMetadataFromContext returns the metadata of the check (see Check.Metadata) that belongs to the
+// provided context. The context that is passed to check functions, interceptors and status listeners
+// of a check carries its metadata. It returns nil if the check has no metadata.
+func CheckMetadataFromContext(ctx context.Context) map[string]interface{} {
+	metadata, _ := ctx.Value(checkMetadataKey{}).(map[string]interface{})
+	return metadata
+}
+
+func withCheckMetadata(ctx context.Context, check *Check) context.Context {
+	if check.Metadata == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, checkMetadataKey{}, check.Metadata)
+}
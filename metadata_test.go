@@ -0,0 +1,62 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckMetadataIsIncludedInResult(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name:     "database",
+			Metadata: map[string]interface{}{"owner": "team-db", "runbook": "https://example.com/runbooks/db"},
+			Check:    func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+	data, err := json.Marshal(res.Details["database"])
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "team-db", res.Details["database"].Metadata["owner"])
+	assert.Contains(t, string(data), `"metadata":{"owner":"team-db","runbook":"https://example.com/runbooks/db"}`)
+}
+
+func TestCheckMetadataIsPassedToInterceptorsAndListeners(t *testing.T) {
+	// Arrange
+	var interceptorMetadata, listenerMetadata map[string]interface{}
+	metadata := map[string]interface{}{"owner": "team-db"}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{
+			Name:     "database",
+			Metadata: metadata,
+			Check:    func(ctx context.Context) error { return nil },
+			Interceptors: []Interceptor{func(next InterceptorFunc) InterceptorFunc {
+				return func(ctx context.Context, name string, state CheckState) CheckState {
+					interceptorMetadata = CheckMetadataFromContext(ctx)
+					return next(ctx, name, state)
+				}
+			}},
+			StatusListener: func(ctx context.Context, name string, state CheckState) {
+				listenerMetadata = CheckMetadataFromContext(ctx)
+			},
+		}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, metadata, interceptorMetadata)
+	assert.Equal(t, metadata, listenerMetadata)
+	assert.Nil(t, CheckMetadataFromContext(context.Background()))
+}
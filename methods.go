@@ -0,0 +1,37 @@
+package health
+
+import (
+	"net/http"
+	"strings"
+)
+
+// allowedMethods returns the HTTP methods that a handler accepts (see WithMethods). POST is always
+// accepted if forced refreshes are enabled (see WithRefreshEnabled).
+func allowedMethods(cfg *HandlerConfig) []string {
+	methods := cfg.methods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead}
+	}
+
+	if cfg.refreshEnabled && !containsMethod(methods, http.MethodPost) {
+		methods = append(append([]string(nil), methods...), http.MethodPost)
+	}
+
+	return methods
+}
+
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMethodNotAllowed responds with HTTP status code 405 (Method Not Allowed)
+// and an Allow header that lists all accepted methods.
+func writeMethodNotAllowed(w http.ResponseWriter, methods []string) {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
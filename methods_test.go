@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerRejectsDisallowedMethods(t *testing.T) {
+	// Arrange
+	ckr := checkerMock{}
+	handler := NewHandler(&ckr)
+
+	// Act
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/health", nil))
+
+	// Assert
+	ckr.AssertNotCalled(t, "Check")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+}
+
+func TestHandlerWithMethods(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(WithDisabledAutostart())
+	handler := NewHandler(ckr, WithMethods(http.MethodGet, http.MethodPost), WithRefreshEnabled())
+
+	// Act
+	post := httptest.NewRecorder()
+	handler.ServeHTTP(post, httptest.NewRequest(http.MethodPost, "/health", nil))
+	head := httptest.NewRecorder()
+	handler.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, post.Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, head.Code)
+	assert.Equal(t, "GET, POST", head.Header().Get("Allow"))
+}
+
+func TestHandlerRefreshAllowsPost(t *testing.T) {
+	// Arrange
+	handler := NewHandler(NewChecker(WithDisabledAutostart()), WithRefreshEnabled())
+
+	// Act
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/health", nil))
+
+	// Assert
+	assert.Equal(t, "GET, HEAD, POST", w.Header().Get("Allow"))
+}
+
+func TestHandlerCheckSelectionFromPostBody(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "cache", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewHandler(ckr, WithMethods(http.MethodPost), WithCheckSelection())
+
+	formRequest := httptest.NewRequest(http.MethodPost, "/health", strings.NewReader("check=database&check=cache"))
+	formRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	jsonRequest := httptest.NewRequest(http.MethodPost, "/health", strings.NewReader(`{"checks":["search"]}`))
+	jsonRequest.Header.Set("Content-Type", "application/json")
+	invalidRequest := httptest.NewRequest(http.MethodPost, "/health", strings.NewReader(`{"checks":`))
+	invalidRequest.Header.Set("Content-Type", "application/json")
+
+	// Act
+	form := httptest.NewRecorder()
+	handler.ServeHTTP(form, formRequest)
+	jsonBody := httptest.NewRecorder()
+	handler.ServeHTTP(jsonBody, jsonRequest)
+	invalid := httptest.NewRecorder()
+	handler.ServeHTTP(invalid, invalidRequest)
+
+	// Assert
+	var formResult, jsonResult CheckerResult
+	require.NoError(t, json.Unmarshal(form.Body.Bytes(), &formResult))
+	require.NoError(t, json.Unmarshal(jsonBody.Body.Bytes(), &jsonResult))
+	assert.Len(t, formResult.Details, 2)
+	assert.Contains(t, formResult.Details, "database")
+	assert.Contains(t, formResult.Details, "cache")
+	assert.Len(t, jsonResult.Details, 1)
+	assert.Contains(t, jsonResult.Details, "search")
+	assert.Equal(t, http.StatusBadRequest, invalid.Code)
+}
@@ -0,0 +1,103 @@
+package health
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// qualityValue is an element of a header that lists acceptable values along with their relative weights,
+// such as "Accept", "Accept-Encoding" or "Accept-Language" (see parseQualityValues).
+type qualityValue struct {
+	value   string
+	quality float64
+}
+
+// selectResultWriter selects a ResultWriter based on the "Accept" header of the request (see WithResultWriters).
+// It also returns the media type that the selected writer was registered for, which is empty for the default
+// result writer (see WithResultWriter). It returns false if none of the accepted media types is supported.
+func selectResultWriter(r *http.Request, cfg *HandlerConfig) (ResultWriter, string, bool) {
+	accept := r.Header.Get("Accept")
+	if len(cfg.resultWriters) == 0 || accept == "" || accept == "*/*" {
+		return cfg.resultWriter, "", true
+	}
+
+	for _, mediaRange := range parseAcceptHeader(accept) {
+		if writer, ok := cfg.resultWriters[mediaRange.value]; ok {
+			return writer, mediaRange.value, true
+		}
+
+		if mediaRange.value == "*/*" {
+			return cfg.resultWriter, "", true
+		}
+
+		if strings.HasSuffix(mediaRange.value, "/*") {
+			prefix := strings.TrimSuffix(mediaRange.value, "*")
+			for _, mediaType := range sortedKeys(cfg.resultWriters) {
+				if strings.HasPrefix(mediaType, prefix) {
+					return cfg.resultWriters[mediaType], mediaType, true
+				}
+			}
+		}
+	}
+
+	return nil, "", false
+}
+
+// parseAcceptHeader parses the value of an "Accept" header and returns all acceptable
+// media ranges ordered by their quality (highest first). Media type parameters are ignored.
+func parseAcceptHeader(accept string) []qualityValue {
+	var ranges []qualityValue
+	for _, mediaRange := range parseQualityValues(accept) {
+		if mediaRange.quality > 0 {
+			ranges = append(ranges, mediaRange)
+		}
+	}
+	return ranges
+}
+
+// parseQualityValues parses a comma-separated list of values with optional weights, such as the value of an
+// "Accept", "Accept-Encoding" or "Accept-Language" header (e.g., "de-CH, de;q=0.8, *;q=0"). It returns all values
+// in lower case along with their quality, ordered by quality (highest first). Values without a weight have
+// a quality of 1. Values with a quality of 0 are returned as well, since they explicitly mark a value as not
+// acceptable. Values with an invalid weight (i.e., one that is not a number between 0 and 1) are ignored.
+func parseQualityValues(header string) []qualityValue {
+	var values []qualityValue
+
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		value := strings.ToLower(strings.TrimSpace(params[0]))
+		if value == "" {
+			continue
+		}
+
+		quality, valid := 1.0, true
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if len(param) >= 2 && strings.EqualFold(param[:2], "q=") {
+				quality, valid = parseQuality(param[2:])
+			}
+		}
+
+		if valid {
+			values = append(values, qualityValue{value: value, quality: quality})
+		}
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].quality > values[j].quality
+	})
+
+	return values
+}
+
+// parseQuality parses a weight of a quality value (see parseQualityValues).
+// It returns false if the weight is not a number between 0 and 1.
+func parseQuality(weight string) (float64, bool) {
+	quality, err := strconv.ParseFloat(weight, 64)
+	if err != nil || quality < 0 || quality > 1 {
+		return 0, false
+	}
+	return quality, true
+}
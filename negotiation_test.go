@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func doTestContentNegotiation(t *testing.T, accept string, expectedStatusCode int, expectedContentType string) {
+	// Arrange
+	handler := NewHandler(
+		NewChecker(WithCheck(Check{Name: "check", Check: func(ctx context.Context) error { return nil }})),
+		WithResultWriters(map[string]ResultWriter{
+			"application/xml":  NewXMLResultWriter(),
+			"application/yaml": NewYAMLResultWriter(),
+		}),
+	)
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	response := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(response, request)
+
+	// Assert
+	assert.Equal(t, expectedStatusCode, response.Code)
+	assert.Equal(t, expectedContentType, response.Header().Get("Content-Type"))
+}
+
+func TestContentNegotiationWithoutAcceptHeaderUsesDefaultWriter(t *testing.T) {
+	doTestContentNegotiation(t, "", http.StatusOK, "application/json; charset=utf-8")
+}
+
+func TestContentNegotiationWithWildcardUsesDefaultWriter(t *testing.T) {
+	doTestContentNegotiation(t, "*/*", http.StatusOK, "application/json; charset=utf-8")
+}
+
+func TestContentNegotiationSelectsRegisteredWriter(t *testing.T) {
+	doTestContentNegotiation(t, "application/xml", http.StatusOK, "application/xml; charset=utf-8")
+}
+
+func TestContentNegotiationRespectsQuality(t *testing.T) {
+	doTestContentNegotiation(t, "application/xml;q=0.5, application/yaml", http.StatusOK, "application/yaml; charset=utf-8")
+}
+
+func TestContentNegotiationRespondsNotAcceptable(t *testing.T) {
+	doTestContentNegotiation(t, "text/html", http.StatusNotAcceptable, "text/plain; charset=utf-8")
+}
+
+func TestContentNegotiationIgnoresMediaRangesWithInvalidQuality(t *testing.T) {
+	doTestContentNegotiation(t, "application/yaml;q=high, application/xml;Q=0.5", http.StatusOK, "application/xml; charset=utf-8")
+}
+
+func TestParseQualityValues(t *testing.T) {
+	// Act
+	values := parseQualityValues("gzip;q=0.5, BR ; level=3, deflate;q=0, identity;q=invalid, *;q=2, , compress")
+
+	// Assert
+	assert.Equal(t, []qualityValue{
+		{value: "br", quality: 1},
+		{value: "compress", quality: 1},
+		{value: "gzip", quality: 0.5},
+		{value: "deflate", quality: 0},
+	}, values)
+}
@@ -0,0 +1,102 @@
+// Package otel provides OpenTelemetry tracing instrumentation for health checks and health endpoints.
+// It is provided as a separate module, so that the health package itself does not depend on OpenTelemetry.
+package otel
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/alexliesenfeld/health/otel"
+
+// Tracing creates OpenTelemetry spans for check function executions (see Tracing.Interceptor)
+// and for requests to health endpoints (see Tracing.Middleware).
+//
+// The following span attributes are recorded:
+//   - health.check.name: the name of the check (check spans only).
+//   - health.check.status: the availability status of the check after its execution (check spans only).
+//   - health.check.duration_seconds: the duration of the check execution in seconds (check spans only).
+//   - health.status: the aggregated availability status (request spans only).
+//
+// Check errors are recorded as span events and set the span status to codes.Error.
+type Tracing struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracing creates a new Tracing instance that creates spans using the provided trace.TracerProvider.
+// If provider is nil, the global trace.TracerProvider is used (see go.opentelemetry.io/otel.GetTracerProvider).
+func NewTracing(provider trace.TracerProvider) *Tracing {
+	if provider == nil {
+		provider = otelapi.GetTracerProvider()
+	}
+
+	return &Tracing{
+		tracer:     provider.Tracer(instrumentationName),
+		propagator: otelapi.GetTextMapPropagator(),
+	}
+}
+
+// Interceptor returns a health.Interceptor that creates a span for each check function execution. The span
+// context is passed on to the check function, so that spans created by the check function become child spans.
+// Use it with health.WithInterceptors to apply it to all checks, or add it to health.Check.Interceptors
+// to only apply it to specific checks.
+func (t *Tracing) Interceptor() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			ctx, span := t.tracer.Start(ctx, "health.check "+name, trace.WithAttributes(
+				attribute.String("health.check.name", name),
+			))
+			defer span.End()
+
+			now := time.Now()
+			result := next(ctx, name, state)
+
+			span.SetAttributes(
+				attribute.String("health.check.status", string(result.Status)),
+				attribute.Float64("health.check.duration_seconds", time.Since(now).Seconds()),
+			)
+
+			if result.Result != nil {
+				span.RecordError(result.Result)
+				span.SetStatus(codes.Error, result.Result.Error())
+			}
+
+			return result
+		}
+	}
+}
+
+// Middleware returns a health.Middleware that creates a span for each request to a health endpoint
+// (see health.WithMiddleware). Trace context that is propagated by the caller (e.g., using W3C trace context
+// headers) is extracted using the global propagator (see go.opentelemetry.io/otel.GetTextMapPropagator).
+func (t *Tracing) Middleware() health.Middleware {
+	return func(next health.MiddlewareFunc) health.MiddlewareFunc {
+		return func(r *http.Request) health.CheckerResult {
+			ctx := t.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := t.tracer.Start(ctx, "health.request", trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.method", r.Method),
+					attribute.String("http.target", r.URL.RequestURI()),
+				))
+			defer span.End()
+
+			result := next(r.WithContext(ctx))
+
+			span.SetAttributes(attribute.String("health.status", string(result.Status)))
+			if result.Status == health.StatusDown {
+				span.SetStatus(codes.Error, "system is "+string(result.Status))
+			}
+
+			return result
+		}
+	}
+}
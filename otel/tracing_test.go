@@ -0,0 +1,130 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otelapi "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newRecordingTracing() (*Tracing, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return NewTracing(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))), recorder
+}
+
+func attributesOf(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attributes := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestInterceptorCreatesCheckSpans(t *testing.T) {
+	// Arrange
+	tracing, recorder := newRecordingTracing()
+	var checkSpanCtx trace.SpanContext
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(tracing.Interceptor()),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				checkSpanCtx = trace.SpanContextFromContext(ctx)
+				return nil
+			},
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "health.check database", spans[0].Name())
+	assert.Equal(t, spans[0].SpanContext().SpanID(), checkSpanCtx.SpanID())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	attributes := attributesOf(spans[0])
+	assert.Equal(t, "database", attributes["health.check.name"].AsString())
+	assert.Equal(t, string(health.StatusUp), attributes["health.check.status"].AsString())
+	assert.Contains(t, attributes, attribute.Key("health.check.duration_seconds"))
+}
+
+func TestInterceptorRecordsCheckErrors(t *testing.T) {
+	// Arrange
+	tracing, recorder := newRecordingTracing()
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithInterceptors(tracing.Interceptor()),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return errors.New("connection refused") },
+		}),
+	)
+
+	// Act
+	checker.Check(context.Background())
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection refused", spans[0].Status().Description)
+	assert.Equal(t, string(health.StatusDown), attributesOf(spans[0])["health.check.status"].AsString())
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
+
+func TestMiddlewareCreatesRequestSpans(t *testing.T) {
+	// Arrange
+	prevPropagator := otelapi.GetTextMapPropagator()
+	otelapi.SetTextMapPropagator(propagation.TraceContext{})
+	defer otelapi.SetTextMapPropagator(prevPropagator)
+
+	tracing, recorder := newRecordingTracing()
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return errors.New("connection refused") },
+		}),
+	)
+	handler := health.NewHandler(checker, health.WithMiddleware(tracing.Middleware()))
+
+	req := httptest.NewRequest(http.MethodGet, "/health?full=true", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "health.request", spans[0].Name())
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+	attributes := attributesOf(spans[0])
+	assert.Equal(t, http.MethodGet, attributes["http.method"].AsString())
+	assert.Equal(t, "/health?full=true", attributes["http.target"].AsString())
+	assert.Equal(t, string(health.StatusDown), attributes["health.status"].AsString())
+}
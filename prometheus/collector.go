@@ -0,0 +1,97 @@
+package prometheus
+
+import (
+	"github.com/alexliesenfeld/health"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	componentStatusDesc = prom.NewDesc(
+		"health_component_status",
+		"The current availability status of the component (1 for the current status, 0 otherwise).",
+		[]string{"name", "status"}, nil,
+	)
+	componentLastCheckDurationDesc = prom.NewDesc(
+		"health_component_last_check_duration_seconds",
+		"The duration of the last check execution of the component in seconds.",
+		[]string{"name"}, nil,
+	)
+	componentContiguousFailsDesc = prom.NewDesc(
+		"health_component_contiguous_failures",
+		"The number of contiguous check failures of the component.",
+		[]string{"name"}, nil,
+	)
+	componentChecksTotalDesc = prom.NewDesc(
+		"health_component_checks_total",
+		"The total number of check executions of the component.",
+		[]string{"name"}, nil,
+	)
+
+	availabilityStatuses = []health.AvailabilityStatus{
+		health.StatusUp,
+		health.StatusDegraded,
+		health.StatusStarting,
+		health.StatusDown,
+		health.StatusSkipped,
+		health.StatusUnknown,
+	}
+)
+
+// Collector is a prometheus.Collector that exports the last known state of all checks of a health.Checker.
+// In contrast to Metrics, it does not need to be wired into the Checker configuration. Instead, it reads
+// the cached check states on each scrape (see health.Checker.GetCheckState), so collecting metrics never
+// executes any check function.
+//
+// The following metrics are exported:
+//   - health_component_status: 1 for the current status of a component, 0 for all others (labels "name", "status").
+//   - health_component_last_check_duration_seconds: the duration of the last check execution (label "name").
+//   - health_component_contiguous_failures: the number of contiguous check failures (label "name").
+//   - health_component_checks_total: the total number of check executions (label "name").
+//
+// Except for health_component_status, metrics are only exported for checks that have been executed at least once.
+type Collector struct {
+	checker health.Checker
+}
+
+// NewCollector creates a new Collector for the provided health.Checker. It must be registered
+// with a prometheus.Registerer to export metrics.
+func NewCollector(checker health.Checker) *Collector {
+	return &Collector{checker: checker}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	ch <- componentStatusDesc
+	ch <- componentLastCheckDurationDesc
+	ch <- componentContiguousFailsDesc
+	ch <- componentChecksTotalDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	for _, name := range c.checker.GetCheckNames(nil) {
+		state, ok := c.checker.GetCheckState(name)
+		if !ok {
+			continue
+		}
+
+		for _, status := range availabilityStatuses {
+			value := 0.0
+			if state.Status == status {
+				value = 1
+			}
+			ch <- prom.MustNewConstMetric(componentStatusDesc, prom.GaugeValue, value, name, string(status))
+		}
+
+		if state.LastCheckedAt.IsZero() {
+			continue
+		}
+
+		ch <- prom.MustNewConstMetric(componentLastCheckDurationDesc, prom.GaugeValue,
+			state.LastCheckDuration.Seconds(), name)
+		ch <- prom.MustNewConstMetric(componentContiguousFailsDesc, prom.GaugeValue,
+			float64(state.ContiguousFails), name)
+		ch <- prom.MustNewConstMetric(componentChecksTotalDesc, prom.CounterValue,
+			float64(state.CheckCount), name)
+	}
+}
@@ -0,0 +1,60 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorExportsCheckStates(t *testing.T) {
+	// Arrange
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return errors.New("connection refused") },
+		}),
+		health.WithPeriodicCheck(time.Hour, 0, health.Check{
+			Name:  "cache",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+	checker.Check(context.Background())
+
+	expected := `
+# HELP health_component_checks_total The total number of check executions of the component.
+# TYPE health_component_checks_total counter
+health_component_checks_total{name="database"} 1
+# HELP health_component_contiguous_failures The number of contiguous check failures of the component.
+# TYPE health_component_contiguous_failures gauge
+health_component_contiguous_failures{name="database"} 1
+# HELP health_component_status The current availability status of the component (1 for the current status, 0 otherwise).
+# TYPE health_component_status gauge
+health_component_status{name="cache",status="degraded"} 0
+health_component_status{name="cache",status="down"} 0
+health_component_status{name="cache",status="skipped"} 0
+health_component_status{name="cache",status="starting"} 0
+health_component_status{name="cache",status="unknown"} 1
+health_component_status{name="cache",status="up"} 0
+health_component_status{name="database",status="degraded"} 0
+health_component_status{name="database",status="down"} 1
+health_component_status{name="database",status="skipped"} 0
+health_component_status{name="database",status="starting"} 0
+health_component_status{name="database",status="unknown"} 0
+health_component_status{name="database",status="up"} 0
+`
+
+	// Act
+	err := testutil.CollectAndCompare(NewCollector(checker), strings.NewReader(expected),
+		"health_component_status", "health_component_contiguous_failures", "health_component_checks_total")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, testutil.CollectAndCount(NewCollector(checker), "health_component_last_check_duration_seconds"))
+}
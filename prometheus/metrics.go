@@ -0,0 +1,125 @@
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds Prometheus metrics about health checks. It provides an interceptor (see Metrics.Interceptor)
+// that records the outcome and duration of check function executions, and a status listener
+// (see Metrics.StatusListener) that records the aggregated system status.
+//
+// The following metrics are exported:
+//   - health_check_up: 1 if a check is up, 0 otherwise (label "name").
+//   - health_check_contiguous_failures: the number of contiguous failures of a check (label "name").
+//   - health_check_duration_seconds: a histogram of check execution durations (label "name").
+//     Its buckets can be configured (see WithDurationBuckets and WithNativeHistograms).
+//   - health_system_up: 1 if the aggregated system status is up, 0 otherwise.
+//
+// Metrics are only exported for checks that have been executed at least once, so that checks that
+// have never run do not show up as being down.
+type Metrics struct {
+	checkUp              *prom.GaugeVec
+	checkContiguousFails *prom.GaugeVec
+	checkDuration        *prom.HistogramVec
+	systemUp             prom.Gauge
+}
+
+// MetricsOption is a configuration option for Metrics (see NewMetrics).
+type MetricsOption func(opts *prom.HistogramOpts)
+
+// WithDurationBuckets sets the upper bounds of the buckets of the health_check_duration_seconds histogram
+// (e.g., prometheus.ExponentialBuckets(0.001, 2, 12) to track the latency of fast dependency pings in detail).
+// By default, prometheus.DefBuckets is used.
+func WithDurationBuckets(buckets ...float64) MetricsOption {
+	return func(opts *prom.HistogramOpts) {
+		opts.Buckets = buckets
+	}
+}
+
+// WithNativeHistograms additionally exports the health_check_duration_seconds histogram as a native histogram
+// with the provided bucket growth factor (e.g., 1.1; see prometheus.HistogramOpts.NativeHistogramBucketFactor).
+// Native histograms allow to calculate precise quantiles (such as the p99 check latency) without choosing
+// bucket boundaries upfront, but need to be enabled in the Prometheus server.
+func WithNativeHistograms(bucketFactor float64) MetricsOption {
+	return func(opts *prom.HistogramOpts) {
+		opts.NativeHistogramBucketFactor = bucketFactor
+	}
+}
+
+// NewMetrics creates a new Metrics instance and registers all metrics with the provided registerer.
+func NewMetrics(registerer prom.Registerer, options ...MetricsOption) (*Metrics, error) {
+	durationOpts := prom.HistogramOpts{
+		Name:    "health_check_duration_seconds",
+		Help:    "The duration of health check executions in seconds.",
+		Buckets: prom.DefBuckets,
+	}
+
+	for _, opt := range options {
+		opt(&durationOpts)
+	}
+
+	m := Metrics{
+		checkUp: prom.NewGaugeVec(prom.GaugeOpts{
+			Name: "health_check_up",
+			Help: "Whether the health check is up (1) or not (0).",
+		}, []string{"name"}),
+		checkContiguousFails: prom.NewGaugeVec(prom.GaugeOpts{
+			Name: "health_check_contiguous_failures",
+			Help: "The number of contiguous failures of the health check.",
+		}, []string{"name"}),
+		checkDuration: prom.NewHistogramVec(durationOpts, []string{"name"}),
+		systemUp: prom.NewGauge(prom.GaugeOpts{
+			Name: "health_system_up",
+			Help: "Whether the aggregated system health status is up (1) or not (0).",
+		}),
+	}
+
+	for _, collector := range []prom.Collector{m.checkUp, m.checkContiguousFails, m.checkDuration, m.systemUp} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+// Interceptor returns a health.Interceptor that records the outcome and duration of each check function
+// execution. Use it with health.WithInterceptors to apply it to all checks, or add it to health.Check.Interceptors
+// to only apply it to specific checks.
+func (m *Metrics) Interceptor() health.Interceptor {
+	return func(next health.InterceptorFunc) health.InterceptorFunc {
+		return func(ctx context.Context, name string, state health.CheckState) health.CheckState {
+			now := time.Now()
+			result := next(ctx, name, state)
+			m.checkDuration.WithLabelValues(name).Observe(time.Since(now).Seconds())
+
+			if result.Status != health.StatusUnknown {
+				m.checkUp.WithLabelValues(name).Set(statusValue(result.Status))
+				m.checkContiguousFails.WithLabelValues(name).Set(float64(result.ContiguousFails))
+			}
+
+			return result
+		}
+	}
+}
+
+// StatusListener returns a listener that records the aggregated system status.
+// Use it with health.WithStatusListener.
+func (m *Metrics) StatusListener() func(ctx context.Context, state health.CheckerState) {
+	return func(_ context.Context, state health.CheckerState) {
+		if state.Status != health.StatusUnknown {
+			m.systemUp.Set(statusValue(state.Status))
+		}
+	}
+}
+
+func statusValue(status health.AvailabilityStatus) float64 {
+	if status == health.StatusUp {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,115 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alexliesenfeld/health"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRecordCheckResults(t *testing.T) {
+	// Arrange
+	registry := prom.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	require.NoError(t, err)
+
+	checker := health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithInterceptors(metrics.Interceptor()),
+		health.WithStatusListener(metrics.StatusListener()),
+		health.WithCheck(health.Check{
+			Name:  "database",
+			Check: func(ctx context.Context) error { return errors.New("connection refused") },
+		}),
+		health.WithCheck(health.Check{
+			Name:  "cache",
+			Check: func(ctx context.Context) error { return nil },
+		}),
+	)
+
+	expected := `
+# HELP health_check_contiguous_failures The number of contiguous failures of the health check.
+# TYPE health_check_contiguous_failures gauge
+health_check_contiguous_failures{name="cache"} 0
+health_check_contiguous_failures{name="database"} 2
+# HELP health_check_up Whether the health check is up (1) or not (0).
+# TYPE health_check_up gauge
+health_check_up{name="cache"} 1
+health_check_up{name="database"} 0
+# HELP health_system_up Whether the aggregated system health status is up (1) or not (0).
+# TYPE health_system_up gauge
+health_system_up 0
+`
+
+	// Act
+	checker.Check(context.Background())
+	checker.Check(context.Background())
+
+	// Assert
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"health_check_up", "health_check_contiguous_failures", "health_system_up"))
+	assert.Equal(t, uint64(2), durationHistogram(t, registry, "database").GetSampleCount())
+	assert.Equal(t, uint64(2), durationHistogram(t, registry, "cache").GetSampleCount())
+}
+
+func TestNewMetricsFailsIfMetricsAreAlreadyRegistered(t *testing.T) {
+	// Arrange
+	registry := prom.NewRegistry()
+	_, err := NewMetrics(registry)
+	require.NoError(t, err)
+
+	// Act
+	_, err = NewMetrics(registry)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+// durationHistogram returns the health_check_duration_seconds histogram of a check.
+func durationHistogram(t *testing.T, registry *prom.Registry, name string) *dto.Histogram {
+	t.Helper()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "health_check_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			if metric.GetLabel()[0].GetValue() == name {
+				return metric.GetHistogram()
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestMetricsUseConfiguredDurationBuckets(t *testing.T) {
+	// Arrange
+	registry := prom.NewRegistry()
+	metrics, err := NewMetrics(registry, WithDurationBuckets(0.001, 0.01), WithNativeHistograms(1.1))
+	require.NoError(t, err)
+
+	// Act
+	metrics.checkDuration.WithLabelValues("database").Observe(0.005)
+
+	// Assert
+	histogram := durationHistogram(t, registry, "database")
+	require.Len(t, histogram.GetBucket(), 2)
+	assert.Equal(t, 0.001, histogram.GetBucket()[0].GetUpperBound())
+	assert.Equal(t, uint64(0), histogram.GetBucket()[0].GetCumulativeCount())
+	assert.Equal(t, 0.01, histogram.GetBucket()[1].GetUpperBound())
+	assert.Equal(t, uint64(1), histogram.GetBucket()[1].GetCumulativeCount())
+	assert.Equal(t, int32(3), histogram.GetSchema())
+}
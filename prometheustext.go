@@ -0,0 +1,82 @@
+package health
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PrometheusResultWriter writes a CheckerResult in the Prometheus text exposition format, so that a health
+// endpoint can be scraped by Prometheus without importing the Prometheus client library (use the prometheus
+// module of this library for a full integration). The following metrics are written:
+//   - health_status: 1 if the aggregated status is up, 0 otherwise.
+//   - health_check_status: 1 if a component is up, 0 otherwise (label "check").
+//   - health_check_duration_seconds: the duration of the last check execution of a component
+//     in seconds (label "check"). Only written for components whose duration is known.
+//
+// Prometheus considers scrapes that respond with an HTTP status code other than 2xx as failed. Use
+// WithStatusCode to respond with http.StatusOK for all statuses if the endpoint is only used for scraping.
+type PrometheusResultWriter struct{}
+
+// PrometheusContentType is the media type of the Prometheus text exposition format (see PrometheusResultWriter).
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var prometheusLabelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// NewPrometheusResultWriter creates a new instance of a PrometheusResultWriter.
+func NewPrometheusResultWriter() *PrometheusResultWriter {
+	return &PrometheusResultWriter{}
+}
+
+// Write implements ResultWriter.Write.
+func (rw *PrometheusResultWriter) Write(result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	var buf bytes.Buffer
+
+	writePrometheusHeader(&buf, "health_status", "Whether the aggregated system health status is up (1) or not (0).")
+	fmt.Fprintf(&buf, "health_status %s\n", prometheusStatusValue(result.Status))
+
+	names := sortedKeys(result.Details)
+
+	if len(names) > 0 {
+		writePrometheusHeader(&buf, "health_check_status", "Whether the health check is up (1) or not (0).")
+		for _, name := range names {
+			fmt.Fprintf(&buf, "health_check_status{check=\"%s\"} %s\n",
+				prometheusLabelValueEscaper.Replace(name), prometheusStatusValue(result.Details[name].Status))
+		}
+
+		headerWritten := false
+		for _, name := range names {
+			duration := result.Details[name].Duration
+			if duration <= 0 {
+				continue
+			}
+
+			if !headerWritten {
+				writePrometheusHeader(&buf, "health_check_duration_seconds",
+					"The duration of the last health check execution in seconds.")
+				headerWritten = true
+			}
+
+			fmt.Fprintf(&buf, "health_check_duration_seconds{check=\"%s\"} %s\n",
+				prometheusLabelValueEscaper.Replace(name), strconv.FormatFloat(duration.Seconds(), 'g', -1, 64))
+		}
+	}
+
+	w.Header().Set("Content-Type", PrometheusContentType)
+	w.WriteHeader(statusCode)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writePrometheusHeader(buf *bytes.Buffer, name, help string) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+func prometheusStatusValue(status AvailabilityStatus) string {
+	if status == StatusUp {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type (
+	// Publisher publishes check results to external systems (such as StatsD, Datadog, CloudWatch,
+	// or a message queue). In contrast to status listeners (see WithStatusListener), a Publisher
+	// is called after every check evaluation, not only when the status changes.
+	// Publishers are called asynchronously from a dedicated goroutine per Publisher
+	// (see WithPublisher), so a slow Publisher does not stall check execution.
+	Publisher interface {
+		// Publish publishes the state of the Checker after a check evaluation. The passed
+		// CheckerState is a copy that is owned by the Publisher. The context is cancelled
+		// when the Checker is stopped (see Checker.Stop).
+		Publish(ctx context.Context, state CheckerState) error
+	}
+
+	// PublisherFunc is an adapter that allows to use an ordinary function as a Publisher.
+	PublisherFunc func(ctx context.Context, state CheckerState) error
+
+	publisherConfig struct {
+		publisher Publisher
+		queueSize int
+	}
+
+	publisherWorker struct {
+		publisher Publisher
+		queue     chan CheckerState
+	}
+)
+
+var (
+	PublisherQueueFullErr = errors.New("publisher queue is full")
+)
+
+// Publish implements Publisher.Publish.
+func (f PublisherFunc) Publish(ctx context.Context, state CheckerState) error {
+	return f(ctx, state)
+}
+
+func newPublisherWorkers(configs []publisherConfig) []*publisherWorker {
+	workers := make([]*publisherWorker, 0, len(configs))
+	for _, cfg := range configs {
+		workers = append(workers, &publisherWorker{
+			publisher: cfg.publisher,
+			queue:     make(chan CheckerState, cfg.queueSize),
+		})
+	}
+	return workers
+}
+
+// enqueue adds the state to the queue of the worker without blocking. If the queue is full,
+// the state is dropped and an error is returned.
+func (w *publisherWorker) enqueue(state CheckerState) error {
+	select {
+	case w.queue <- state:
+		return nil
+	default:
+		return PublisherQueueFullErr
+	}
+}
+
+func (w *publisherWorker) run(ctx context.Context, errorHandler func(err error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case state := <-w.queue:
+			if err := w.publish(ctx, state); err != nil && errorHandler != nil {
+				errorHandler(err)
+			}
+		}
+	}
+}
+
+func (w *publisherWorker) publish(ctx context.Context, state CheckerState) (err error) {
+	// A panicking publisher must neither crash the application nor affect other publishers.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("publisher panicked: %v", r)
+		}
+	}()
+
+	return w.publisher.Publish(ctx, state)
+}
+
+func copyCheckerState(state CheckerState) CheckerState {
+	checkStates := make(map[string]CheckState, len(state.CheckState))
+	for name, checkState := range state.CheckState {
+		checkStates[name] = checkState
+	}
+	return CheckerState{Status: state.Status, CheckState: checkStates, UnhealthyReason: state.UnhealthyReason}
+}
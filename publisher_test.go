@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisherIsCalledAfterEveryEvaluation(t *testing.T) {
+	// Arrange
+	states := make(chan CheckerState, 10)
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+		WithPublisher(PublisherFunc(func(ctx context.Context, state CheckerState) error {
+			states <- state
+			return nil
+		}), 10),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	ckr.Check(context.Background())
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	for i := 0; i < 2; i++ {
+		select {
+		case state := <-states:
+			assert.Equal(t, StatusUp, state.CheckState["check"].Status)
+		case <-time.After(1 * time.Second):
+			require.Fail(t, "publisher was not called")
+		}
+	}
+}
+
+func TestSlowPublisherDoesNotBlockChecks(t *testing.T) {
+	// Arrange
+	var droppedResults int32
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	ckr := NewChecker(
+		WithDisabledCache(),
+		WithCheck(Check{Name: "check", Check: func(ctx context.Context) error { return nil }}),
+		WithPublisher(PublisherFunc(func(ctx context.Context, state CheckerState) error {
+			<-blocked
+			return nil
+		}), 1),
+		WithPublisherErrorHandler(func(err error) {
+			if errors.Is(err, PublisherQueueFullErr) {
+				atomic.AddInt32(&droppedResults, 1)
+			}
+		}),
+	)
+
+	// Act
+	for i := 0; i < 5; i++ {
+		ckr.Check(context.Background())
+	}
+
+	// Assert
+	assert.Greater(t, atomic.LoadInt32(&droppedResults), int32(0))
+}
+
+func TestPublisherPanicIsConvertedToError(t *testing.T) {
+	// Arrange
+	worker := publisherWorker{publisher: PublisherFunc(func(ctx context.Context, state CheckerState) error {
+		panic("boom")
+	})}
+
+	// Act
+	err := worker.publish(context.Background(), CheckerState{})
+
+	// Assert
+	assert.EqualError(t, err, "publisher panicked: boom")
+}
@@ -0,0 +1,121 @@
+package health
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRateLimitBuckets is the number of rate limit keys above which idle keys are evicted.
+const maxRateLimitBuckets = 10000
+
+type (
+	rateLimitConfig struct {
+		rps     float64
+		burst   int
+		keyFunc func(r *http.Request) string
+	}
+
+	// rateLimiter limits the number of requests per key using the token bucket algorithm
+	// (see WithRateLimit). It also retains the last result, so that it can be served to
+	// rate limited requests without executing any checks.
+	rateLimiter struct {
+		mtx        sync.Mutex
+		cfg        rateLimitConfig
+		buckets    map[string]*tokenBucket
+		lastResult *CheckerResult
+	}
+
+	tokenBucket struct {
+		tokens    float64
+		updatedAt time.Time
+	}
+)
+
+// RemoteIPKey is a rate limit key function that limits requests per client IP address
+// (see WithRateLimit). It uses http.Request.RemoteAddr, so it does not consider proxy headers.
+func RemoteIPKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func newRateLimiter(cfg *rateLimitConfig) *rateLimiter {
+	if cfg == nil {
+		return nil
+	}
+	return &rateLimiter{cfg: *cfg, buckets: map[string]*tokenBucket{}}
+}
+
+// allow returns true if the request may be processed. Otherwise, it returns false
+// along with the time that needs to pass before the next request is allowed.
+func (l *rateLimiter) allow(r *http.Request) (time.Duration, bool) {
+	var key string
+	if l.cfg.keyFunc != nil {
+		key = l.cfg.keyFunc(r)
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+	burst := float64(l.cfg.burst)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitBuckets {
+			l.evictIdleBuckets(now)
+		}
+		bucket = &tokenBucket{tokens: burst, updatedAt: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.cfg.rps)
+	bucket.updatedAt = now
+
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / l.cfg.rps * float64(time.Second)), false
+	}
+
+	bucket.tokens--
+
+	return 0, true
+}
+
+// evictIdleBuckets removes all buckets that have been refilled completely,
+// since they behave like new buckets. Must be called while holding l.mtx.
+func (l *rateLimiter) evictIdleBuckets(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.cfg.rps >= float64(l.cfg.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *rateLimiter) storeResult(result CheckerResult) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.lastResult = &result
+}
+
+func (l *rateLimiter) loadResult() (CheckerResult, bool) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.lastResult == nil {
+		return CheckerResult{}, false
+	}
+
+	return *l.lastResult, true
+}
+
+// writeRetryAfter sets the Retry-After header to the provided duration, rounded up to full seconds.
+func writeRetryAfter(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Max(1, math.Ceil(wait.Seconds())))))
+}
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterUsesBucketPerKey(t *testing.T) {
+	// Arrange
+	limiter := newRateLimiter(&rateLimitConfig{rps: 0.5, burst: 2, keyFunc: RemoteIPKey})
+	request := func(remoteAddr string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/health", nil)
+		r.RemoteAddr = remoteAddr
+		return r
+	}
+
+	// Act
+	_, first := limiter.allow(request("10.0.0.1:1000"))
+	_, second := limiter.allow(request("10.0.0.1:1001"))
+	wait, third := limiter.allow(request("10.0.0.1:1002"))
+	_, otherClient := limiter.allow(request("10.0.0.2:1000"))
+
+	// Assert
+	assert.True(t, first)
+	assert.True(t, second)
+	assert.False(t, third)
+	assert.InDelta(t, 2*time.Second, wait, float64(100*time.Millisecond))
+	assert.True(t, otherClient)
+}
+
+func TestHandlerRateLimitServesLastResultWithTooManyRequests(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}}),
+	)
+	handler := NewHandler(ckr, WithRateLimit(0.1, 1, nil))
+
+	// Act
+	first := httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/health", nil))
+	limited := httptest.NewRecorder()
+	handler.ServeHTTP(limited, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "10", limited.Header().Get("Retry-After"))
+	assert.JSONEq(t, first.Body.String(), limited.Body.String())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHandlerRateLimitRespondsWithTooManyRequests(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewHandler(ckr, WithCheckSelection(), WithRateLimit(1, 1, nil))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	limited := httptest.NewRecorder()
+	handler.ServeHTTP(limited, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+}
+
+func TestHandlerRateLimitIsDisabledForNonPositiveValues(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewHandler(ckr, WithRateLimit(1, 1, nil), WithRateLimit(0, 1, nil), WithRateLimit(1, -1, nil))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+}
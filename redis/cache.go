@@ -0,0 +1,59 @@
+// Package redis provides a Redis based health.ResultCache that allows multiple instances of a service
+// to share the results of checks (see health.WithResultCache and health.Check.SharedCache).
+// It is provided as a separate module, so that the health package itself does not depend on a Redis client.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/redis/go-redis/v9"
+)
+
+// ResultCache is a health.ResultCache that stores check results as JSON in Redis.
+type ResultCache struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewResultCache creates a new ResultCache that uses the provided Redis client. The key prefix is prepended
+// to the check name to build the Redis key of a check result (e.g., "my-service:health:"). Instances that
+// share check results must use the same key prefix.
+func NewResultCache(client redis.UniversalClient, keyPrefix string) *ResultCache {
+	return &ResultCache{client: client, keyPrefix: keyPrefix}
+}
+
+// Get implements health.ResultCache.Get.
+func (c *ResultCache) Get(ctx context.Context, checkName string) (health.CheckState, bool, error) {
+	data, err := c.client.Get(ctx, c.keyPrefix+checkName).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return health.CheckState{}, false, nil
+	} else if err != nil {
+		return health.CheckState{}, false, fmt.Errorf("cannot read from redis: %w", err)
+	}
+
+	var state health.CheckState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return health.CheckState{}, false, fmt.Errorf("cannot unmarshal check state: %w", err)
+	}
+
+	return state, true, nil
+}
+
+// Set implements health.ResultCache.Set.
+func (c *ResultCache) Set(ctx context.Context, checkName string, state health.CheckState, ttl time.Duration) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("cannot marshal check state: %w", err)
+	}
+
+	if err := c.client.Set(ctx, c.keyPrefix+checkName, data, ttl).Err(); err != nil {
+		return fmt.Errorf("cannot write to redis: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(t *testing.T) (*ResultCache, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewResultCache(client, "orders:health:"), server
+}
+
+func TestResultCacheStoresCheckStates(t *testing.T) {
+	// Arrange
+	cache, server := newTestCache(t)
+	state := health.CheckState{
+		Status:          health.StatusDown,
+		LastCheckedAt:   time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		ContiguousFails: 3,
+		Result:          errors.New("connection refused"),
+	}
+
+	// Act
+	setErr := cache.Set(context.Background(), "database", state, time.Minute)
+	cached, ok, getErr := cache.Get(context.Background(), "database")
+
+	// Assert
+	require.NoError(t, setErr)
+	require.NoError(t, getErr)
+	assert.True(t, ok)
+	assert.Equal(t, state.Status, cached.Status)
+	assert.Equal(t, state.LastCheckedAt, cached.LastCheckedAt)
+	assert.Equal(t, state.ContiguousFails, cached.ContiguousFails)
+	assert.EqualError(t, cached.Result, "connection refused")
+	assert.True(t, server.Exists("orders:health:database"))
+	assert.Equal(t, time.Minute, server.TTL("orders:health:database"))
+}
+
+func TestResultCacheReportsMissingAndExpiredStates(t *testing.T) {
+	// Arrange
+	cache, server := newTestCache(t)
+	require.NoError(t, cache.Set(context.Background(), "database", health.CheckState{Status: health.StatusUp}, time.Minute))
+
+	// Act
+	server.FastForward(2 * time.Minute)
+	_, expired, expiredErr := cache.Get(context.Background(), "database")
+	_, missing, missingErr := cache.Get(context.Background(), "cache")
+
+	// Assert
+	assert.NoError(t, expiredErr)
+	assert.False(t, expired)
+	assert.NoError(t, missingErr)
+	assert.False(t, missing)
+}
+
+func TestResultCacheFailsOnInvalidData(t *testing.T) {
+	// Arrange
+	cache, server := newTestCache(t)
+	require.NoError(t, server.Set("orders:health:database", "not json"))
+
+	// Act
+	_, ok, err := cache.Get(context.Background(), "database")
+
+	// Assert
+	assert.False(t, ok)
+	assert.ErrorContains(t, err, "cannot unmarshal check state")
+}
+
+func TestResultCacheFailsIfRedisIsUnavailable(t *testing.T) {
+	// Arrange
+	cache, server := newTestCache(t)
+	server.Close()
+
+	// Act
+	setErr := cache.Set(context.Background(), "database", health.CheckState{Status: health.StatusUp}, time.Minute)
+	_, ok, getErr := cache.Get(context.Background(), "database")
+
+	// Assert
+	assert.ErrorContains(t, setErr, "cannot write to redis")
+	assert.ErrorContains(t, getErr, "cannot read from redis")
+	assert.False(t, ok)
+}
@@ -0,0 +1,51 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// refreshLimiter limits how often a handler executes a forced refresh (see WithRefreshEnabled).
+type refreshLimiter struct {
+	mtx         sync.Mutex
+	minInterval time.Duration
+	lastRefresh time.Time
+}
+
+// allow returns true if a forced refresh may be executed now. Otherwise, it returns
+// false along with the time that needs to pass before the next refresh is allowed.
+func (l *refreshLimiter) allow() (time.Duration, bool) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := time.Now()
+	if !l.lastRefresh.IsZero() {
+		if wait := l.lastRefresh.Add(l.minInterval).Sub(now); wait > 0 {
+			return wait, false
+		}
+	}
+
+	l.lastRefresh = now
+
+	return 0, true
+}
+
+func isRefreshRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && r.URL.Query().Get("refresh") == "true"
+}
+
+// refresh forces the execution of all checks that are accepted by the filter (see Checker.CheckNow).
+func refresh(ctx context.Context, checker Checker, filter CheckFilter) CheckerResult {
+	if filter == nil {
+		return checker.CheckAllNow(ctx)
+	}
+
+	for _, name := range checker.GetCheckNames(filter) {
+		//nolint:errcheck
+		checker.CheckNow(ctx, name)
+	}
+
+	return checker.CheckWithFilter(ctx, filter)
+}
@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshLimiterAllowsOneRefreshPerInterval(t *testing.T) {
+	// Arrange
+	limiter := refreshLimiter{minInterval: 1 * time.Hour}
+
+	// Act
+	_, firstAllowed := limiter.allow()
+	wait, secondAllowed := limiter.allow()
+
+	// Assert
+	assert.True(t, firstAllowed)
+	assert.False(t, secondAllowed)
+	assert.Greater(t, wait, 59*time.Minute)
+}
+
+func TestHandlerRefreshBypassesCache(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}}),
+	)
+	handler := NewHandler(ckr, WithRefreshEnabled(), WithRefreshRateLimit(1*time.Hour))
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health?refresh=true", nil))
+	refreshed := httptest.NewRecorder()
+	handler.ServeHTTP(refreshed, httptest.NewRequest(http.MethodPost, "/health?refresh=true", nil))
+	limited := httptest.NewRecorder()
+	handler.ServeHTTP(limited, httptest.NewRequest(http.MethodPost, "/health?refresh=true", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, refreshed.Code)
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "3600", limited.Header().Get("Retry-After"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHandlerRefreshIsDisabledByDefault(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}}),
+	)
+	handler := NewHandler(ckr)
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/health?refresh=true", nil))
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHandlerRefreshOnlyExecutesFilteredChecks(t *testing.T) {
+	// Arrange
+	var taggedCalls, otherCalls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithCheck(Check{Name: "database", Tags: []string{"ready"}, Check: func(ctx context.Context) error {
+			atomic.AddInt32(&taggedCalls, 1)
+			return nil
+		}}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&otherCalls, 1)
+			return nil
+		}}),
+	)
+	handler := NewHandler(ckr, WithTagFilter("ready"), WithRefreshEnabled())
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/health?refresh=true", nil))
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&taggedCalls))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&otherCalls))
+}
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ConfigChange describes how Checker.Reload changed the set of checks (see WithConfigChangeListener).
+type ConfigChange struct {
+	// Added holds the names of all checks that were added, in alphabetical order.
+	Added []string
+	// Removed holds the names of all checks that were removed, in alphabetical order.
+	Removed []string
+	// Updated holds the names of all checks whose configuration has changed, in alphabetical order.
+	Updated []string
+}
+
+// Reload implements Checker.Reload. Please refer to Checker.Reload for more information.
+func (ck *defaultChecker) Reload(cfg CheckerConfig) (err error) {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	// Only checks whose configuration has changed are created again, so that unchanged checks
+	// keep their resources (e.g., connection pools, see DisposableCheckFactory).
+	configChecks, err := cfg.newChecks(ck.cfg.checks)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			disposeCreatedChecks(configChecks, ck.cfg.checks, ck.cfg.logger)
+		}
+	}()
+
+	var (
+		change   ConfigChange
+		checks   = make(map[string]*Check, len(ck.cfg.checks)+len(configChecks))
+		replaced []*Check
+	)
+
+	for name, check := range ck.cfg.checks {
+		newCheck, ok := configChecks[name]
+
+		switch {
+		case check.config == nil && ok:
+			return fmt.Errorf("check %q already exists", name)
+		case check.config == nil || newCheck == check:
+			checks[name] = check
+		case ok:
+			checks[name] = newCheck
+			change.Updated = append(change.Updated, name)
+			replaced = append(replaced, check)
+		default:
+			change.Removed = append(change.Removed, name)
+			replaced = append(replaced, check)
+		}
+	}
+
+	for name, check := range configChecks {
+		if _, ok := ck.cfg.checks[name]; !ok {
+			checks[name] = check
+			change.Added = append(change.Added, name)
+		}
+	}
+
+	checkLevels, err := orderByDependencies(checks)
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(change.Added)
+	sort.Strings(change.Removed)
+	sort.Strings(change.Updated)
+
+	for _, name := range append(change.Removed, change.Updated...) {
+		if cancel, ok := ck.periodicChecks[name]; ok {
+			cancel()
+			delete(ck.periodicChecks, name)
+		}
+	}
+
+	ck.stateMtx.Lock()
+	cfg.applyGlobalSettings(&ck.cfg)
+	ck.cfg.checks = checks
+	ck.checkLevels = checkLevels
+	for _, name := range change.Removed {
+		delete(ck.state.CheckState, name)
+		delete(ck.history, name)
+		delete(ck.maintenance, name)
+		delete(ck.disabled, name)
+		delete(ck.availability, name)
+		delete(ck.incidents, name)
+	}
+	ck.stateMtx.Unlock()
+
+	results := make([]checkResult, 0, len(change.Added))
+	for _, name := range change.Added {
+		results = append(results, checkResult{name, CheckState{Status: StatusUnknown}})
+	}
+
+	ctx := context.Background()
+	ck.updateState(ctx, results...)
+
+	for _, check := range replaced {
+		disposeCheck(check, ck.cfg.logger)
+	}
+
+	if ck.started {
+		for _, name := range append(change.Added, change.Updated...) {
+			if isPeriodicCheck(checks[name]) {
+				ck.startPeriodicCheck(ck.ctx, checks[name])
+			}
+		}
+	}
+
+	if ck.cfg.configChangeListener != nil && len(change.Added)+len(change.Removed)+len(change.Updated) > 0 {
+		callListener(&ck.cfg, "config change listener", func() {
+			ck.cfg.configChangeListener(ctx, change)
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadAddsRemovesAndUpdatesChecks(t *testing.T) {
+	// Arrange
+	var changes []ConfigChange
+	ckr, err := NewCheckerFromConfig(CheckerConfig{Checks: []CheckConfig{
+		{Name: "database", Type: "static"},
+		{Name: "search", Type: "static", Interval: ConfigDuration(time.Hour)},
+		{Name: "cache", Type: "static"},
+	}},
+		WithConfigChangeListener(func(ctx context.Context, change ConfigChange) {
+			changes = append(changes, change)
+		}),
+		WithCheck(Check{Name: "in-code", Check: func(ctx context.Context) error { return nil }}),
+	)
+	require.NoError(t, err)
+	defer ckr.Stop()
+	ckr.Check(context.Background())
+
+	// Act
+	err = ckr.Reload(CheckerConfig{Timeout: ConfigDuration(3 * time.Second), Checks: []CheckConfig{
+		{Name: "database", Type: "static"},
+		{Name: "search", Type: "static", Interval: ConfigDuration(time.Minute)},
+		{Name: "queue", Type: "static", Params: map[string]string{"error": "connection refused"}},
+	}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []ConfigChange{{Added: []string{"queue"}, Removed: []string{"cache"}, Updated: []string{"search"}}}, changes)
+	assert.Equal(t, []string{"database", "in-code", "queue", "search"}, ckr.GetCheckNames(nil))
+	assert.Equal(t, []string{"search"}, ckr.GetRunningPeriodicCheckNames())
+	assert.Equal(t, 3*time.Second, ckr.(*defaultChecker).cfg.timeout)
+
+	state, _ := ckr.GetCheckState("database")
+	assert.Equal(t, StatusUp, state.Status)
+
+	res := ckr.Check(context.Background())
+	assert.Equal(t, StatusDown, res.Status)
+	assert.EqualError(t, res.Details["queue"].Error, "connection refused")
+}
+
+func TestReloadWithInvalidConfigChangesNothing(t *testing.T) {
+	// Arrange
+	ckr, err := NewCheckerFromConfig(CheckerConfig{Checks: []CheckConfig{{Name: "database", Type: "static"}}},
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "in-code", Check: func(ctx context.Context) error { return nil }}),
+	)
+	require.NoError(t, err)
+
+	// Act
+	unknownTypeErr := ckr.Reload(CheckerConfig{Checks: []CheckConfig{{Name: "search", Type: "unknown"}}})
+	conflictErr := ckr.Reload(CheckerConfig{Checks: []CheckConfig{{Name: "in-code", Type: "static"}}})
+
+	// Assert
+	assert.Error(t, unknownTypeErr)
+	assert.EqualError(t, conflictErr, `check "in-code" already exists`)
+	assert.Equal(t, []string{"database", "in-code"}, ckr.GetCheckNames(nil))
+}
+
+type disposableCheckCounter struct {
+	mtx      sync.Mutex
+	created  map[string]int
+	disposed map[string]int
+}
+
+var disposableChecks = disposableCheckCounter{created: map[string]int{}, disposed: map[string]int{}}
+
+func init() {
+	RegisterDisposableCheckFactory("disposable", func(params map[string]string) (func(ctx context.Context) error, func() error, error) {
+		id := params["id"]
+
+		disposableChecks.mtx.Lock()
+		disposableChecks.created[id]++
+		disposableChecks.mtx.Unlock()
+
+		dispose := func() error {
+			disposableChecks.mtx.Lock()
+			defer disposableChecks.mtx.Unlock()
+			disposableChecks.disposed[id]++
+			return nil
+		}
+
+		return func(ctx context.Context) error { return nil }, dispose, nil
+	})
+}
+
+func (c *disposableCheckCounter) counts(id string) (int, int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.created[id], c.disposed[id]
+}
+
+func TestReloadOnlyCreatesChangedChecksAndDisposesReplacedChecks(t *testing.T) {
+	// Arrange
+	ckr, err := NewCheckerFromConfig(CheckerConfig{Checks: []CheckConfig{
+		{Name: "unchanged", Type: "disposable", Params: map[string]string{"id": "reload-unchanged"}},
+		{Name: "updated", Type: "disposable", Params: map[string]string{"id": "reload-updated"}},
+		{Name: "removed", Type: "disposable", Params: map[string]string{"id": "reload-removed"}},
+	}}, WithDisabledAutostart())
+	require.NoError(t, err)
+
+	// Act
+	err = ckr.Reload(CheckerConfig{Checks: []CheckConfig{
+		{Name: "unchanged", Type: "disposable", Params: map[string]string{"id": "reload-unchanged"}},
+		{Name: "updated", Type: "disposable", Params: map[string]string{"id": "reload-updated"}, Timeout: ConfigDuration(time.Second)},
+	}})
+
+	// Assert
+	require.NoError(t, err)
+
+	created, disposed := disposableChecks.counts("reload-unchanged")
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 0, disposed)
+
+	created, disposed = disposableChecks.counts("reload-updated")
+	assert.Equal(t, 2, created)
+	assert.Equal(t, 1, disposed)
+
+	created, disposed = disposableChecks.counts("reload-removed")
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 1, disposed)
+}
+
+func TestReloadWithInvalidConfigDisposesCreatedChecks(t *testing.T) {
+	// Arrange
+	ckr, err := NewCheckerFromConfig(CheckerConfig{}, WithDisabledAutostart(),
+		WithCheck(Check{Name: "in-code", Check: func(ctx context.Context) error { return nil }}))
+	require.NoError(t, err)
+
+	// Act
+	err = ckr.Reload(CheckerConfig{Checks: []CheckConfig{
+		{Name: "valid", Type: "disposable", Params: map[string]string{"id": "invalid-valid"}},
+		{Name: "in-code", Type: "disposable", Params: map[string]string{"id": "invalid-conflict"}},
+	}})
+
+	// Assert
+	assert.Error(t, err)
+	for _, id := range []string{"invalid-valid", "invalid-conflict"} {
+		created, disposed := disposableChecks.counts(id)
+		assert.Equal(t, 1, created, id)
+		assert.Equal(t, 1, disposed, id)
+	}
+}
+
+func TestRemoveCheckDisposesCheck(t *testing.T) {
+	// Arrange
+	ckr, err := NewCheckerFromConfig(CheckerConfig{Checks: []CheckConfig{
+		{Name: "database", Type: "disposable", Params: map[string]string{"id": "remove"}},
+	}}, WithDisabledAutostart())
+	require.NoError(t, err)
+
+	// Act
+	err = ckr.RemoveCheck("database")
+
+	// Assert
+	require.NoError(t, err)
+	_, disposed := disposableChecks.counts("remove")
+	assert.Equal(t, 1, disposed)
+}
@@ -0,0 +1,98 @@
+package health
+
+import (
+	"errors"
+	"regexp"
+)
+
+// redactedPlaceholder replaces sensitive parts of error messages (see ScrubSecrets).
+const redactedPlaceholder = "[REDACTED]"
+
+type (
+	// sanitizedError is an error with a sanitized message (see WithErrorSanitizer). It still wraps the
+	// original error, so that errors.Is and errors.As keep working on check results.
+	sanitizedError struct {
+		message string
+		err     error
+	}
+
+	secretPattern struct {
+		pattern     *regexp.Regexp
+		replacement string
+	}
+)
+
+// secretPatterns holds the patterns that are redacted by ScrubSecrets.
+var secretPatterns = []secretPattern{
+	// Passwords in URLs and DSNs, e.g., "postgres://user:secret@db:5432".
+	{regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://[^:/@\s]*:)[^\s/]+@`), "${1}" + redactedPlaceholder + "@"},
+	// Passwords in Go MySQL driver DSNs, e.g., "user:secret@tcp(db:3306)/app".
+	{regexp.MustCompile(`([\w.-]+:)[^@\s/]+@(tcp|udp|unix)\(`), "${1}" + redactedPlaceholder + "@${2}("},
+	// Key/value pairs, e.g., "password=secret" or "api_key: secret".
+	{
+		regexp.MustCompile(`(?i)\b(password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key)(\s*[=:]\s*)("[^"]*"|'[^']*'|[^\s;,&]+)`),
+		"${1}${2}" + redactedPlaceholder,
+	},
+	// Bearer tokens, e.g., in echoed authorization headers.
+	{regexp.MustCompile(`(?i)\b(bearer\s+)[a-zA-Z0-9._~+/-]+=*`), "${1}" + redactedPlaceholder},
+	// Private IPv4 addresses (RFC 1918).
+	{
+		regexp.MustCompile(`\b(10(\.\d{1,3}){3}|172\.(1[6-9]|2\d|3[01])(\.\d{1,3}){2}|192\.168(\.\d{1,3}){2})\b`),
+		redactedPlaceholder,
+	},
+}
+
+// WithErrorSanitizer sets a function that turns check errors into the message that is exposed by the Checker.
+// It is applied to the error of a check function right after the function returned (or panicked), before
+// any interceptor runs, so interceptors, the panic handler (see WithPanicHandler), status listeners, lifecycle
+// hooks, publishers, logs and check results only ever see the sanitized message. The stack trace of a panic
+// (see PanicError) is sanitized as well. Errors that are created by interceptors are not sanitized. This allows to
+// redact sensitive information, such as passwords in connection strings or internal IP addresses, in a
+// single place. The sanitized error still wraps the original error, so that errors.Is and errors.As keep
+// working (e.g., for CheckTimeoutErr). ScrubSecrets can be used as a default sanitizer.
+func WithErrorSanitizer(sanitizer func(error) string) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.errorSanitizer = sanitizer
+	}
+}
+
+// ScrubSecrets is an error sanitizer (see WithErrorSanitizer) that redacts passwords in URLs and DSNs,
+// the values of key/value pairs that look like credentials (e.g., "password=..." or "token: ..."),
+// bearer tokens and private IPv4 addresses from the error message.
+func ScrubSecrets(err error) string {
+	message := err.Error()
+	for _, p := range secretPatterns {
+		message = p.pattern.ReplaceAllString(message, p.replacement)
+	}
+	return message
+}
+
+// sanitizeError applies the configured error sanitizer (see WithErrorSanitizer).
+func sanitizeError(cfg *checkerConfig, err error) error {
+	if err == nil || cfg.errorSanitizer == nil {
+		return err
+	}
+
+	return &sanitizedError{message: cfg.errorSanitizer(err), err: err}
+}
+
+// sanitizePanicError applies the configured error sanitizer (see WithErrorSanitizer) to the recovered value
+// and to the stack trace of the panic. The sanitized value still wraps the recovered value if it is an error.
+func sanitizePanicError(cfg *checkerConfig, err *PanicError) *PanicError {
+	if cfg.errorSanitizer == nil {
+		return err
+	}
+
+	return &PanicError{
+		Value: &sanitizedError{message: cfg.errorSanitizer(err), err: err.Unwrap()},
+		Stack: []byte(cfg.errorSanitizer(errors.New(string(err.Stack)))),
+	}
+}
+
+func (e *sanitizedError) Error() string {
+	return e.message
+}
+
+func (e *sanitizedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,76 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// maxSelectionBodySize is the maximum size of a POST request body that selects checks (see WithCheckSelection).
+const maxSelectionBodySize = 1 << 20
+
+// selectRequestedChecks narrows down the filter to the checks that were selected by the "check"
+// query parameter or the body of a POST request (see WithCheckSelection). It returns an error if
+// a selected check does not exist or is not accepted by the filter.
+func selectRequestedChecks(r *http.Request, checker Checker, filter CheckFilter) (CheckFilter, error) {
+	names := r.URL.Query()["check"]
+	if r.Method == http.MethodPost {
+		bodyNames, err := readSelectedChecksFromBody(r)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, bodyNames...)
+	}
+
+	if len(names) == 0 {
+		return filter, nil
+	}
+
+	available := map[string]bool{}
+	for _, name := range checker.GetCheckNames(filter) {
+		available[name] = true
+	}
+
+	for _, name := range names {
+		if !available[name] {
+			return nil, fmt.Errorf("unknown check %q", name)
+		}
+	}
+
+	nameFilter := NameFilter(names...)
+	return func(check Check) bool {
+		return nameFilter(check) && (filter == nil || filter(check))
+	}, nil
+}
+
+// readSelectedChecksFromBody reads the names of the selected checks from the body of a POST request. The body
+// is either a form (e.g., "check=database&check=cache") or a JSON object (e.g., {"checks":["database","cache"]}).
+func readSelectedChecksFromBody(r *http.Request) ([]string, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	body := io.LimitReader(r.Body, maxSelectionBodySize)
+
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		r.Body = io.NopCloser(body)
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+		return r.PostForm["check"], nil
+	case "application/json":
+		var selection struct {
+			Checks []string `json:"checks"`
+		}
+		if err := json.NewDecoder(body).Decode(&selection); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("invalid request body: %w", err)
+		}
+		return selection.Checks, nil
+	default:
+		return nil, nil
+	}
+}
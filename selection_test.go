@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerCheckSelection(t *testing.T) {
+	// Arrange
+	var databaseCalls, searchCalls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&databaseCalls, 1)
+			return nil
+		}}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&searchCalls, 1)
+			return nil
+		}}),
+	)
+	handler := NewHandler(ckr, WithCheckSelection())
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health?check=database", nil))
+
+	// Assert
+	var result CheckerResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, result.Details, 1)
+	assert.Contains(t, result.Details, "database")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&databaseCalls))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&searchCalls))
+}
+
+func TestHandlerCheckSelectionRejectsUnknownChecks(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Tags: []string{"ready"}, Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewHandler(ckr, WithCheckSelection(), WithTagFilter("ready"))
+
+	// Act
+	unknown := httptest.NewRecorder()
+	handler.ServeHTTP(unknown, httptest.NewRequest(http.MethodGet, "/health?check=cache", nil))
+	excluded := httptest.NewRecorder()
+	handler.ServeHTTP(excluded, httptest.NewRequest(http.MethodGet, "/health?check=search", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, unknown.Code)
+	assert.Equal(t, http.StatusBadRequest, excluded.Code)
+}
+
+func TestHandlerCheckSelectionIsDisabledByDefault(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error { return nil }}),
+	)
+	w := httptest.NewRecorder()
+
+	// Act
+	NewHandler(ckr).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health?check=database", nil))
+
+	// Assert
+	var result CheckerResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Len(t, result.Details, 2)
+}
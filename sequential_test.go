@@ -0,0 +1,68 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequentialExecutionOrdersChecksByPriority(t *testing.T) {
+	// Arrange
+	var (
+		mtx   sync.Mutex
+		order []string
+	)
+	check := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mtx.Lock()
+			defer mtx.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithSequentialExecution(),
+		WithCheck(Check{Name: "cache", Check: check("cache")}),
+		WithCheck(Check{Name: "database", Priority: 10, Check: check("database")}),
+		WithCheck(Check{Name: "search", Check: check("search")}),
+		WithCheck(Check{Name: "api", DependsOn: []string{"search"}, Priority: 100, Check: check("api")}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Equal(t, []string{"database", "cache", "search", "api"}, order)
+}
+
+func TestSequentialExecutionSkipsChecksWhenBudgetIsExhausted(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithTimeout(20*time.Millisecond),
+		WithSequentialExecution(),
+		WithCheck(Check{Name: "database", Priority: 1, Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error {
+			return nil
+		}}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDown, res.Status)
+	assert.True(t, res.Details["database"].TimedOut)
+	assert.Equal(t, StatusSkipped, res.Details["search"].Status)
+	assert.ErrorIs(t, res.Details["search"].Error, BudgetExhaustedErr)
+	assert.False(t, res.Details["search"].TimedOut)
+}
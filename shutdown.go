@@ -0,0 +1,49 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ShutdownOnSignal marks the system as unavailable as soon as one of the provided signals is received
+// (see Checker.SetUnhealthy). It then waits for the provided drain period, so that load balancers can detect
+// the status change and stop routing traffic to this instance, before it shuts down the Checker
+// (see Checker.Shutdown). The returned channel is closed after the Checker was shut down, so that
+// the application can stop its HTTP server and terminate afterwards, e.g.:
+//
+//	<-health.ShutdownOnSignal(checker, 10*time.Second)
+//	server.Shutdown(context.Background())
+//
+// If no signals are provided, os.Interrupt and syscall.SIGTERM are used.
+func ShutdownOnSignal(checker Checker, drainPeriod time.Duration, signals ...os.Signal) <-chan struct{} {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, signals...)
+
+	return shutdownOnSignal(checker, drainPeriod, signalChan, func() { signal.Stop(signalChan) })
+}
+
+func shutdownOnSignal(checker Checker, drainPeriod time.Duration, signals <-chan os.Signal, stop func()) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		sig := <-signals
+		stop()
+
+		checker.SetUnhealthy(fmt.Sprintf("received signal %v", sig))
+		time.Sleep(drainPeriod)
+		//nolint:errcheck
+		checker.Shutdown(context.Background())
+	}()
+
+	return done
+}
@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetUnhealthyReportsSystemAsDown(t *testing.T) {
+	// Arrange
+	var lastState CheckerState
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithStatusListener(func(ctx context.Context, state CheckerState) { lastState = state }),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	resBefore := ckr.Check(context.Background())
+	ckr.SetUnhealthy("draining")
+	resAfter := ckr.Check(context.Background())
+	ckr.Pause()
+	resPaused := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusUp, resBefore.Status)
+	assert.Equal(t, StatusDown, resAfter.Status)
+	assert.Equal(t, StatusUp, resAfter.Details["database"].Status)
+	assert.Equal(t, StatusDown, resPaused.Status)
+	assert.Equal(t, StatusDown, lastState.Status)
+	assert.Equal(t, "draining", lastState.UnhealthyReason)
+}
+
+func TestShutdownStopsChecker(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithPeriodicCheck(1*time.Hour, 0, Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	err := ckr.Shutdown(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, ckr.IsStarted())
+	assert.Equal(t, 0, ckr.GetRunningPeriodicCheckCount())
+	assert.Equal(t, StatusDown, ckr.Check(context.Background()).Status)
+}
+
+func TestShutdownOnSignal(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithPeriodicCheck(1*time.Hour, 0, Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	signals := make(chan os.Signal, 1)
+	stopped := false
+
+	// Act
+	done := shutdownOnSignal(ckr, 10*time.Millisecond, signals, func() { stopped = true })
+	signals <- os.Interrupt
+	<-done
+
+	// Assert
+	assert.True(t, stopped)
+	assert.False(t, ckr.IsStarted())
+	assert.Equal(t, StatusDown, ckr.Check(context.Background()).Status)
+}
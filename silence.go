@@ -0,0 +1,113 @@
+package health
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// Silence suppresses status notifications of a set of checks within a time window (e.g., during a planned
+	// deployment of a dependency). In contrast to maintenance (see Checker.SetMaintenance), silenced checks are
+	// still executed, their results are recorded and they still contribute to the aggregated status. However,
+	// the status listeners and lifecycle hooks of silenced checks are not called, silenced components are not
+	// included in webhook requests (see WithWebhookListener), and they are marked as silenced in check results
+	// (see CheckResult.Silenced). A silence applies to all checks that either have one of the listed names or
+	// carry at least one of the listed tags (see Check.Tags).
+	Silence struct {
+		// Checks holds the names of the checks that are silenced.
+		Checks []string
+		// Tags holds the tags of the checks that are silenced (see Check.Tags).
+		Tags []string
+		// StartsAt holds the time when the silence begins. If zero, the silence begins immediately.
+		StartsAt time.Time
+		// EndsAt holds the time when the silence ends. If zero, the silence does not end
+		// until it is removed (see Checker.RemoveSilence).
+		EndsAt time.Time
+		// Comment describes why the checks are silenced.
+		Comment string
+	}
+
+	silenceRegistry struct {
+		mtx      sync.RWMutex
+		nextID   int
+		silences map[string]Silence
+	}
+)
+
+// WithSilence adds a silence (see Silence). This option can be used multiple times to add more than one silence.
+// Silences can also be added while the Checker is running (see Checker.AddSilence).
+func WithSilence(silence Silence) CheckerOption {
+	return func(cfg *checkerConfig) {
+		if cfg.silences == nil {
+			cfg.silences = newSilenceRegistry()
+		}
+		cfg.silences.add(silence)
+	}
+}
+
+// AddSilence implements Checker.AddSilence. Please refer to Checker.AddSilence for more information.
+func (ck *defaultChecker) AddSilence(silence Silence) string {
+	return ck.cfg.silences.add(silence)
+}
+
+// RemoveSilence implements Checker.RemoveSilence. Please refer to Checker.RemoveSilence for more information.
+func (ck *defaultChecker) RemoveSilence(id string) bool {
+	return ck.cfg.silences.remove(id)
+}
+
+func newSilenceRegistry() *silenceRegistry {
+	return &silenceRegistry{silences: map[string]Silence{}}
+}
+
+func (r *silenceRegistry) add(silence Silence) string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	// Slices are copied, because they might be modified by the caller later on.
+	silence.Checks = append([]string(nil), silence.Checks...)
+	silence.Tags = append([]string(nil), silence.Tags...)
+
+	r.nextID++
+	id := strconv.Itoa(r.nextID)
+	r.silences[id] = silence
+
+	return id
+}
+
+func (r *silenceRegistry) remove(id string) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	_, ok := r.silences[id]
+	delete(r.silences, id)
+
+	return ok
+}
+
+// isSilenced returns true if an active silence applies to the check. It is safe to call this function
+// on a nil registry.
+func (r *silenceRegistry) isSilenced(now time.Time, check *Check) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	for _, silence := range r.silences {
+		if silence.isActive(now) && silence.matches(check) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Silence) isActive(now time.Time) bool {
+	return (s.StartsAt.IsZero() || !now.Before(s.StartsAt)) && (s.EndsAt.IsZero() || now.Before(s.EndsAt))
+}
+
+func (s *Silence) matches(check *Check) bool {
+	return NameFilter(s.Checks...)(*check) || TagFilter(s.Tags...)(*check)
+}
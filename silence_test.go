@@ -0,0 +1,141 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSilenceSuppressesStatusListeners(t *testing.T) {
+	// Arrange
+	var notified []string
+	listener := func(ctx context.Context, name string, state CheckState) {
+		notified = append(notified, name)
+	}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Tags: []string{"storage"}, StatusListener: listener,
+			Check: func(ctx context.Context) error { return errors.New("connection refused") }}),
+		WithCheck(Check{Name: "search", StatusListener: listener,
+			Check: func(ctx context.Context) error { return errors.New("connection refused") }}),
+		WithSilence(Silence{Tags: []string{"storage"}, Comment: "database migration"}),
+	)
+
+	// Act
+	res := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, []string{"search"}, notified)
+	assert.Equal(t, StatusDown, res.Status)
+	assert.Equal(t, StatusDown, res.Details["database"].Status)
+	assert.True(t, res.Details["database"].Silenced)
+	assert.False(t, res.Details["search"].Silenced)
+
+	state, _ := ckr.GetCheckState("database")
+	assert.True(t, state.Silenced)
+}
+
+func TestSilenceIsOnlyActiveWithinTimeWindow(t *testing.T) {
+	// Arrange
+	var notifications int
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	failing := true
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithClock(clock),
+		WithCheck(Check{Name: "database",
+			StatusListener: func(ctx context.Context, name string, state CheckState) { notifications++ },
+			Check: func(ctx context.Context) error {
+				if failing {
+					return errors.New("connection refused")
+				}
+				return nil
+			}}),
+	)
+	ckr.AddSilence(Silence{Checks: []string{"database"}, StartsAt: clock.Now().Add(1 * time.Minute),
+		EndsAt: clock.Now().Add(2 * time.Minute)})
+
+	// Act
+	before := ckr.Check(context.Background())
+	clock.advance(90 * time.Second)
+	failing = false
+	during := ckr.Check(context.Background())
+	clock.advance(1 * time.Minute)
+	failing = true
+	after := ckr.Check(context.Background())
+
+	// Assert
+	assert.False(t, before.Details["database"].Silenced)
+	assert.True(t, during.Details["database"].Silenced)
+	assert.Equal(t, StatusUp, during.Details["database"].Status)
+	assert.False(t, after.Details["database"].Silenced)
+	assert.Equal(t, 2, notifications)
+}
+
+func TestRemoveSilence(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	id := ckr.AddSilence(Silence{Checks: []string{"database"}})
+
+	// Act
+	during := ckr.Check(context.Background())
+	removed := ckr.RemoveSilence(id)
+	after := ckr.Check(context.Background())
+
+	// Assert
+	assert.True(t, removed)
+	assert.False(t, ckr.RemoveSilence(id))
+	assert.True(t, during.Details["database"].Silenced)
+	assert.False(t, after.Details["database"].Silenced)
+}
+
+func TestWebhookListenerOmitsSilencedComponents(t *testing.T) {
+	// Arrange
+	bodies := make(chan []byte, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	defer server.Close()
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", NonCritical: true,
+			Check: func(ctx context.Context) error { return errors.New("connection refused") }}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error { return nil }}),
+		WithSilence(Silence{Checks: []string{"database"}}),
+		WithWebhookListener(server.URL),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	var body []byte
+	select {
+	case body = <-bodies:
+	case <-time.After(1 * time.Second):
+		require.Fail(t, "webhook was not called")
+	}
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, StatusUp, payload.Status)
+	assert.Contains(t, payload.Components, "search")
+	assert.NotContains(t, payload.Components, "database")
+}
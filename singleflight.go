@@ -0,0 +1,66 @@
+package health
+
+// joinInFlightChecks returns a filter that accepts all checks that are accepted by the provided filter, except
+// for checks that are currently executed by another check run (see WithSingleFlight). Because check runs are
+// serialized by ck.mtx, the results of these checks are available as soon as the caller acquired ck.mtx.
+// Must be called before acquiring ck.mtx.
+func (ck *defaultChecker) joinInFlightChecks(filter CheckFilter) CheckFilter {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	if len(ck.inFlight) == 0 {
+		return filter
+	}
+
+	joined := make(map[string]struct{}, len(ck.inFlight))
+	for name := range ck.inFlight {
+		joined[name] = struct{}{}
+	}
+
+	return func(check Check) bool {
+		if _, ok := joined[check.Name]; ok {
+			return false
+		}
+		return isIncluded(filter, &check)
+	}
+}
+
+// markInFlight marks all checks that will be executed by runChecks as in-flight, so that concurrent check runs
+// can join them instead of executing them again (see WithSingleFlight). It returns the names of the marked
+// checks, which need to be passed to unmarkInFlight after the results have been stored.
+// Must be called while holding ck.mtx.
+func (ck *defaultChecker) markInFlight(filter CheckFilter, force bool) []string {
+	if !ck.cfg.singleFlight {
+		return nil
+	}
+
+	var names []string
+	for _, check := range ck.cfg.checks {
+		if ck.isDue(check, filter, force) {
+			names = append(names, check.Name)
+		}
+	}
+
+	ck.stateMtx.Lock()
+	defer ck.stateMtx.Unlock()
+
+	for _, name := range names {
+		ck.inFlight[name] = struct{}{}
+	}
+
+	return names
+}
+
+// unmarkInFlight removes the in-flight mark of the checks (see markInFlight).
+func (ck *defaultChecker) unmarkInFlight(names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	ck.stateMtx.Lock()
+	defer ck.stateMtx.Unlock()
+
+	for _, name := range names {
+		delete(ck.inFlight, name)
+	}
+}
@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleFlightSharesConcurrentExecution(t *testing.T) {
+	// Arrange
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithSingleFlight(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(started)
+				<-release
+			}
+			return nil
+		}}),
+	)
+
+	// Act
+	var wg sync.WaitGroup
+	results := make(chan CheckerResult, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- ckr.Check(context.Background())
+		}()
+
+		if i == 0 {
+			<-started
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for result := range results {
+		assert.Equal(t, StatusUp, result.Status)
+	}
+}
+
+func TestSingleFlightExecutesChecksAgainAfterCompletion(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithSingleFlight(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
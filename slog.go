@@ -0,0 +1,108 @@
+//go:build go1.21
+
+package health
+
+import (
+	"context"
+	"log/slog"
+)
+
+type (
+	// SlogLevels defines the levels at which check executions are logged (see WithSlog).
+	SlogLevels struct {
+		// Success is the level of executions that succeeded. Default is slog.LevelDebug.
+		Success slog.Level
+		// Failure is the level of executions that failed without the check being down (e.g., because
+		// Check.MaxContiguousFails was not reached yet, or because the check is degraded). Default is slog.LevelWarn.
+		Failure slog.Level
+		// Down is the level of executions after which the check is down. Default is slog.LevelError.
+		Down slog.Level
+		// Transition is the level of status changes of a check. Default is slog.LevelInfo.
+		Transition slog.Level
+	}
+
+	// SlogOption is a configuration option for WithSlog.
+	SlogOption func(cfg *slogConfig)
+
+	slogConfig struct {
+		levels      SlogLevels
+		checkLevels map[string]SlogLevels
+	}
+)
+
+// DefaultSlogLevels returns the levels that WithSlog uses by default (see SlogLevels).
+func DefaultSlogLevels() SlogLevels {
+	return SlogLevels{
+		Success:    slog.LevelDebug,
+		Failure:    slog.LevelWarn,
+		Down:       slog.LevelError,
+		Transition: slog.LevelInfo,
+	}
+}
+
+// WithSlog logs every check execution and every status change of a check with the provided *slog.Logger.
+// Executions are logged with the message "health check executed" at a level that depends on the result
+// (see SlogLevels), status changes with the message "health check status changed". Each record contains the
+// attributes "check" (the check name), "status", "duration" and, if the check failed, "error". Status changes
+// additionally contain the attribute "previousStatus". Executions are logged by an interceptor that is added
+// to all checks (see WithInterceptors), so checks that are skipped (see Check.DependsOn) are not logged.
+// This option requires Go 1.21 or later. Use WithLogger to log internal events of the Checker.
+func WithSlog(logger *slog.Logger, options ...SlogOption) CheckerOption {
+	cfg := slogConfig{levels: DefaultSlogLevels(), checkLevels: map[string]SlogLevels{}}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return WithInterceptors(func(next InterceptorFunc) InterceptorFunc {
+		return func(ctx context.Context, name string, state CheckState) CheckState {
+			result := next(ctx, name, state)
+			cfg.log(ctx, logger, name, state, result)
+			return result
+		}
+	})
+}
+
+// WithSlogLevels sets the levels at which check executions are logged (see SlogLevels).
+func WithSlogLevels(levels SlogLevels) SlogOption {
+	return func(cfg *slogConfig) {
+		cfg.levels = levels
+	}
+}
+
+// WithSlogCheckLevels overrides the levels at which executions of the check with the provided name are logged
+// (e.g., to log failures of a non-critical check at slog.LevelInfo only). This option can be used multiple times.
+func WithSlogCheckLevels(name string, levels SlogLevels) SlogOption {
+	return func(cfg *slogConfig) {
+		cfg.checkLevels[name] = levels
+	}
+}
+
+func (cfg *slogConfig) log(ctx context.Context, logger *slog.Logger, name string, oldState, newState CheckState) {
+	levels, ok := cfg.checkLevels[name]
+	if !ok {
+		levels = cfg.levels
+	}
+
+	attrs := []slog.Attr{
+		slog.String("check", name),
+		slog.String("status", string(newState.Status)),
+		slog.Duration("duration", newState.LastCheckDuration),
+	}
+	if newState.Result != nil {
+		attrs = append(attrs, slog.String("error", newState.Result.Error()))
+	}
+
+	level := levels.Success
+	if newState.Status == StatusDown {
+		level = levels.Down
+	} else if newState.Result != nil {
+		level = levels.Failure
+	}
+
+	logger.LogAttrs(ctx, level, "health check executed", attrs...)
+
+	if oldState.Status != newState.Status {
+		attrs = append(attrs, slog.String("previousStatus", string(oldState.Status)))
+		logger.LogAttrs(ctx, levels.Transition, "health check status changed", attrs...)
+	}
+}
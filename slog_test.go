@@ -0,0 +1,68 @@
+//go:build go1.21
+
+package health
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSlogLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+}
+
+func TestSlogLogsExecutionsAndTransitions(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithSlog(newTestSlogLogger(&buf)),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t,
+		"level=ERROR msg=\"health check executed\" check=database status=down error=\"connection refused\"\n"+
+			"level=INFO msg=\"health check status changed\" check=database status=down error=\"connection refused\" "+
+			"previousStatus=unknown\n",
+		buf.String())
+}
+
+func TestSlogUsesPerCheckLevels(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	levels := DefaultSlogLevels()
+	levels.Down = slog.LevelInfo
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithSlog(newTestSlogLogger(&buf), WithSlogCheckLevels("search", levels)),
+		WithCheck(Check{Name: "search", NonCritical: true, Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+
+	// Assert
+	assert.Contains(t, buf.String(), "level=INFO msg=\"health check executed\" check=search status=down")
+	assert.NotContains(t, buf.String(), "level=ERROR")
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// detachedContext is a context that carries the values of its parent but is neither cancelled nor
+// has a deadline when the parent is. It allows a check run to complete in the background after the
+// caller stopped waiting for it (see WithStaleWhileRevalidate).
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
+
+// checkWithSoftDeadline executes all checks that are accepted by the filter in the background and waits
+// for their results until the soft deadline has passed (see WithStaleWhileRevalidate). If the check run
+// did not complete in time, the last known results are returned and marked as stale. Only one check run
+// is executed in the background at a time. Requests that arrive while it is still running do not start
+// another one, but wait for it to complete until the soft deadline has passed.
+func (ck *defaultChecker) checkWithSoftDeadline(ctx context.Context, filter CheckFilter) CheckerResult {
+	results, done := ck.revalidate(ctx, filter)
+
+	select {
+	case result := <-results:
+		return result
+	case <-done:
+		// Another request started the check run, so the results are read from the state.
+		ck.stateMtx.RLock()
+		defer ck.stateMtx.RUnlock()
+		return ck.mapStateToCheckerResult(filter)
+	case <-ck.cfg.clock.After(ck.cfg.staleAfter):
+	case <-ctx.Done():
+	}
+
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	result := ck.mapStateToCheckerResult(filter)
+	result.Stale = true
+
+	return result
+}
+
+// revalidate starts a check run in the background, unless another one is still running (see checkWithSoftDeadline).
+// If a check run was started, its result is sent on the returned results channel. Otherwise, the results channel
+// is nil and the returned done channel is closed as soon as the running check run has completed.
+func (ck *defaultChecker) revalidate(ctx context.Context, filter CheckFilter) (<-chan CheckerResult, <-chan struct{}) {
+	ck.stateMtx.Lock()
+	defer ck.stateMtx.Unlock()
+
+	if ck.revalidation != nil {
+		return nil, ck.revalidation
+	}
+
+	done := make(chan struct{})
+	ck.revalidation = done
+	results := make(chan CheckerResult, 1)
+
+	go func() {
+		result := ck.check(detachedContext{ctx}, filter, false)
+
+		ck.stateMtx.Lock()
+		ck.revalidation = nil
+		ck.stateMtx.Unlock()
+
+		close(done)
+		results <- result
+	}()
+
+	return results, nil
+}
@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleWhileRevalidateReturnsStaleResult(t *testing.T) {
+	// Arrange
+	var calls int32
+	release := make(chan struct{})
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCacheDuration(1*time.Hour),
+		WithStaleWhileRevalidate(20*time.Millisecond),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return nil
+		}}),
+	)
+
+	// Act
+	stale := ckr.Check(context.Background())
+	close(release)
+	assert.Eventually(t, func() bool {
+		return ckr.GetState().CheckState["database"].Status == StatusUp
+	}, 1*time.Second, 5*time.Millisecond)
+	fresh := ckr.Check(context.Background())
+
+	// Assert
+	assert.True(t, stale.Stale)
+	assert.Equal(t, StatusUnknown, stale.Status)
+	assert.False(t, fresh.Stale)
+	assert.Equal(t, StatusUp, fresh.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestStaleWhileRevalidateReturnsFreshResultWithinSoftDeadline(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithStaleWhileRevalidate(1*time.Second),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.False(t, result.Stale)
+	assert.Equal(t, StatusUp, result.Status)
+}
+
+func TestStaleWhileRevalidateDetachesCheckContext(t *testing.T) {
+	// Arrange
+	var notCancelled atomic.Value
+	ctx, cancel := context.WithCancel(context.Background())
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithStaleWhileRevalidate(10*time.Millisecond),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			time.Sleep(30 * time.Millisecond)
+			notCancelled.Store(ctx.Err() == nil)
+			return nil
+		}}),
+	)
+
+	// Act
+	result := ckr.Check(ctx)
+	cancel()
+
+	// Assert
+	assert.True(t, result.Stale)
+	assert.Eventually(t, func() bool {
+		ok, _ := notCancelled.Load().(bool)
+		return ok
+	}, 1*time.Second, 5*time.Millisecond)
+}
+
+func TestStaleWhileRevalidateRunsOneBackgroundCheckRunAtATime(t *testing.T) {
+	// Arrange
+	var calls int32
+	release := make(chan struct{})
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithStaleWhileRevalidate(10*time.Millisecond),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return nil
+		}}),
+	)
+
+	// Act
+	results := make(chan CheckerResult, 20)
+	for i := 0; i < 20; i++ {
+		go func() { results <- ckr.Check(context.Background()) }()
+	}
+	for i := 0; i < 20; i++ {
+		assert.True(t, (<-results).Stale)
+	}
+	close(release)
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		return ckr.GetState().CheckState["database"].Status == StatusUp
+	}, 1*time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,158 @@
+package health
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+type (
+	// StatusPageOption is a configuration option for a status page handler (see NewStatusPageHandler).
+	StatusPageOption func(cfg *statusPageConfig)
+
+	statusPageConfig struct {
+		title           string
+		refreshInterval time.Duration
+	}
+
+	statusPageData struct {
+		Title          string
+		RefreshSeconds int
+		Status         AvailabilityStatus
+		GeneratedAt    string
+		Components     []statusPageComponent
+		Info           map[string]interface{}
+		InfoKeys       []string
+		HasInfo        bool
+		HasComponents  bool
+	}
+
+	statusPageComponent struct {
+		Name          string
+		Status        AvailabilityStatus
+		LastCheckedAt string
+		Duration      string
+		Error         string
+	}
+)
+
+var statusPageTemplate = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+{{if .RefreshSeconds}}<meta http-equiv="refresh" content="{{.RefreshSeconds}}">{{end}}
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.5em 0.75em; border-bottom: 1px solid #ddd; vertical-align: top; }
+th { background: #f5f5f5; }
+.status { display: inline-block; padding: 0.15em 0.6em; border-radius: 0.8em; font-weight: bold; color: #fff; background: #888; }
+.status-up { background: #2e7d32; }
+.status-down { background: #c62828; }
+.status-degraded, .status-starting { background: #ef6c00; }
+.status-maintenance { background: #1565c0; }
+.error { color: #c62828; font-family: monospace; white-space: pre-wrap; }
+.meta { color: #666; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}} <span class="status status-{{.Status}}">{{.Status}}</span></h1>
+<p class="meta">Generated at {{.GeneratedAt}}{{if .RefreshSeconds}}, refreshes every {{.RefreshSeconds}}s{{end}}</p>
+{{if .HasComponents}}
+<table>
+<tr><th>Component</th><th>Status</th><th>Last checked</th><th>Duration</th><th>Error</th></tr>
+{{range .Components}}<tr>
+<td>{{.Name}}</td>
+<td><span class="status status-{{.Status}}">{{.Status}}</span></td>
+<td>{{.LastCheckedAt}}</td>
+<td>{{.Duration}}</td>
+<td class="error">{{.Error}}</td>
+</tr>
+{{end}}</table>
+{{end}}
+{{if .HasInfo}}
+<h2>Info</h2>
+<table>
+{{range $key := .InfoKeys}}<tr><th>{{$key}}</th><td>{{index $.Info $key}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// WithStatusPageTitle sets the title of the status page. Default is "Health Status".
+func WithStatusPageTitle(title string) StatusPageOption {
+	return func(cfg *statusPageConfig) {
+		cfg.title = title
+	}
+}
+
+// WithStatusPageRefreshInterval sets the interval in which the browser reloads the status page.
+// Default value is 10 seconds. A value of 0 disables automatic reloading.
+func WithStatusPageRefreshInterval(interval time.Duration) StatusPageOption {
+	return func(cfg *statusPageConfig) {
+		cfg.refreshInterval = interval
+	}
+}
+
+// NewStatusPageHandler creates a new http.Handler that serves a self-contained HTML page (i.e., without
+// any external assets) that shows the aggregated status, the status of each component, the time and
+// duration of the last check, error messages and info values (see WithInfo). The page reloads itself
+// periodically (see WithStatusPageRefreshInterval). It is meant for humans: the page is always served
+// with HTTP status code 200, so use NewHandler for probes and monitoring systems. Since the page shows
+// error messages and info values, make sure it is only accessible to trusted users.
+func NewStatusPageHandler(checker Checker, options ...StatusPageOption) http.HandlerFunc {
+	cfg := statusPageConfig{title: "Health Status", refreshInterval: 10 * time.Second}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		result := checker.Check(r.Context())
+
+		data := statusPageData{
+			Title:          cfg.title,
+			RefreshSeconds: int(cfg.refreshInterval.Seconds()),
+			Status:         result.Status,
+			GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
+			Info:           result.Info,
+			InfoKeys:       sortedKeys(result.Info),
+			HasInfo:        len(result.Info) > 0,
+			HasComponents:  len(result.Details) > 0,
+		}
+
+		for _, name := range sortedKeys(result.Details) {
+			checkResult := result.Details[name]
+			component := statusPageComponent{
+				Name:   name,
+				Status: checkResult.Status,
+				Error:  errorMessage(checkResult.Error),
+			}
+
+			if !checkResult.Timestamp.IsZero() {
+				component.LastCheckedAt = checkResult.Timestamp.UTC().Format(time.RFC3339)
+			}
+
+			if checkResult.Duration > 0 {
+				component.Duration = checkResult.Duration.Round(time.Microsecond).String()
+			}
+
+			data.Components = append(data.Components, component)
+		}
+
+		var buf bytes.Buffer
+		if err := statusPageTemplate.Execute(&buf, &data); err != nil {
+			http.Error(w, "cannot render status page", http.StatusInternalServerError)
+			return
+		}
+
+		disableResponseCache(w)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		//nolint:errcheck
+		w.Write(buf.Bytes())
+	}
+}
@@ -0,0 +1,39 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusPageHandler(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithInfo(map[string]interface{}{"version": "v1.0.0"}),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return errors.New("connection <refused>")
+		}}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewStatusPageHandler(ckr, WithStatusPageTitle("My Service"))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	// Assert
+	body := w.Body.String()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, body, "<title>My Service</title>")
+	assert.Contains(t, body, `<meta http-equiv="refresh" content="10">`)
+	assert.Contains(t, body, `<span class="status status-down">down</span>`)
+	assert.Contains(t, body, "<td>search</td>")
+	assert.Contains(t, body, "connection &lt;refused&gt;")
+	assert.Contains(t, body, "v1.0.0")
+}
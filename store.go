@@ -0,0 +1,221 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type (
+	// StateStore persists the state of all checks, so that it can be restored after a restart
+	// (see WithStateStore). This way, a restarted instance continues to count failures
+	// (see Check.MaxContiguousFails) instead of reporting a failing dependency as healthy
+	// until the failure threshold is reached again.
+	StateStore interface {
+		// Load returns the persisted check states by check name. It must not return
+		// an error if no state was persisted yet, but an empty map instead.
+		Load(ctx context.Context) (map[string]CheckState, error)
+		// Save persists the provided check states by check name. The map is owned by the StateStore.
+		Save(ctx context.Context, states map[string]CheckState) error
+	}
+
+	// MemoryStateStore is a StateStore that keeps the check states in memory. This is useful to retain
+	// the state when a Checker is recreated within the same process (e.g., in tests).
+	MemoryStateStore struct {
+		mtx    sync.Mutex
+		states map[string]CheckState
+	}
+
+	// FileStateStore is a StateStore that persists the check states as JSON in a file.
+	FileStateStore struct {
+		mtx  sync.Mutex
+		path string
+	}
+
+	jsonCheckState struct {
+		LastCheckedAt       time.Time              `json:"lastCheckedAt"`
+		LastSuccessAt       time.Time              `json:"lastSuccessAt"`
+		LastFailureAt       time.Time              `json:"lastFailureAt"`
+		FirstCheckStartedAt time.Time              `json:"firstCheckStartedAt"`
+		ContiguousFails     uint                   `json:"contiguousFails"`
+		CheckCount          uint                   `json:"checkCount"`
+		LastCheckDuration   time.Duration          `json:"lastCheckDuration"`
+		Status              AvailabilityStatus     `json:"status"`
+		Error               string                 `json:"error,omitempty"`
+		Data                map[string]interface{} `json:"data,omitempty"`
+		TimedOut            bool                   `json:"timedOut,omitempty"`
+		Attempts            uint                   `json:"attempts,omitempty"`
+	}
+
+	stateStorePublisher struct {
+		store StateStore
+	}
+)
+
+// NewMemoryStateStore creates a new, empty MemoryStateStore.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: map[string]CheckState{}}
+}
+
+// Load implements StateStore.Load.
+func (s *MemoryStateStore) Load(_ context.Context) (map[string]CheckState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	states := make(map[string]CheckState, len(s.states))
+	for name, state := range s.states {
+		states[name] = state
+	}
+
+	return states, nil
+}
+
+// Save implements StateStore.Save.
+func (s *MemoryStateStore) Save(_ context.Context, states map[string]CheckState) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.states = states
+
+	return nil
+}
+
+// NewFileStateStore creates a new FileStateStore that persists the check states in the file at the provided path.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// Load implements StateStore.Load.
+func (s *FileStateStore) Load(_ context.Context) (map[string]CheckState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]CheckState{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("cannot read state file: %w", err)
+	}
+
+	var states map[string]CheckState
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal state file: %w", err)
+	}
+
+	return states, nil
+}
+
+// Save implements StateStore.Save. The file is replaced atomically, so that
+// a crash while saving does not leave a corrupted file behind.
+func (s *FileStateStore) Save(_ context.Context, states map[string]CheckState) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	data, err := json.Marshal(states)
+	if err != nil {
+		return fmt.Errorf("cannot marshal check states: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary state file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("cannot write temporary state file: %w", err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("cannot write temporary state file: %w", err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), s.path); err != nil {
+		return fmt.Errorf("cannot replace state file: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON provides a custom marshaller for the CheckState type, which is used to persist
+// check states (see StateStore and ResultCache). Unexported fields are not marshalled.
+func (cs CheckState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonCheckState{
+		LastCheckedAt:       cs.LastCheckedAt,
+		LastSuccessAt:       cs.LastSuccessAt,
+		LastFailureAt:       cs.LastFailureAt,
+		FirstCheckStartedAt: cs.FirstCheckStartedAt,
+		ContiguousFails:     cs.ContiguousFails,
+		CheckCount:          cs.CheckCount,
+		LastCheckDuration:   cs.LastCheckDuration,
+		Status:              cs.Status,
+		Error:               errorMessage(cs.Result),
+		Data:                cs.Data,
+		TimedOut:            cs.TimedOut,
+		Attempts:            cs.Attempts,
+	})
+}
+
+func (cs *CheckState) UnmarshalJSON(data []byte) error {
+	var state jsonCheckState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	*cs = CheckState{
+		LastCheckedAt:       state.LastCheckedAt,
+		LastSuccessAt:       state.LastSuccessAt,
+		LastFailureAt:       state.LastFailureAt,
+		FirstCheckStartedAt: state.FirstCheckStartedAt,
+		ContiguousFails:     state.ContiguousFails,
+		CheckCount:          state.CheckCount,
+		LastCheckDuration:   state.LastCheckDuration,
+		Status:              state.Status,
+		Data:                state.Data,
+		TimedOut:            state.TimedOut,
+		Attempts:            state.Attempts,
+	}
+
+	if state.Error != "" {
+		cs.Result = errors.New(state.Error)
+	}
+
+	return nil
+}
+
+// Publish implements Publisher.Publish.
+func (p *stateStorePublisher) Publish(ctx context.Context, state CheckerState) error {
+	if err := p.store.Save(ctx, state.CheckState); err != nil {
+		return fmt.Errorf("cannot save check states: %w", err)
+	}
+	return nil
+}
+
+// restoreState restores the check states from the state store (see WithStateStore). States of checks
+// that are not configured anymore are ignored. Must be called while holding ck.mtx.
+func (ck *defaultChecker) restoreState(ctx context.Context) {
+	if ck.cfg.stateStore == nil {
+		return
+	}
+
+	states, err := ck.cfg.stateStore.Load(ctx)
+	if err != nil {
+		ck.handleErr(fmt.Errorf("cannot load check states: %w", err))
+		return
+	}
+
+	var results []checkResult
+	for name, state := range states {
+		if _, ok := ck.cfg.checks[name]; ok {
+			results = append(results, checkResult{name, state})
+		}
+	}
+
+	ck.updateState(ctx, results...)
+}
@@ -0,0 +1,79 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStateStoreRoundTrip(t *testing.T) {
+	// Arrange
+	store := NewFileStateStore(filepath.Join(t.TempDir(), "state.json"))
+	now := time.Now().UTC().Truncate(time.Second)
+	states := map[string]CheckState{
+		"database": {
+			LastCheckedAt:   now,
+			LastFailureAt:   now,
+			ContiguousFails: 3,
+			CheckCount:      5,
+			Status:          StatusDown,
+			Result:          fmt.Errorf("connection refused"),
+		},
+	}
+
+	// Act
+	emptyStates, emptyErr := store.Load(context.Background())
+	saveErr := store.Save(context.Background(), states)
+	loadedStates, loadErr := store.Load(context.Background())
+
+	// Assert
+	require.NoError(t, emptyErr)
+	require.NoError(t, saveErr)
+	require.NoError(t, loadErr)
+	assert.Empty(t, emptyStates)
+	assert.Equal(t, StatusDown, loadedStates["database"].Status)
+	assert.Equal(t, uint(3), loadedStates["database"].ContiguousFails)
+	assert.Equal(t, uint(5), loadedStates["database"].CheckCount)
+	assert.True(t, now.Equal(loadedStates["database"].LastCheckedAt))
+	assert.EqualError(t, loadedStates["database"].Result, "connection refused")
+}
+
+func TestCheckerRestoresStateFromStore(t *testing.T) {
+	// Arrange
+	store := NewMemoryStateStore()
+	require.NoError(t, store.Save(context.Background(), map[string]CheckState{
+		"database": {LastCheckedAt: time.Now(), ContiguousFails: 2, Status: StatusUp, Result: errors.New("failed")},
+		"removed":  {Status: StatusDown},
+	}))
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithStateStore(store),
+		WithDisabledCache(),
+		WithCheck(Check{
+			Name:               "database",
+			MaxContiguousFails: 3,
+			Check:              func(ctx context.Context) error { return errors.New("failed") },
+		}),
+	)
+
+	// Act
+	ckr.Start()
+	defer ckr.Stop()
+	res := ckr.Check(context.Background())
+	_, removedExists := ckr.GetCheckState("removed")
+
+	// Assert
+	assert.Equal(t, StatusDown, res.Status)
+	assert.False(t, removedExists)
+	assert.Eventually(t, func() bool {
+		states, _ := store.Load(context.Background())
+		return states["database"].ContiguousFails >= 3
+	}, 1*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,194 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type (
+	// streamingStatusLine is the first and the last line of a streamed response (see WithStreaming).
+	streamingStatusLine struct {
+		Status AvailabilityStatus `json:"status"`
+		Final  bool               `json:"final,omitempty"`
+	}
+
+	// streamingComponentLine holds the result of a single component of a streamed response (see WithStreaming).
+	streamingComponentLine struct {
+		Component string      `json:"component"`
+		Result    CheckResult `json:"result"`
+	}
+
+	checkResultListenerKey struct{}
+)
+
+const (
+	// StreamingContentType is the content type of streamed responses (see WithStreaming).
+	StreamingContentType = "application/x-ndjson"
+
+	// StatusTrailer is the HTTP trailer that holds the aggregated status of a streamed response
+	// after all checks have completed (see WithStreaming).
+	StatusTrailer = "X-Health-Status"
+)
+
+// streamResults writes the last known aggregated status and the corresponding HTTP status code immediately,
+// and then streams the result of each component as soon as its check has completed (see WithStreaming).
+// All checks are executed by a single check run, which is wrapped by the configured middleware
+// (see WithHTTPMiddleware and WithMiddleware).
+func streamResults(w http.ResponseWriter, r *http.Request, checker Checker, filter CheckFilter, cfg *HandlerConfig) {
+	var (
+		encoder    = json.NewEncoder(w)
+		flusher, _ = w.(http.Flusher)
+		streamed   = map[string]bool{}
+		// Middleware may remove details from the result after the check run has completed (e.g., for
+		// unauthorized requests), so components are only written as they complete if there is none.
+		live = len(cfg.middleware) == 0
+	)
+
+	write := func(line interface{}) {
+		//nolint:errcheck
+		encoder.Encode(line)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	writeComponent := func(name string, result CheckResult) {
+		if cfg.resultLimits != nil {
+			result = limitCheckResult(result, cfg.resultLimits)
+		}
+		streamed[name] = true
+		write(streamingComponentLine{Component: name, Result: result})
+	}
+
+	result, ok := withHTTPMiddleware(w, r, cfg, func(r *http.Request) CheckerResult {
+		lastKnown := CheckerResult{Status: lastKnownStatus(checker, filter)}
+
+		disableResponseCache(w)
+		setHeaders(w, resolveResponseHeaders(&lastKnown, cfg))
+		w.Header().Set("Content-Type", StreamingContentType)
+		w.Header().Set("Trailer", StatusTrailer)
+		w.WriteHeader(resolveHTTPStatusCode(&lastKnown, cfg))
+		write(streamingStatusLine{Status: lastKnown.Status})
+
+		showDetails := cfg.detailsAuth == nil || cfg.detailsAuth(r)
+		return runStreamedCheck(r, checker, filter, func(name string, result CheckResult) {
+			if live && showDetails {
+				writeComponent(name, result)
+			}
+		})
+	})
+	if !ok {
+		return
+	}
+
+	// Components that were not executed by the check run (e.g., periodic checks) or that were
+	// held back for the middleware are written after the check run has completed.
+	removeUnauthorizedDetails(r, &result, cfg)
+	for _, name := range sortedKeys(result.Details) {
+		if !streamed[name] {
+			writeComponent(name, result.Details[name])
+		}
+	}
+
+	write(streamingStatusLine{Status: result.Status, Final: true})
+	w.Header().Set(StatusTrailer, string(result.Status))
+}
+
+// runStreamedCheck executes a single check run and calls onResult with the result of each check as soon as it
+// has completed. onResult is only called from the calling goroutine and never after runStreamedCheck returned.
+func runStreamedCheck(
+	r *http.Request,
+	checker Checker,
+	filter CheckFilter,
+	onResult func(name string, result CheckResult),
+) CheckerResult {
+	var (
+		// Each check reports at most one result per check run, so sending never blocks. Results that are
+		// reported by a check run that continues in the background (see WithStaleWhileRevalidate) are dropped.
+		results = make(chan streamingComponentLine, len(checker.GetCheckNames(filter)))
+		done    = make(chan CheckerResult, 1)
+	)
+
+	ctx := withCheckResultListener(r.Context(), func(name string, result CheckResult) {
+		select {
+		case results <- streamingComponentLine{Component: name, Result: result}:
+		default:
+		}
+	})
+
+	go func() {
+		done <- check(ctx, checker, filter)
+	}()
+
+	for {
+		select {
+		case line := <-results:
+			onResult(line.Component, line.Result)
+		case result := <-done:
+			// Results are reported before the check run returns, so all of them are buffered already.
+			for {
+				select {
+				case line := <-results:
+					onResult(line.Component, line.Result)
+				default:
+					return result
+				}
+			}
+		}
+	}
+}
+
+// withCheckResultListener returns a context that makes check runs call the listener with the result of
+// each check as soon as it has completed, before the results of all checks are stored (see runStreamedCheck).
+func withCheckResultListener(ctx context.Context, listener func(name string, result CheckResult)) context.Context {
+	return context.WithValue(ctx, checkResultListenerKey{}, listener)
+}
+
+// checkResultListenerFrom returns the check result listener of the context (see withCheckResultListener).
+func checkResultListenerFrom(ctx context.Context) func(name string, result CheckResult) {
+	listener, _ := ctx.Value(checkResultListenerKey{}).(func(name string, result CheckResult))
+	return listener
+}
+
+// lastKnownStatus returns the aggregated status of the checks that are accepted by the filter without
+// executing any check. The status is aggregated like the status of a regular check run, i.e., using the
+// configured aggregator (see WithAggregator) and excluding checks that are in maintenance or disabled
+// (see Checker.SetMaintenance and Checker.DisableCheck). For Checker implementations other than the one
+// returned by NewChecker, the statuses of the accepted checks are aggregated using WorstStatusAggregator.
+func lastKnownStatus(checker Checker, filter CheckFilter) AvailabilityStatus {
+	if ck, ok := checker.(*defaultChecker); ok {
+		return ck.lastKnownStatus(filter)
+	}
+
+	state := checker.GetState()
+	if filter == nil {
+		return state.Status
+	}
+
+	accepted := map[string]bool{}
+	for _, name := range checker.GetCheckNames(filter) {
+		accepted[name] = true
+	}
+
+	states := map[string]CheckState{}
+	for _, info := range checker.ListChecks() {
+		if accepted[info.Name] && !info.NonCritical {
+			states[info.Name] = state.CheckState[info.Name]
+		}
+	}
+
+	return WorstStatusAggregator(states)
+}
+
+// lastKnownStatus returns the aggregated status of the checks that are accepted by the filter
+// without executing any check (see mapStateToCheckerResult).
+func (ck *defaultChecker) lastKnownStatus(filter CheckFilter) AvailabilityStatus {
+	ck.stateMtx.RLock()
+	defer ck.stateMtx.RUnlock()
+
+	if filter == nil {
+		return ck.state.Status
+	}
+	return ck.aggregateStatus(filter)
+}
@@ -0,0 +1,192 @@
+package health
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingWritesLastKnownStatusFirstAndComponentsAsTheyComplete(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error { return nil }}),
+	)
+	ckr.Check(context.Background())
+
+	server := httptest.NewServer(NewHandler(ckr, WithStreaming()))
+	defer server.Close()
+
+	// Act
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, StreamingContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "down", resp.Trailer.Get(StatusTrailer))
+
+	require.Len(t, lines, 4)
+	assert.Equal(t, map[string]interface{}{"status": "down"}, lines[0])
+	assert.Equal(t, map[string]interface{}{"status": "down", "final": true}, lines[3])
+
+	components := []string{lines[1]["component"].(string), lines[2]["component"].(string)}
+	sort.Strings(components)
+	assert.Equal(t, []string{"database", "search"}, components)
+}
+
+func TestStreamingOmitsUnauthorizedDetails(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	handler := NewHandler(ckr, WithStreaming(), WithDetailsAuthorizer(func(r *http.Request) bool { return false }))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "{\"status\":\"unknown\"}\n{\"status\":\"up\",\"final\":true}\n", w.Body.String())
+}
+
+func TestStreamingAggregatesLastKnownStatusLikeTheChecker(t *testing.T) {
+	// Arrange
+	down := func(ctx context.Context) error { return errors.New("failed") }
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithAggregator(QuorumAggregator(2)),
+		WithCheck(Check{Name: "database", Tags: []string{"ready"}, Check: down}),
+		WithCheck(Check{Name: "cache", Tags: []string{"ready"}, Check: down}),
+		WithCheck(Check{Name: "search", Tags: []string{"ready"}, Check: func(ctx context.Context) error { return nil }}),
+	)
+	ckr.Check(context.Background())
+	require.NoError(t, ckr.SetMaintenance("cache", time.Time{}, "planned downtime"))
+
+	handler := NewHandler(ckr, WithStreaming(), WithTagFilter("ready"))
+	w := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	var first streamingStatusLine
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&first))
+	assert.Equal(t, StatusDegraded, first.Status)
+}
+
+func TestStreamingWritesComponentsOfASingleCheckRunAsTheyComplete(t *testing.T) {
+	// Arrange
+	var (
+		fastStarted = make(chan struct{})
+		releaseSlow = make(chan struct{})
+	)
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithTimeout(5*time.Second),
+		WithCheck(Check{Name: "fast", Check: func(ctx context.Context) error {
+			close(fastStarted)
+			return nil
+		}}),
+		WithCheck(Check{Name: "slow", Check: func(ctx context.Context) error {
+			// Only succeeds if both checks are executed concurrently and the result
+			// of the fast check was streamed before this check has completed.
+			select {
+			case <-fastStarted:
+			case <-time.After(2 * time.Second):
+				return errors.New("checks were not executed concurrently")
+			}
+			select {
+			case <-releaseSlow:
+				return nil
+			case <-time.After(2 * time.Second):
+				return errors.New("result of fast check was not streamed")
+			}
+		}}),
+	)
+
+	server := httptest.NewServer(NewHandler(ckr, WithStreaming()))
+	defer server.Close()
+
+	// Act
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+		if line["component"] == "fast" {
+			close(releaseSlow)
+		}
+	}
+
+	// Assert
+	require.Len(t, lines, 4)
+	assert.Equal(t, "fast", lines[1]["component"])
+	assert.Equal(t, "slow", lines[2]["component"])
+	assert.Equal(t, "up", lines[2]["result"].(map[string]interface{})["status"])
+	assert.Equal(t, map[string]interface{}{"status": "up", "final": true}, lines[3])
+}
+
+func TestStreamingAppliesMiddleware(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return nil }}),
+	)
+	stripDetails := func(next MiddlewareFunc) MiddlewareFunc {
+		return func(r *http.Request) CheckerResult {
+			result := next(r)
+			result.Details = nil
+			return result
+		}
+	}
+	rejectAll := func(next HTTPMiddlewareFunc) HTTPMiddlewareFunc {
+		return func(w http.ResponseWriter, r *http.Request) CheckerResult {
+			w.WriteHeader(http.StatusUnauthorized)
+			return CheckerResult{}
+		}
+	}
+
+	// Act
+	stripped := httptest.NewRecorder()
+	NewHandler(ckr, WithStreaming(), WithMiddleware(stripDetails)).
+		ServeHTTP(stripped, httptest.NewRequest(http.MethodGet, "/health", nil))
+	rejected := httptest.NewRecorder()
+	NewHandler(ckr, WithStreaming(), WithHTTPMiddleware(rejectAll)).
+		ServeHTTP(rejected, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Assert
+	assert.Equal(t, "{\"status\":\"unknown\"}\n{\"status\":\"up\",\"final\":true}\n", stripped.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, rejected.Code)
+	assert.Empty(t, rejected.Body.String())
+}
@@ -0,0 +1,35 @@
+package health
+
+import "context"
+
+// Subscribe implements Checker.Subscribe. Please refer to Checker.Subscribe for more information.
+func (ck *defaultChecker) Subscribe(ctx context.Context, queueSize int) <-chan CheckerState {
+	ch := make(chan CheckerState, queueSize+1)
+
+	ck.stateMtx.Lock()
+	ch <- copyCheckerState(ck.state)
+	ck.subscribers[ch] = struct{}{}
+	ck.stateMtx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		ck.stateMtx.Lock()
+		delete(ck.subscribers, ch)
+		close(ch)
+		ck.stateMtx.Unlock()
+	}()
+
+	return ch
+}
+
+// notifySubscribers passes a copy of the current state to all subscribers (see Checker.Subscribe)
+// without blocking. Must be called while holding the state lock.
+func (ck *defaultChecker) notifySubscribers() {
+	for ch := range ck.subscribers {
+		select {
+		case ch <- copyCheckerState(ck.state):
+		default:
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package health
+
+import "context"
+
+// CheckTemplate allows to configure a check once and to instantiate it for multiple targets of type T
+// (e.g., the connection strings of all database shards). All instances share the configuration of
+// the template, such as timeouts, thresholds and interceptors, so that nearly identical checks do not
+// have to be copied.
+//
+// Example:
+//
+//	shards := health.CheckTemplate[string]{
+//		Base: health.Check{Timeout: 2 * time.Second, MaxContiguousFails: 3, Tags: []string{"storage"}},
+//		NewCheck: func(dsn string) func(ctx context.Context) error {
+//			return checks.SQLPing(openDB(dsn))
+//		},
+//	}
+//
+//	checker := health.NewChecker(
+//		health.WithCheck(shards.Check("shard-1", dsn1)),
+//		health.WithCheck(shards.Check("shard-2", dsn2)),
+//	)
+type CheckTemplate[T any] struct {
+	// Base holds the configuration that is shared by all instances of the template.
+	// Its name and check functions are ignored.
+	Base Check
+
+	// NewCheck creates the check function for a target. It is called once per instance.
+	NewCheck func(target T) func(ctx context.Context) error
+}
+
+// Check creates a new instance of the template with the provided name that checks the target.
+// Slices and maps of the template are copied, so that instances can be modified independently.
+func (t *CheckTemplate[T]) Check(name string, target T) Check {
+	check := t.Base
+	check.Name = name
+	check.Check = t.NewCheck(target)
+	check.CheckWithData = nil
+
+	check.Interceptors = append([]Interceptor(nil), t.Base.Interceptors...)
+	check.DependsOn = append([]string(nil), t.Base.DependsOn...)
+	check.Tags = append([]string(nil), t.Base.Tags...)
+	check.Probes = append([]Probe(nil), t.Base.Probes...)
+	check.Metadata = copyData(t.Base.Metadata)
+
+	return check
+}
@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTemplateCreatesInstances(t *testing.T) {
+	// Arrange
+	template := CheckTemplate[string]{
+		Base: Check{Timeout: 2 * time.Second, MaxContiguousFails: 3, Tags: []string{"storage"}},
+		NewCheck: func(shard string) func(ctx context.Context) error {
+			return func(ctx context.Context) error {
+				if shard == "shard-2" {
+					return errors.New("connection refused")
+				}
+				return nil
+			}
+		},
+	}
+
+	// Act
+	first := template.Check("shard-1", "shard-1")
+	second := template.Check("shard-2", "shard-2")
+	second.Tags = append(second.Tags, "critical")
+
+	// Assert
+	assert.Equal(t, "shard-1", first.Name)
+	assert.Equal(t, 2*time.Second, first.Timeout)
+	assert.Equal(t, uint(3), second.MaxContiguousFails)
+	assert.NoError(t, first.Check(context.Background()))
+	assert.EqualError(t, second.Check(context.Background()), "connection refused")
+	assert.Equal(t, []string{"storage"}, first.Tags)
+	assert.Equal(t, []string{"storage"}, template.Base.Tags)
+}
+
+func TestCheckTemplateInstancesCanBeAddedToChecker(t *testing.T) {
+	// Arrange
+	template := CheckTemplate[error]{
+		NewCheck: func(err error) func(ctx context.Context) error {
+			return func(ctx context.Context) error { return err }
+		},
+	}
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithCheck(template.Check("shard-1", nil)),
+		WithCheck(template.Check("shard-2", errors.New("connection refused"))),
+	)
+
+	// Act
+	result := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDown, result.Status)
+	assert.Equal(t, StatusUp, result.Details["shard-1"].Status)
+	assert.Equal(t, StatusDown, result.Details["shard-2"].Status)
+}
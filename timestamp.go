@@ -0,0 +1,89 @@
+package health
+
+import "time"
+
+// TimestampFormat defines how a JSONResultWriter writes timestamps (see WithJSONTimestampFormat).
+type TimestampFormat int
+
+const (
+	// TimestampRFC3339Nano writes timestamps as RFC 3339 strings with nanosecond precision
+	// (e.g., "2021-07-01T08:05:14.123456789Z"). This is the default format.
+	TimestampRFC3339Nano TimestampFormat = iota
+	// TimestampRFC3339 writes timestamps as RFC 3339 strings with second precision (e.g., "2021-07-01T08:05:14Z").
+	TimestampRFC3339
+	// TimestampRFC3339Millis writes timestamps as RFC 3339 strings with millisecond precision
+	// (e.g., "2021-07-01T08:05:14.123Z").
+	TimestampRFC3339Millis
+	// TimestampUnixSeconds writes timestamps as the number of seconds since the Unix epoch.
+	TimestampUnixSeconds
+	// TimestampUnixMillis writes timestamps as the number of milliseconds since the Unix epoch.
+	TimestampUnixMillis
+	// TimestampUnixNanos writes timestamps as the number of nanoseconds since the Unix epoch.
+	TimestampUnixNanos
+)
+
+const rfc3339Millis = "2006-01-02T15:04:05.000Z07:00"
+
+// WithJSONTimestampFormat sets the format of the timestamps that are written by a JSONResultWriter
+// (see TimestampFormat). Timestamps of checks that were not executed yet are omitted
+// if a format is configured. Default is TimestampRFC3339Nano.
+func WithJSONTimestampFormat(format TimestampFormat) JSONResultWriterOption {
+	return func(rw *JSONResultWriter) {
+		rw.timestampFormat = format
+	}
+}
+
+// WithJSONTimestampLocation sets the time zone of the RFC 3339 timestamps that are written by
+// a JSONResultWriter (e.g., time.Local). Default is UTC.
+func WithJSONTimestampLocation(location *time.Location) JSONResultWriterOption {
+	return func(rw *JSONResultWriter) {
+		rw.timestampLocation = location
+	}
+}
+
+func (rw *JSONResultWriter) formatsTimestamps() bool {
+	return rw.timestampFormat != TimestampRFC3339Nano || rw.timestampLocation != nil
+}
+
+// formatTimestampField reformats the "timestamp" field of a generic JSON object.
+func (rw *JSONResultWriter) formatTimestampField(fields map[string]interface{}) {
+	value, ok := fields["timestamp"].(string)
+	if !ok {
+		return
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return
+	}
+
+	if timestamp.IsZero() {
+		delete(fields, "timestamp")
+		return
+	}
+
+	fields["timestamp"] = rw.formatTimestamp(timestamp)
+}
+
+func (rw *JSONResultWriter) formatTimestamp(timestamp time.Time) interface{} {
+	location := rw.timestampLocation
+	if location == nil {
+		location = time.UTC
+	}
+	timestamp = timestamp.In(location)
+
+	switch rw.timestampFormat {
+	case TimestampRFC3339:
+		return timestamp.Format(time.RFC3339)
+	case TimestampRFC3339Millis:
+		return timestamp.Format(rfc3339Millis)
+	case TimestampUnixSeconds:
+		return timestamp.Unix()
+	case TimestampUnixMillis:
+		return timestamp.UnixNano() / int64(time.Millisecond)
+	case TimestampUnixNanos:
+		return timestamp.UnixNano()
+	default:
+		return timestamp.Format(time.RFC3339Nano)
+	}
+}
@@ -0,0 +1,80 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONResultWriterTimestampFormats(t *testing.T) {
+	timestamp := time.Date(2021, 7, 1, 8, 5, 14, 123456789, time.UTC)
+
+	for format, expected := range map[TimestampFormat]string{
+		TimestampRFC3339:       `"2021-07-01T08:05:14Z"`,
+		TimestampRFC3339Millis: `"2021-07-01T08:05:14.123Z"`,
+		TimestampUnixSeconds:   `1625126714`,
+		TimestampUnixMillis:    `1625126714123`,
+		TimestampUnixNanos:     `1625126714123456789`,
+	} {
+		// Arrange
+		w := httptest.NewRecorder()
+		result := &CheckerResult{
+			Status: StatusUp,
+			Details: map[string]CheckResult{
+				"database": {Status: StatusUp, Timestamp: timestamp},
+				"search":   {Status: StatusUnknown},
+			},
+		}
+
+		// Act
+		err := NewJSONResultWriter(WithJSONTimestampFormat(format)).
+			Write(result, http.StatusOK, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"status": "up",
+			"details": {
+				"database": {"status": "up", "timestamp": `+expected+`},
+				"search": {"status": "unknown"}
+			}
+		}`, w.Body.String())
+	}
+}
+
+func TestJSONResultWriterTimestampLocation(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	location := time.FixedZone("CEST", 2*60*60)
+	result := &CheckerResult{
+		Status: StatusUp,
+		Details: map[string]CheckResult{
+			"database": {
+				Status:    StatusUp,
+				Timestamp: time.Date(2021, 7, 1, 8, 5, 14, 0, time.UTC),
+				History:   []CheckHistoryEntry{{Status: StatusUp, Timestamp: time.Date(2021, 7, 1, 8, 5, 14, 0, time.UTC)}},
+			},
+		},
+	}
+
+	// Act
+	err := NewJSONResultWriter(WithJSONTimestampFormat(TimestampRFC3339), WithJSONTimestampLocation(location)).
+		Write(result, http.StatusOK, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": "up",
+		"details": {
+			"database": {
+				"status": "up",
+				"timestamp": "2021-07-01T10:05:14+02:00",
+				"history": [{"status": "up", "timestamp": "2021-07-01T10:05:14+02:00", "duration": "0s"}]
+			}
+		}
+	}`, w.Body.String())
+}
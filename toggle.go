@@ -0,0 +1,76 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// ToggleProvider decides if a check is enabled (see WithToggleProvider). It allows to switch checks
+	// on and off at runtime using a feature flag system, e.g., to disable a noisy check in production
+	// without redeploying. IsEnabled is called frequently (whenever a check is due and whenever results
+	// are created), so implementations should answer from a local cache rather than a remote call.
+	ToggleProvider interface {
+		// IsEnabled returns false if the check with the given name is disabled.
+		IsEnabled(checkName string) bool
+	}
+
+	// ToggleProviderFunc is an adapter to allow the use of ordinary functions as a ToggleProvider.
+	ToggleProviderFunc func(checkName string) bool
+)
+
+// WithToggleProvider configures a ToggleProvider that decides which checks are enabled. A check is disabled
+// if either the provider reports it as disabled or it was disabled using Checker.DisableCheck.
+// Disabled checks behave as described in Checker.DisableCheck.
+func WithToggleProvider(provider ToggleProvider) CheckerOption {
+	return func(cfg *checkerConfig) {
+		cfg.toggleProvider = provider
+	}
+}
+
+// IsEnabled implements ToggleProvider.IsEnabled.
+func (f ToggleProviderFunc) IsEnabled(checkName string) bool {
+	return f(checkName)
+}
+
+// DisableCheck implements Checker.DisableCheck. Please refer to Checker.DisableCheck for more information.
+func (ck *defaultChecker) DisableCheck(name string) error {
+	return ck.setCheckDisabled(name, true)
+}
+
+// EnableCheck implements Checker.EnableCheck. Please refer to Checker.EnableCheck for more information.
+func (ck *defaultChecker) EnableCheck(name string) error {
+	return ck.setCheckDisabled(name, false)
+}
+
+func (ck *defaultChecker) setCheckDisabled(name string, disabled bool) error {
+	ck.mtx.Lock()
+	defer ck.mtx.Unlock()
+
+	if _, ok := ck.cfg.checks[name]; !ok {
+		return fmt.Errorf("check %q does not exist", name)
+	}
+
+	ck.stateMtx.Lock()
+	if disabled {
+		ck.disabled[name] = struct{}{}
+	} else {
+		delete(ck.disabled, name)
+	}
+	ck.stateMtx.Unlock()
+
+	// The aggregated status is updated right away, because disabled checks do not contribute to it.
+	ck.updateState(context.Background())
+
+	return nil
+}
+
+// isDisabled returns true if the check was disabled using Checker.DisableCheck or by the
+// ToggleProvider (see WithToggleProvider). Must be called while holding ck.mtx or the state lock.
+func (ck *defaultChecker) isDisabled(name string) bool {
+	if _, ok := ck.disabled[name]; ok {
+		return true
+	}
+
+	return ck.cfg.toggleProvider != nil && !ck.cfg.toggleProvider.IsEnabled(name)
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisableCheckSkipsCheckAndExcludesItFromAggregation(t *testing.T) {
+	// Arrange
+	var calls int32
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.New("connection refused")
+		}}),
+		WithCheck(Check{Name: "search", Check: func(ctx context.Context) error { return nil }}),
+	)
+
+	// Act
+	err := ckr.DisableCheck("database")
+	res := ckr.Check(context.Background())
+	_, checkNowErr := ckr.CheckNow(context.Background(), "database")
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, checkNowErr)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+	assert.Equal(t, StatusUp, res.Status)
+	assert.Equal(t, StatusDisabled, res.Details["database"].Status)
+	assert.Equal(t, StatusUp, res.Details["search"].Status)
+	assert.Error(t, ckr.DisableCheck("unknown"))
+}
+
+func TestEnableCheck(t *testing.T) {
+	// Arrange
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}}),
+	)
+	require.NoError(t, ckr.DisableCheck("database"))
+
+	// Act
+	err := ckr.EnableCheck("database")
+	res := ckr.Check(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, StatusDown, res.Status)
+	assert.Equal(t, StatusDown, res.Details["database"].Status)
+	assert.Error(t, ckr.EnableCheck("unknown"))
+}
+
+func TestWithToggleProvider(t *testing.T) {
+	// Arrange
+	var (
+		calls   int32
+		enabled atomic.Value
+	)
+	enabled.Store(false)
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithToggleProvider(ToggleProviderFunc(func(checkName string) bool {
+			return checkName != "database" || enabled.Load().(bool)
+		})),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.New("connection refused")
+		}}),
+	)
+
+	// Act
+	disabledRes := ckr.Check(context.Background())
+	enabled.Store(true)
+	enabledRes := ckr.Check(context.Background())
+
+	// Assert
+	assert.Equal(t, StatusDisabled, disabledRes.Details["database"].Status)
+	assert.Equal(t, StatusDown, enabledRes.Details["database"].Status)
+	assert.Equal(t, StatusDown, enabledRes.Status)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
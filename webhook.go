@@ -0,0 +1,262 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type (
+	// WebhookOption is a configuration option for a webhook listener (see WithWebhookListener).
+	WebhookOption func(cfg *webhookConfig)
+
+	// WebhookPayload is the JSON payload that is sent by a webhook listener (see WithWebhookListener).
+	WebhookPayload struct {
+		// Status is the aggregated system availability status.
+		Status AvailabilityStatus `json:"status"`
+		// PreviousStatus is the aggregated system availability status before the change.
+		PreviousStatus AvailabilityStatus `json:"previousStatus"`
+		// Timestamp holds the time when the change was detected.
+		Timestamp time.Time `json:"timestamp"`
+		// Components contains all components whose status has changed.
+		Components map[string]WebhookComponent `json:"components,omitempty"`
+	}
+
+	// WebhookComponent holds the status change of a component (see WebhookPayload).
+	WebhookComponent struct {
+		// Status is the availability status of the component.
+		Status AvailabilityStatus `json:"status"`
+		// PreviousStatus is the availability status of the component before the change.
+		PreviousStatus AvailabilityStatus `json:"previousStatus"`
+		// Error contains the check error message, if the check failed.
+		Error string `json:"error,omitempty"`
+	}
+
+	webhookConfig struct {
+		client    *http.Client
+		headers   http.Header
+		secret    []byte
+		retry     RetryPolicy
+		queueSize int
+	}
+
+	webhookPublisher struct {
+		*statusChangeDetector
+		url string
+		cfg webhookConfig
+	}
+
+	// statusChangeDetector detects status changes between consecutive checker states
+	// (see WithWebhookListener and NewEventStreamHandler). It is not safe for concurrent use.
+	statusChangeDetector struct {
+		previousStatus     AvailabilityStatus
+		previousComponents map[string]AvailabilityStatus
+	}
+
+	webhookErr struct {
+		err       error
+		retryable bool
+	}
+)
+
+// WebhookSignatureHeader is the HTTP header that contains the HMAC signature of the request body
+// (see WithWebhookSecret).
+const WebhookSignatureHeader = "X-Health-Signature-256"
+
+// WithWebhookListener registers a listener that sends a POST request with a JSON payload (see WebhookPayload)
+// to the provided URL whenever the aggregated system status or the status of a component changes. Requests are
+// sent asynchronously by a Publisher (see WithPublisher), so a slow receiver does not stall check execution.
+// Errors are passed to the publisher error handler (see WithPublisherErrorHandler). This option can be used
+// multiple times to notify more than one receiver. Status changes of silenced components are not sent (see Silence).
+func WithWebhookListener(url string, options ...WebhookOption) CheckerOption {
+	cfg := webhookConfig{
+		client:    &http.Client{Timeout: 10 * time.Second},
+		headers:   http.Header{},
+		queueSize: 100,
+	}
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return WithPublisher(&webhookPublisher{
+		statusChangeDetector: newStatusChangeDetector(),
+		url:                  url,
+		cfg:                  cfg,
+	}, cfg.queueSize)
+}
+
+// WithWebhookHeader adds a custom HTTP header that is sent with every webhook request
+// (e.g., an API key). This option can be used multiple times.
+func WithWebhookHeader(key, value string) WebhookOption {
+	return func(cfg *webhookConfig) {
+		cfg.headers.Add(key, value)
+	}
+}
+
+// WithWebhookSecret enables signing of webhook requests. The hex encoded HMAC-SHA256 signature of the
+// request body is sent in header WebhookSignatureHeader (e.g., "sha256=6f2c..."), so that receivers
+// can verify that the request was sent by this service.
+func WithWebhookSecret(secret string) WebhookOption {
+	return func(cfg *webhookConfig) {
+		cfg.secret = []byte(secret)
+	}
+}
+
+// WithWebhookRetry configures retries of failed webhook requests (see RetryPolicy). A request is
+// retried if it could not be sent or if the receiver responded with HTTP status code 429 or 5xx.
+// Retries are disabled by default.
+func WithWebhookRetry(policy RetryPolicy) WebhookOption {
+	return func(cfg *webhookConfig) {
+		cfg.retry = policy
+	}
+}
+
+// WithWebhookClient sets the http.Client that is used to send webhook requests.
+// By default, a client with a timeout of 10 seconds is used.
+func WithWebhookClient(client *http.Client) WebhookOption {
+	return func(cfg *webhookConfig) {
+		cfg.client = client
+	}
+}
+
+// WithWebhookQueueSize sets how many status changes can be buffered while
+// webhook requests are being sent (see WithPublisher). Default is 100.
+func WithWebhookQueueSize(queueSize int) WebhookOption {
+	return func(cfg *webhookConfig) {
+		cfg.queueSize = queueSize
+	}
+}
+
+// Publish implements Publisher.Publish. It is only called from a single goroutine
+// (see publisherWorker), so no synchronization is required.
+func (p *webhookPublisher) Publish(ctx context.Context, state CheckerState) error {
+	payload, _ := p.detectChanges(state)
+
+	// Status changes of silenced components are not sent (see Silence).
+	for name := range payload.Components {
+		if state.CheckState[name].Silenced {
+			delete(payload.Components, name)
+		}
+	}
+
+	if payload.Status == payload.PreviousStatus && len(payload.Components) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("cannot marshal webhook payload: %w", err)
+	}
+
+	err = p.send(ctx, body)
+	interval := p.cfg.retry.Interval
+
+	for retry := uint(0); err != nil && isRetryableWebhookErr(err) && retry < p.cfg.retry.MaxRetries; retry++ {
+		if waitForStopSignal(ctx, realClock{}, interval) {
+			break
+		}
+
+		err = p.send(ctx, body)
+		interval = p.cfg.retry.nextInterval(interval)
+	}
+
+	return err
+}
+
+func newStatusChangeDetector() *statusChangeDetector {
+	return &statusChangeDetector{
+		previousStatus:     StatusUnknown,
+		previousComponents: map[string]AvailabilityStatus{},
+	}
+}
+
+// detectChanges compares the state with the previously passed state and creates a payload that
+// contains all changes. The second return value is false if nothing has changed.
+func (d *statusChangeDetector) detectChanges(state CheckerState) (WebhookPayload, bool) {
+	payload := WebhookPayload{
+		Status:         state.Status,
+		PreviousStatus: d.previousStatus,
+		Timestamp:      time.Now().UTC(),
+		Components:     map[string]WebhookComponent{},
+	}
+
+	components := make(map[string]AvailabilityStatus, len(state.CheckState))
+	for name, checkState := range state.CheckState {
+		components[name] = checkState.Status
+
+		previousStatus, ok := d.previousComponents[name]
+		if !ok {
+			previousStatus = StatusUnknown
+		}
+
+		if previousStatus != checkState.Status {
+			payload.Components[name] = WebhookComponent{
+				Status:         checkState.Status,
+				PreviousStatus: previousStatus,
+				Error:          errorMessage(checkState.Result),
+			}
+		}
+	}
+
+	d.previousStatus = state.Status
+	d.previousComponents = components
+
+	return payload, payload.Status != payload.PreviousStatus || len(payload.Components) > 0
+}
+
+func (p *webhookPublisher) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("cannot create webhook request: %w", err)
+	}
+
+	for key, values := range p.cfg.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	if len(p.cfg.secret) > 0 {
+		mac := hmac.New(sha256.New, p.cfg.secret)
+		mac.Write(body)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := p.cfg.client.Do(req)
+	if err != nil {
+		return &webhookErr{err: err, retryable: true}
+	}
+	defer resp.Body.Close()
+	//nolint:errcheck
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return &webhookErr{
+			err:       fmt.Errorf("webhook %s responded with status code %d", p.url, resp.StatusCode),
+			retryable: resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError,
+		}
+	}
+
+	return nil
+}
+
+func (e *webhookErr) Error() string {
+	return e.err.Error()
+}
+
+func (e *webhookErr) Unwrap() error {
+	return e.err
+}
+
+func isRetryableWebhookErr(err error) bool {
+	var webhookErr *webhookErr
+	return errors.As(err, &webhookErr) && webhookErr.retryable
+}
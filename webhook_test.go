@@ -0,0 +1,120 @@
+package health
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookListenerSendsSignedStatusChanges(t *testing.T) {
+	// Arrange
+	requests := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	ckr := NewChecker(
+		WithDisabledAutostart(),
+		WithDisabledCache(),
+		WithCheck(Check{Name: "database", Check: func(ctx context.Context) error { return fmt.Errorf("down") }}),
+		WithWebhookListener(server.URL, WithWebhookSecret("secret"), WithWebhookHeader("X-Api-Key", "key")),
+	)
+
+	// Act
+	ckr.Check(context.Background())
+	ckr.Check(context.Background())
+	ckr.Start()
+	defer ckr.Stop()
+
+	// Assert
+	var (
+		req  *http.Request
+		body []byte
+	)
+	select {
+	case req = <-requests:
+		body = <-bodies
+	case <-time.After(1 * time.Second):
+		require.Fail(t, "webhook was not called")
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, StatusDown, payload.Status)
+	assert.Equal(t, StatusUnknown, payload.PreviousStatus)
+	assert.Equal(t, WebhookComponent{Status: StatusDown, PreviousStatus: StatusUnknown, Error: "down"}, payload.Components["database"])
+	assert.Equal(t, "key", req.Header.Get("X-Api-Key"))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), req.Header.Get(WebhookSignatureHeader))
+
+	select {
+	case <-requests:
+		assert.Fail(t, "webhook was called although the status did not change")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWebhookListenerRetriesFailedRequests(t *testing.T) {
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	publisher := webhookPublisher{
+		statusChangeDetector: newStatusChangeDetector(),
+		url:                  server.URL,
+		cfg:                  webhookConfig{client: server.Client(), retry: RetryPolicy{MaxRetries: 2, Interval: 1 * time.Millisecond}},
+	}
+
+	// Act
+	err := publisher.Publish(context.Background(), CheckerState{Status: StatusUp})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookListenerDoesNotRetryClientErrors(t *testing.T) {
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	publisher := webhookPublisher{
+		statusChangeDetector: newStatusChangeDetector(),
+		url:                  server.URL,
+		cfg:                  webhookConfig{client: server.Client(), retry: RetryPolicy{MaxRetries: 2}},
+	}
+
+	// Act
+	err := publisher.Publish(context.Background(), CheckerState{Status: StatusUp})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,241 @@
+// Package websocket provides a WebSocket handler that pushes health status updates to subscribed clients,
+// e.g., to build live dashboards. In contrast to health.NewEventStreamHandler, clients can also send
+// commands over the connection (e.g., to request a refresh). It is provided as a separate module,
+// so that the health package itself does not depend on a WebSocket library.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/gorilla/websocket"
+)
+
+type (
+	// Option is a configuration option for a WebSocket handler (see NewHandler).
+	Option func(cfg *config)
+
+	config struct {
+		heartbeat        time.Duration
+		queueSize        int
+		refreshRateLimit time.Duration
+		upgrader         websocket.Upgrader
+	}
+
+	// Message is a status update that is sent to clients as JSON.
+	Message struct {
+		// Type is the message type: "status" if the status of the system or a component has changed (this
+		// is also the type of the first message after connecting), "heartbeat" for periodic updates (see
+		// WithHeartbeat), "refresh" for the result of a refresh command, or "error" if a command failed.
+		Type string `json:"type"`
+		// Status is the aggregated system availability status.
+		Status health.AvailabilityStatus `json:"status,omitempty"`
+		// Timestamp holds the time when the message was created.
+		Timestamp time.Time `json:"timestamp"`
+		// Components holds the status of each component.
+		Components map[string]Component `json:"components,omitempty"`
+		// Error contains the error message of a failed command.
+		Error string `json:"error,omitempty"`
+	}
+
+	// Component holds the status of a component (see Message).
+	Component struct {
+		// Status is the availability status of the component.
+		Status health.AvailabilityStatus `json:"status"`
+		// Error contains the check error message, if the check failed.
+		Error string `json:"error,omitempty"`
+	}
+
+	// Command is a command that clients can send as JSON.
+	Command struct {
+		// Action is the requested action. Currently, only "refresh" is supported, which executes all
+		// checks immediately (see health.Checker.CheckAllNow) and answers with a "refresh" message.
+		Action string `json:"action"`
+	}
+)
+
+// WithHeartbeat sets the interval in which the current status is sent to clients, even if it has not changed.
+// Default value is 30 seconds. A value of 0 disables heartbeats.
+func WithHeartbeat(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.heartbeat = interval
+	}
+}
+
+// WithQueueSize sets how many checker states can be buffered per client while messages are being
+// written (see health.Checker.Subscribe). If the buffer is full, states are dropped. Default value is 10.
+func WithQueueSize(queueSize int) Option {
+	return func(cfg *config) {
+		cfg.queueSize = queueSize
+	}
+}
+
+// WithRefreshRateLimit sets the minimum time between two refresh commands of a client, so that clients
+// cannot overload the checked dependencies. Refresh commands that are sent earlier are answered with
+// an "error" message. Default value is 10 seconds.
+func WithRefreshRateLimit(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.refreshRateLimit = interval
+	}
+}
+
+// WithCheckOrigin sets a function that decides whether a WebSocket connection from the origin of the request
+// is accepted. By default, only connections from the same origin as the request host are accepted.
+func WithCheckOrigin(checkOrigin func(r *http.Request) bool) Option {
+	return func(cfg *config) {
+		cfg.upgrader.CheckOrigin = checkOrigin
+	}
+}
+
+// NewHandler creates a new http.Handler that upgrades requests to WebSocket connections and pushes status
+// updates (see Message) to the client whenever the status of the system or a component changes. Status
+// changes are only detected when checks are executed (e.g., periodically; see health.WithPeriodicCheck).
+func NewHandler(checker health.Checker, options ...Option) http.HandlerFunc {
+	cfg := config{heartbeat: 30 * time.Second, queueSize: 10, refreshRateLimit: 10 * time.Second}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := cfg.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// The upgrader has already responded with an HTTP error.
+			return
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		commands := make(chan Command)
+		go readCommands(ctx, conn, commands, cancel)
+
+		serve(ctx, conn, checker, commands, &cfg)
+	}
+}
+
+func serve(ctx context.Context, conn *websocket.Conn, checker health.Checker, commands <-chan Command, cfg *config) {
+	var heartbeat <-chan time.Time
+	if cfg.heartbeat > 0 {
+		ticker := time.NewTicker(cfg.heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	var (
+		states      = checker.Subscribe(ctx, cfg.queueSize)
+		lastState   *health.CheckerState
+		lastRefresh time.Time
+	)
+
+	for {
+		var msg Message
+
+		select {
+		case <-ctx.Done():
+			return
+		case state, ok := <-states:
+			if !ok {
+				return
+			}
+			if lastState != nil && !hasChanged(*lastState, state) {
+				continue
+			}
+			lastState = &state
+			msg = newMessage("status", state)
+		case <-heartbeat:
+			if lastState == nil {
+				continue
+			}
+			msg = newMessage("heartbeat", *lastState)
+		case cmd := <-commands:
+			msg = handleCommand(ctx, checker, cmd, &lastRefresh, cfg.refreshRateLimit)
+		}
+
+		if err := conn.WriteJSON(&msg); err != nil {
+			return
+		}
+	}
+}
+
+// readCommands reads commands from the connection until it is closed. Since the connection
+// does not support concurrent readers, it must be the only goroutine that reads from it.
+func readCommands(ctx context.Context, conn *websocket.Conn, commands chan<- Command, cancel context.CancelFunc) {
+	defer cancel()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		// Invalid commands are passed on as empty commands, which are reported as unsupported.
+		var cmd Command
+		//nolint:errcheck
+		json.Unmarshal(data, &cmd)
+
+		select {
+		case commands <- cmd:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func handleCommand(ctx context.Context, checker health.Checker, cmd Command, lastRefresh *time.Time, rateLimit time.Duration) Message {
+	if cmd.Action != "refresh" {
+		return Message{Type: "error", Timestamp: time.Now().UTC(), Error: "unsupported action"}
+	}
+
+	if !lastRefresh.IsZero() && time.Since(*lastRefresh) < rateLimit {
+		return Message{Type: "error", Timestamp: time.Now().UTC(), Error: "too many refresh requests"}
+	}
+	*lastRefresh = time.Now()
+
+	result := checker.CheckAllNow(ctx)
+	msg := Message{Type: "refresh", Status: result.Status, Timestamp: time.Now().UTC()}
+	if len(result.Details) > 0 {
+		msg.Components = make(map[string]Component, len(result.Details))
+		for name, checkResult := range result.Details {
+			msg.Components[name] = Component{Status: checkResult.Status, Error: errorMessage(checkResult.Error)}
+		}
+	}
+
+	return msg
+}
+
+func newMessage(msgType string, state health.CheckerState) Message {
+	msg := Message{Type: msgType, Status: state.Status, Timestamp: time.Now().UTC()}
+	if len(state.CheckState) > 0 {
+		msg.Components = make(map[string]Component, len(state.CheckState))
+		for name, checkState := range state.CheckState {
+			msg.Components[name] = Component{Status: checkState.Status, Error: errorMessage(checkState.Result)}
+		}
+	}
+	return msg
+}
+
+// hasChanged reports whether the status of the system or of any component has changed.
+func hasChanged(previous, current health.CheckerState) bool {
+	if previous.Status != current.Status || len(previous.CheckState) != len(current.CheckState) {
+		return true
+	}
+
+	for name, state := range current.CheckState {
+		if previousState, ok := previous.CheckState[name]; !ok || previousState.Status != state.Status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,140 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexliesenfeld/health"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChecker creates a Checker with a check "database" that fails while failing is set.
+func newTestChecker(failing *atomic.Bool) health.Checker {
+	return health.NewChecker(
+		health.WithDisabledAutostart(),
+		health.WithDisabledCache(),
+		health.WithCheck(health.Check{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				if failing.Load() {
+					return errors.New("connection refused")
+				}
+				return nil
+			},
+		}),
+	)
+}
+
+// connect starts a server for the handler and connects a WebSocket client to it.
+func connect(t *testing.T, handler http.Handler) *websocket.Conn {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) Message {
+	t.Helper()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	var msg Message
+	require.NoError(t, conn.ReadJSON(&msg))
+
+	return msg
+}
+
+func TestHandlerSendsSnapshotAndPushesUpdates(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	checker := newTestChecker(&failing)
+	checker.Check(context.Background())
+	conn := connect(t, NewHandler(checker))
+
+	// Act
+	snapshot := readMessage(t, conn)
+	failing.Store(true)
+	checker.Check(context.Background())
+	update := readMessage(t, conn)
+
+	// Assert
+	assert.Equal(t, "status", snapshot.Type)
+	assert.Equal(t, health.StatusUp, snapshot.Status)
+	assert.Equal(t, map[string]Component{"database": {Status: health.StatusUp}}, snapshot.Components)
+
+	assert.Equal(t, "status", update.Type)
+	assert.Equal(t, health.StatusDown, update.Status)
+	assert.Equal(t, map[string]Component{
+		"database": {Status: health.StatusDown, Error: "connection refused"},
+	}, update.Components)
+}
+
+func TestHandlerSendsHeartbeats(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	checker := newTestChecker(&failing)
+	checker.Check(context.Background())
+	conn := connect(t, NewHandler(checker, WithHeartbeat(10*time.Millisecond)))
+
+	// Act
+	snapshot := readMessage(t, conn)
+	heartbeat := readMessage(t, conn)
+
+	// Assert
+	assert.Equal(t, "status", snapshot.Type)
+	assert.Equal(t, "heartbeat", heartbeat.Type)
+	assert.Equal(t, health.StatusUp, heartbeat.Status)
+}
+
+func TestHandlerAnswersCommands(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	failing.Store(true)
+	checker := newTestChecker(&failing)
+	checker.Check(context.Background())
+	conn := connect(t, NewHandler(checker, WithRefreshRateLimit(time.Hour)))
+	readMessage(t, conn)
+
+	// Act
+	require.NoError(t, conn.WriteJSON(Command{Action: "refresh"}))
+	refresh := readMessage(t, conn)
+	require.NoError(t, conn.WriteJSON(Command{Action: "refresh"}))
+	rateLimited := readMessage(t, conn)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("invalid")))
+	unsupported := readMessage(t, conn)
+
+	// Assert
+	assert.Equal(t, "refresh", refresh.Type)
+	assert.Equal(t, health.StatusDown, refresh.Status)
+	assert.Equal(t, "connection refused", refresh.Components["database"].Error)
+	assert.Equal(t, Message{Type: "error", Timestamp: rateLimited.Timestamp, Error: "too many refresh requests"}, rateLimited)
+	assert.Equal(t, Message{Type: "error", Timestamp: unsupported.Timestamp, Error: "unsupported action"}, unsupported)
+}
+
+func TestHandlerRejectsCrossOriginRequests(t *testing.T) {
+	// Arrange
+	var failing atomic.Bool
+	srv := httptest.NewServer(NewHandler(newTestChecker(&failing)))
+	defer srv.Close()
+
+	header := http.Header{"Origin": []string{"https://example.com"}}
+
+	// Act
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), header)
+
+	// Assert
+	assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
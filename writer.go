@@ -0,0 +1,241 @@
+package health
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// XMLResultWriter writes a CheckerResult in XML format into an
+	// http.ResponseWriter.
+	XMLResultWriter struct{}
+
+	// YAMLResultWriter writes a CheckerResult in YAML format into an
+	// http.ResponseWriter.
+	YAMLResultWriter struct{}
+
+	// TextResultWriter writes only the aggregated availability status of a CheckerResult as plain text
+	// into an http.ResponseWriter. This is useful for load balancers that match the response body
+	// against a fixed string (such as HAProxy or ELB).
+	TextResultWriter struct {
+		textFunc func(result *CheckerResult, statusCode int) string
+	}
+
+	// CompactJSONResultWriter writes a CheckerResult in a compact JSON format into an http.ResponseWriter.
+	// It only contains the aggregated availability status and the availability status of each component,
+	// but omits timestamps, error messages and info values, e.g.: {"status":"down","details":{"database":"down"}}.
+	CompactJSONResultWriter struct{}
+
+	compactJSONCheckerResult struct {
+		Status  AvailabilityStatus            `json:"status"`
+		Details map[string]AvailabilityStatus `json:"details,omitempty"`
+	}
+
+	xmlCheckerResult struct {
+		XMLName xml.Name         `xml:"health"`
+		Status  string           `xml:"status,attr"`
+		Stale   bool             `xml:"stale,attr,omitempty"`
+		Info    []xmlInfoEntry   `xml:"info>entry,omitempty"`
+		Details []xmlCheckResult `xml:"details>component,omitempty"`
+	}
+
+	xmlInfoEntry struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	}
+
+	xmlCheckResult struct {
+		Name      string          `xml:"name,attr"`
+		Status    string          `xml:"status,attr"`
+		Timestamp *time.Time      `xml:"timestamp,attr,omitempty"`
+		Error     string          `xml:"error,omitempty"`
+		Data      *xmlDataEntries `xml:"data,omitempty"`
+	}
+
+	xmlDataEntries struct {
+		Entries []xmlInfoEntry `xml:"entry"`
+	}
+
+	yamlCheckerResult struct {
+		Info    map[string]interface{}     `yaml:"info,omitempty"`
+		Status  string                     `yaml:"status"`
+		Details map[string]yamlCheckResult `yaml:"details,omitempty"`
+		Stale   bool                       `yaml:"stale,omitempty"`
+	}
+
+	yamlCheckResult struct {
+		Status    string                 `yaml:"status"`
+		Timestamp *time.Time             `yaml:"timestamp,omitempty"`
+		Error     string                 `yaml:"error,omitempty"`
+		Data      map[string]interface{} `yaml:"data,omitempty"`
+	}
+)
+
+// NewXMLResultWriter creates a new instance of an XMLResultWriter.
+func NewXMLResultWriter() *XMLResultWriter {
+	return &XMLResultWriter{}
+}
+
+// Write implements ResultWriter.Write.
+func (rw *XMLResultWriter) Write(result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	xmlResp, err := xml.Marshal(toXMLCheckerResult(result))
+	if err != nil {
+		return fmt.Errorf("cannot marshal response: %w", err)
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(statusCode)
+	if _, err = w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	_, err = w.Write(xmlResp)
+	return err
+}
+
+// NewYAMLResultWriter creates a new instance of a YAMLResultWriter.
+func NewYAMLResultWriter() *YAMLResultWriter {
+	return &YAMLResultWriter{}
+}
+
+// Write implements ResultWriter.Write.
+func (rw *YAMLResultWriter) Write(result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	yamlResp, err := yaml.Marshal(toYAMLCheckerResult(result))
+	if err != nil {
+		return fmt.Errorf("cannot marshal response: %w", err)
+	}
+	w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, err = w.Write(yamlResp)
+	return err
+}
+
+// NewTextResultWriter creates a new TextResultWriter that writes the aggregated
+// availability status (e.g., "up" or "down") into the response body.
+func NewTextResultWriter() *TextResultWriter {
+	return &TextResultWriter{textFunc: func(result *CheckerResult, _ int) string {
+		return string(result.Status)
+	}}
+}
+
+// NewOKFailTextResultWriter creates a new TextResultWriter that writes "OK" into the response body if the
+// HTTP status code signals success (i.e., it is smaller than 400) and "FAIL" otherwise.
+func NewOKFailTextResultWriter() *TextResultWriter {
+	return &TextResultWriter{textFunc: func(_ *CheckerResult, statusCode int) string {
+		if statusCode < http.StatusBadRequest {
+			return "OK"
+		}
+		return "FAIL"
+	}}
+}
+
+// Write implements ResultWriter.Write.
+func (rw *TextResultWriter) Write(result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, err := w.Write([]byte(rw.textFunc(result, statusCode)))
+	return err
+}
+
+// NewCompactJSONResultWriter creates a new instance of a CompactJSONResultWriter.
+func NewCompactJSONResultWriter() *CompactJSONResultWriter {
+	return &CompactJSONResultWriter{}
+}
+
+// Write implements ResultWriter.Write.
+func (rw *CompactJSONResultWriter) Write(result *CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	compactResult := compactJSONCheckerResult{Status: result.Status}
+	if len(result.Details) > 0 {
+		compactResult.Details = make(map[string]AvailabilityStatus, len(result.Details))
+		for name, checkResult := range result.Details {
+			compactResult.Details[name] = checkResult.Status
+		}
+	}
+
+	jsonResp, err := json.Marshal(compactResult)
+	if err != nil {
+		return fmt.Errorf("cannot marshal response: %w", err)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, err = w.Write(jsonResp)
+	return err
+}
+
+func toXMLCheckerResult(result *CheckerResult) xmlCheckerResult {
+	xmlResult := xmlCheckerResult{Status: string(result.Status)}
+
+	for _, key := range sortedKeys(result.Info) {
+		xmlResult.Info = append(xmlResult.Info, xmlInfoEntry{Key: key, Value: fmt.Sprint(result.Info[key])})
+	}
+
+	for _, name := range sortedKeys(result.Details) {
+		checkResult := result.Details[name]
+		xmlResult.Details = append(xmlResult.Details, xmlCheckResult{
+			Name:      name,
+			Status:    string(checkResult.Status),
+			Timestamp: timeOrNil(checkResult.Timestamp),
+			Error:     errorMessage(checkResult.Error),
+			Data:      toXMLDataEntries(checkResult.Data),
+		})
+	}
+
+	return xmlResult
+}
+
+func toXMLDataEntries(data map[string]interface{}) *xmlDataEntries {
+	if len(data) == 0 {
+		return nil
+	}
+
+	entries := &xmlDataEntries{}
+	for _, key := range sortedKeys(data) {
+		entries.Entries = append(entries.Entries, xmlInfoEntry{Key: key, Value: fmt.Sprint(data[key])})
+	}
+	return entries
+}
+
+func toYAMLCheckerResult(result *CheckerResult) yamlCheckerResult {
+	yamlResult := yamlCheckerResult{Status: string(result.Status), Info: result.Info, Stale: result.Stale}
+
+	if len(result.Details) > 0 {
+		yamlResult.Details = make(map[string]yamlCheckResult, len(result.Details))
+		for name, checkResult := range result.Details {
+			yamlResult.Details[name] = yamlCheckResult{
+				Status:    string(checkResult.Status),
+				Timestamp: timeOrNil(checkResult.Timestamp),
+				Error:     errorMessage(checkResult.Error),
+				Data:      checkResult.Data,
+			}
+		}
+	}
+
+	return yamlResult
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
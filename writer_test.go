@@ -0,0 +1,179 @@
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCheckerResult() *CheckerResult {
+	return &CheckerResult{
+		Status: StatusDown,
+		Info:   map[string]interface{}{"version": "v1.0.0"},
+		Details: map[string]CheckResult{
+			"database": {Status: StatusDown, Timestamp: time.Date(2021, 7, 1, 8, 5, 14, 0, time.UTC), Error: fmt.Errorf("connection refused")},
+			"search":   {Status: StatusUp, Data: map[string]interface{}{"version": "7.10"}},
+		},
+	}
+}
+
+func TestXMLResultWriter(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+
+	// Act
+	err := NewXMLResultWriter().Write(testCheckerResult(), http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<health status="down"><info><entry key="version">v1.0.0</entry></info><details>`+
+		`<component name="database" status="down" timestamp="2021-07-01T08:05:14Z"><error>connection refused</error></component>`+
+		`<component name="search" status="up"><data><entry key="version">7.10</entry></data></component></details></health>`, w.Body.String())
+}
+
+func TestYAMLResultWriter(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+
+	// Act
+	err := NewYAMLResultWriter().Write(testCheckerResult(), http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `info:
+    version: v1.0.0
+status: down
+details:
+    database:
+        status: down
+        timestamp: 2021-07-01T08:05:14Z
+        error: connection refused
+    search:
+        status: up
+        data:
+            version: "7.10"
+`, w.Body.String())
+}
+
+func TestTextResultWriter(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+
+	// Act
+	err := NewTextResultWriter().Write(testCheckerResult(), http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "down", w.Body.String())
+}
+
+func TestOKFailTextResultWriter(t *testing.T) {
+	// Arrange
+	okResponse := httptest.NewRecorder()
+	failResponse := httptest.NewRecorder()
+	writer := NewOKFailTextResultWriter()
+
+	// Act
+	_ = writer.Write(&CheckerResult{Status: StatusUp}, http.StatusOK, okResponse, httptest.NewRequest(http.MethodGet, "/", nil))
+	_ = writer.Write(testCheckerResult(), http.StatusServiceUnavailable, failResponse, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	assert.Equal(t, "OK", okResponse.Body.String())
+	assert.Equal(t, "FAIL", failResponse.Body.String())
+}
+
+func TestCompactJSONResultWriter(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+
+	// Act
+	err := NewCompactJSONResultWriter().Write(testCheckerResult(), http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"status":"down","details":{"database":"down","search":"up"}}`, w.Body.String())
+}
+
+func TestJSONResultWriterWithFieldNamesAndStatusMapper(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	writer := NewJSONResultWriter(
+		WithJSONFieldNames(map[string]string{"status": "state", "details": "components"}),
+		WithJSONStatusMapper(func(status AvailabilityStatus) string {
+			return strings.ToUpper(string(status))
+		}),
+	)
+	result := testCheckerResult()
+	result.Details["database"] = CheckResult{Status: StatusDown}
+
+	// Act
+	err := writer.Write(result, http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"state": "DOWN",
+		"info": {"version": "v1.0.0"},
+		"components": {
+			"database": {"state": "DOWN", "timestamp": "0001-01-01T00:00:00Z"},
+			"search": {"state": "UP", "timestamp": "0001-01-01T00:00:00Z", "data": {"version": "7.10"}}
+		}
+	}`, w.Body.String())
+}
+
+func TestJSONResultWriterWithResultTransformer(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	writer := NewJSONResultWriter(WithResultTransformer(func(result *CheckerResult) interface{} {
+		return map[string]bool{"healthy": result.Status == StatusUp}
+	}))
+
+	// Act
+	err := writer.Write(testCheckerResult(), http.StatusServiceUnavailable, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"healthy":false}`, w.Body.String())
+}
+
+func TestPrometheusResultWriter(t *testing.T) {
+	// Arrange
+	w := httptest.NewRecorder()
+	result := testCheckerResult()
+	result.Details["search"] = CheckResult{Status: StatusUp, Duration: 250 * time.Millisecond}
+	result.Details[`cache "eu"`] = CheckResult{Status: StatusDegraded}
+
+	// Act
+	err := NewPrometheusResultWriter().Write(result, http.StatusOK, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, PrometheusContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "# HELP health_status Whether the aggregated system health status is up (1) or not (0).\n"+
+		"# TYPE health_status gauge\n"+
+		"health_status 0\n"+
+		"# HELP health_check_status Whether the health check is up (1) or not (0).\n"+
+		"# TYPE health_check_status gauge\n"+
+		"health_check_status{check=\"cache \\\"eu\\\"\"} 0\n"+
+		"health_check_status{check=\"database\"} 0\n"+
+		"health_check_status{check=\"search\"} 1\n"+
+		"# HELP health_check_duration_seconds The duration of the last health check execution in seconds.\n"+
+		"# TYPE health_check_duration_seconds gauge\n"+
+		"health_check_duration_seconds{check=\"search\"} 0.25\n", w.Body.String())
+}